@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"task2/internal/adapter/repository"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/service"
+	"task2/internal/infrastructure/config"
+	"task2/internal/infrastructure/dependencies"
+)
+
+// admin changes a user's role from the command line, for example to make the first admin of an existing deployment:
+//
+//	go run ./cmd/admin -email alice@example.com
+//	go run ./cmd/admin -email bob@example.com -role manager
+func main() {
+	logger := log.New(os.Stdout, "[ADMIN] ", log.LstdFlags)
+
+	email := flag.String("email", "", "email of the user whose role to change")
+	role := flag.String("role", string(entity.RoleAdmin), "role to give the user: admin, manager, member or guest")
+	flag.Parse()
+
+	if *email == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if !entity.Role(*role).IsValid() {
+		logger.Fatalf("Invalid role %q", *role)
+	}
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		logger.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Initialize dependencies
+	deps, err := dependencies.NewDependencies(cfg)
+	if err != nil {
+		logger.Fatalf("Failed to initialize dependencies: %v", err)
+	}
+	defer deps.Close()
+
+	userRepo := repository.NewUserRepository(deps.DB)
+	userService := service.NewUserService(userRepo, repository.NewTransactor(deps.DB))
+
+	ctx := context.Background()
+	user, err := userService.GetUserByEmail(ctx, *email)
+	if err != nil {
+		logger.Fatalf("User %s not found", *email)
+	}
+
+	user, err = userService.ChangeRole(ctx, user.UUID, entity.Role(*role))
+	if err != nil {
+		logger.Fatalf("Failed to change role: %v", err)
+	}
+
+	logger.Printf("%s is now %s", user.Email, user.Role)
+}
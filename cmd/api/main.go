@@ -17,6 +17,8 @@ import (
 	"task2/internal/infrastructure/dependencies"
 	"task2/internal/infrastructure/middleware"
 	"task2/internal/infrastructure/router"
+	"task2/internal/infrastructure/scheduler"
+	"task2/pkg/markdown"
 )
 
 func main() {
@@ -47,43 +49,137 @@ func main() {
 	logger.Println("Creating repositories...")
 	userRepo := repository.NewUserRepository(deps.DB)
 	taskRepo := repository.NewTaskRepository(deps.DB)
+	taskRepo.SetKeyPrefix(cfg.TaskKeyPrefix)
+	notificationRepo := repository.NewNotificationRepository(deps.DB)
+	automationRepo := repository.NewAutomationRepository(deps.DB)
+	savedViewRepo := repository.NewSavedViewRepository(deps.DB)
+	reminderRepo := repository.NewReminderRepository(deps.DB)
+	emailOutboxRepo := repository.NewEmailOutboxRepository(deps.DB)
+	transferRepo := repository.NewTransferRepository(deps.DB)
+	approvalRepo := repository.NewApprovalRepository(deps.DB)
+	linkRepo := repository.NewTaskLinkRepository(deps.DB)
+	snoozeRepo := repository.NewSnoozeRepository(deps.DB)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(deps.DB)
+	tokenRevocationRepo := repository.NewTokenRevocationRepository(deps.DB)
+	passwordResetTokenRepo := repository.NewPasswordResetTokenRepository(deps.DB)
+	personalAccessTokenRepo := repository.NewPersonalAccessTokenRepository(deps.DB)
+	customFieldRepo := repository.NewCustomFieldRepository(deps.DB)
+	transactor := repository.NewTransactor(deps.DB)
 	
 	// Create domain services
 	logger.Println("Creating domain services...")
-	userService := service.NewUserService(userRepo)
-	taskService := service.NewTaskService(taskRepo, userRepo)
+	userService := service.NewUserService(userRepo, transactor)
+	taskService := service.NewTaskService(taskRepo, userRepo, approvalRepo, linkRepo, transactor)
+	notificationService := service.NewNotificationService(notificationRepo)
+	automationService := service.NewAutomationService(automationRepo)
+	savedViewService := service.NewSavedViewService(savedViewRepo, taskRepo, customFieldRepo)
+	reminderService := service.NewReminderService(reminderRepo, taskRepo, transactor)
+	emailOutboxService := service.NewEmailOutboxService(emailOutboxRepo, transactor)
+	transferService := service.NewTransferService(transferRepo, taskRepo, userRepo, transactor)
+	snoozeService := service.NewSnoozeService(snoozeRepo, taskRepo, transactor)
+	refreshTokenService := service.NewRefreshTokenService(refreshTokenRepo, transactor)
+	refreshTokenService.SetTTL(cfg.RefreshTokenTTL)
+	passwordResetService := service.NewPasswordResetService(passwordResetTokenRepo, userRepo, transactor)
+	personalAccessTokenService := service.NewPersonalAccessTokenService(personalAccessTokenRepo, userRepo, transactor)
+	customFieldService := service.NewCustomFieldService(customFieldRepo, savedViewRepo, taskRepo, userRepo, transactor)
 	
 	// Create auth service
 	logger.Println("Creating auth service...")
 	authService := auth.NewAuthService(cfg.JWTSecret)
+	authService.SetAccessTokenTTL(cfg.AccessTokenTTL)
+	revocationStore := auth.NewRevocationStore(tokenRevocationRepo)
 	
 	// Create use cases
 	logger.Println("Creating use cases...")
-	userUseCase := usecase.NewUserUseCase(userService, authService)
+	userUseCase := usecase.NewUserUseCase(userService, refreshTokenService, passwordResetService, authService, revocationStore)
 	userUseCase.SetEmailService(deps.EmailClient)
+	userUseCase.SetRequireVerifiedLogin(cfg.RequireVerifiedEmailForLogin)
+	userUseCase.SetAppBaseURL(cfg.AppBaseURL)
+	if cfg.AppBaseURL == "" {
+		logger.Println("Warning: APP_BASE_URL is not set, so no verification emails will be sent and calendar feeds are disabled")
+	}
+	if cfg.PasswordResetURL == "" {
+		logger.Println("Warning: PASSWORD_RESET_URL is not set, so password reset is disabled")
+	}
+	userUseCase.SetPasswordResetURL(cfg.PasswordResetURL)
+	notificationUseCase := usecase.NewNotificationUseCase(notificationService, userService, emailOutboxService)
+	notificationUseCase.SetEmailService(deps.EmailClient)
 	taskUseCase := usecase.NewTaskUseCase(taskService, userService)
+	taskUseCase.SetNotificationUseCase(notificationUseCase)
+	markdownRenderer := markdown.NewRenderer(cfg.MarkdownAllowedTags, cfg.MarkdownAllowedAttributes)
+	taskUseCase.SetMarkdownRenderer(markdownRenderer)
+	taskUseCase.SetTrashRetention(cfg.TrashRetention)
+	taskUseCase.SetSnoozeService(snoozeService)
+	taskUseCase.SetSavedViewService(savedViewService)
+	calendarUseCase := usecase.NewCalendarUseCase(taskService, userService)
+	calendarUseCase.SetAppBaseURL(cfg.AppBaseURL)
+	automationUseCase := usecase.NewAutomationUseCase(automationService, taskService, notificationUseCase)
+	taskService.Subscribe(automationUseCase.HandleTaskEvent)
+	savedViewUseCase := usecase.NewSavedViewUseCase(savedViewService)
+	savedViewUseCase.SetMarkdownRenderer(markdownRenderer)
+	reminderUseCase := usecase.NewReminderUseCase(reminderService, notificationUseCase)
+	transferUseCase := usecase.NewTransferUseCase(transferService, taskService, notificationUseCase)
+	snoozeUseCase := usecase.NewSnoozeUseCase(snoozeService, notificationUseCase)
+	personalAccessTokenUseCase := usecase.NewPersonalAccessTokenUseCase(personalAccessTokenService)
+	customFieldUseCase := usecase.NewCustomFieldUseCase(customFieldService)
+	customFieldUseCase.SetMarkdownRenderer(markdownRenderer)
 	
 	// Create controllers
 	logger.Println("Creating controllers...")
 	userController := controller.NewUserController(userUseCase)
 	taskController := controller.NewTaskController(taskUseCase)
+	calendarController := controller.NewCalendarController(calendarUseCase)
+	notificationController := controller.NewNotificationController(notificationUseCase)
+	automationController := controller.NewAutomationController(automationUseCase)
+	savedViewController := controller.NewSavedViewController(savedViewUseCase)
+	reminderController := controller.NewReminderController(reminderUseCase)
+	transferController := controller.NewTransferController(transferUseCase)
+	snoozeController := controller.NewSnoozeController(snoozeUseCase)
+	personalAccessTokenController := controller.NewPersonalAccessTokenController(personalAccessTokenUseCase)
+	customFieldController := controller.NewCustomFieldController(customFieldUseCase)
 	
 	// Create middleware
 	logger.Println("Creating middleware...")
-	authMiddleware := middleware.NewAuthMiddleware(authService)
+	authMiddleware := middleware.NewAuthMiddleware(authService, revocationStore)
+	authMiddleware.SetPersonalAccessTokenAuthenticator(personalAccessTokenUseCase.Authenticate)
 	loggingMiddleware := middleware.NewLoggingMiddleware(logger)
 	corsMiddleware := middleware.NewCorsMiddleware(logger)
+	taskKeyMiddleware := middleware.NewTaskKeyMiddleware(taskService.ResolveTaskKey)
+	permissionMiddleware := middleware.NewPermissionMiddleware(userService.GetActiveRole)
 	
 	// Create router
 	logger.Println("Setting up router...")
-	r := router.NewRouter(authMiddleware)
+	r := router.NewRouter(authMiddleware, permissionMiddleware)
 	r.SetLoggingMiddleware(loggingMiddleware)
 	r.SetCorsMiddleware(corsMiddleware)
+	r.SetTaskKeyMiddleware(taskKeyMiddleware)
+	if cfg.RequireVerifiedEmailForSensitive {
+		r.SetVerifiedEmailMiddleware(middleware.NewVerifiedEmailMiddleware(userService.IsEmailVerified))
+	}
 	
 	// Register routes
 	logger.Println("Registering routes...")
 	r.RegisterUserRoutes(userController)
 	r.RegisterTaskRoutes(taskController)
+	r.RegisterCalendarRoutes(calendarController)
+	r.RegisterNotificationRoutes(notificationController)
+	r.RegisterAutomationRoutes(automationController)
+	r.RegisterSavedViewRoutes(savedViewController)
+	r.RegisterReminderRoutes(reminderController)
+	r.RegisterTransferRoutes(transferController)
+	r.RegisterSnoozeRoutes(snoozeController)
+	r.RegisterPersonalAccessTokenRoutes(personalAccessTokenController)
+	r.RegisterCustomFieldRoutes(customFieldController)
+	
+	// Create background jobs
+	logger.Println("Creating background jobs...")
+	jobs := scheduler.NewScheduler(logger)
+	jobs.Every("automation-overdue", time.Minute, automationUseCase.RunOverdueRules)
+	jobs.Every("reminders", 30*time.Second, reminderUseCase.DeliverDueReminders)
+	jobs.Every("email-outbox", 30*time.Second, notificationUseCase.SendQueuedEmails)
+	jobs.Every("trash-purge", time.Hour, taskUseCase.PurgeExpiredTasks)
+	jobs.Every("snooze-wake", time.Minute, snoozeUseCase.WakeDueSnoozes)
+	jobs.Every("revoked-token-purge", time.Hour, revocationStore.PurgeExpired)
 	
 	// Create server
 	port := cfg.Port
@@ -105,12 +201,16 @@ func main() {
 		}
 	}()
 	
+	// Start background jobs
+	jobs.Start()
+	
 	// Wait for interrupt signal to gracefully shut down the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	
 	logger.Println("Server shutting down...")
+	jobs.Stop()
 	
 	// Create a deadline for server shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -138,4 +238,4 @@ func maskString(s string) string {
 		return "***"
 	}
 	return s[:2] + "..." + s[len(s)-2:]
-}
\ No newline at end of file
+}
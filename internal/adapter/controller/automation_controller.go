@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+	"task2/internal/app/dto"
+	"task2/internal/app/usecase"
+	"task2/internal/infrastructure/middleware"
+	"task2/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// AutomationController handles HTTP requests for automation rules
+type AutomationController struct {
+	automationUseCase *usecase.AutomationUseCase
+}
+
+// NewAutomationController creates a new automation controller
+func NewAutomationController(automationUseCase *usecase.AutomationUseCase) *AutomationController {
+	return &AutomationController{
+		automationUseCase: automationUseCase,
+	}
+}
+
+// CreateRule handles creating an automation rule
+func (c *AutomationController) CreateRule(w http.ResponseWriter, r *http.Request) {
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.AutomationRuleRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	// Get user UUID from context
+	userUUID := utils.GetUserUUIDFromRequest(r)
+	
+	// Create rule
+	rule, err := c.automationUseCase.CreateRule(r.Context(), req, userUUID)
+	if err != nil {
+		utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusCreated, "Automation rule created successfully", map[string]interface{}{"rule": rule})
+}
+
+// GetRules handles listing the current user's automation rules
+func (c *AutomationController) GetRules(w http.ResponseWriter, r *http.Request) {
+	// Get user UUID from context
+	userUUID := utils.GetUserUUIDFromRequest(r)
+	
+	// Get rules
+	rulesResp, err := c.automationUseCase.GetRules(r.Context(), userUUID)
+	if err != nil {
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to get automation rules", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"rules": rulesResp.Rules})
+}
+
+// GetRule handles getting one of the current user's automation rules
+func (c *AutomationController) GetRule(w http.ResponseWriter, r *http.Request) {
+	ruleUUID, ok := parseRuleUUID(w, r, "")
+	if !ok {
+		return
+	}
+	
+	// Get rule
+	rule, err := c.automationUseCase.GetRule(r.Context(), ruleUUID, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		utils.RespondJSON(w, http.StatusNotFound, "Automation rule not found", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"rule": rule})
+}
+
+// UpdateRule handles replacing one of the current user's automation rules
+func (c *AutomationController) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	ruleUUID, ok := parseRuleUUID(w, r, "")
+	if !ok {
+		return
+	}
+	
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.AutomationRuleRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	// Update rule
+	rule, err := c.automationUseCase.UpdateRule(r.Context(), ruleUUID, req, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		if err.Error() == "rule not found" {
+			utils.RespondJSON(w, http.StatusNotFound, "Automation rule not found", nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "Automation rule updated successfully", map[string]interface{}{"rule": rule})
+}
+
+// DeleteRule handles deleting one of the current user's automation rules
+func (c *AutomationController) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	ruleUUID, ok := parseRuleUUID(w, r, "")
+	if !ok {
+		return
+	}
+	
+	// Delete rule
+	if err := c.automationUseCase.DeleteRule(r.Context(), ruleUUID, utils.GetUserUUIDFromRequest(r)); err != nil {
+		if err.Error() == "rule not found" {
+			utils.RespondJSON(w, http.StatusNotFound, "Automation rule not found", nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to delete automation rule", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "Automation rule deleted successfully", nil)
+}
+
+// GetExecutions handles getting the execution log of one of the current user's automation rules
+func (c *AutomationController) GetExecutions(w http.ResponseWriter, r *http.Request) {
+	ruleUUID, ok := parseRuleUUID(w, r, "/executions")
+	if !ok {
+		return
+	}
+	
+	// Get executions
+	executions, err := c.automationUseCase.GetExecutions(r.Context(), ruleUUID, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		utils.RespondJSON(w, http.StatusNotFound, "Automation rule not found", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"executions": executions})
+}
+
+// parseRuleUUID extracts the rule UUID from the request path, writing a 400 response if it is invalid
+func parseRuleUUID(w http.ResponseWriter, r *http.Request, suffix string) (uuid.UUID, bool) {
+	uuidStr := strings.TrimPrefix(r.URL.Path, "/api/v1/automations/")
+	uuidStr = strings.TrimSuffix(uuidStr, suffix)
+	ruleUUID, err := uuid.Parse(uuidStr)
+	if err != nil {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid rule UUID", nil)
+		return uuid.Nil, false
+	}
+	
+	return ruleUUID, true
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"task2/internal/adapter/presenter"
+	"task2/internal/app/usecase"
+	"task2/pkg/utils"
+)
+
+// CalendarController handles HTTP requests for calendar feeds
+type CalendarController struct {
+	calendarUseCase *usecase.CalendarUseCase
+}
+
+// NewCalendarController creates a new calendar controller
+func NewCalendarController(calendarUseCase *usecase.CalendarUseCase) *CalendarController {
+	return &CalendarController{
+		calendarUseCase: calendarUseCase,
+	}
+}
+
+// GetFeed handles serving a user's calendar feed, authenticated by the token in the path
+func (c *CalendarController) GetFeed(w http.ResponseWriter, r *http.Request) {
+	// Extract token from path
+	name := strings.TrimPrefix(r.URL.Path, "/api/v1/calendar/")
+	if !strings.HasSuffix(name, ".ics") {
+		utils.RespondJSON(w, http.StatusNotFound, "Calendar feed not found", nil)
+		return
+	}
+	token := strings.TrimSuffix(name, ".ics")
+	if token == "" {
+		utils.RespondJSON(w, http.StatusNotFound, "Calendar feed not found", nil)
+		return
+	}
+	
+	// Get component type
+	component := r.URL.Query().Get("type")
+	if component == "" {
+		component = presenter.CalendarEvent
+	}
+	if component != presenter.CalendarEvent && component != presenter.CalendarTodo {
+		utils.RespondJSON(w, http.StatusBadRequest, "type must be event or todo", nil)
+		return
+	}
+	
+	// Render feed
+	feed, err := c.calendarUseCase.GetFeed(r.Context(), token, component)
+	if err != nil {
+		utils.RespondJSON(w, http.StatusNotFound, "Calendar feed not found", nil)
+		return
+	}
+	
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="tasks.ics"`)
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.WriteString(w, feed); err != nil {
+		log.Printf("GetFeed: Failed to write feed: %v", err)
+	}
+}
+
+// RegenerateToken handles issuing a new calendar feed token for the current user
+func (c *CalendarController) RegenerateToken(w http.ResponseWriter, r *http.Request) {
+	// Get user UUID from context
+	userUUID := utils.GetUserUUIDFromRequest(r)
+	
+	// Regenerate token
+	tokenResp, err := c.calendarUseCase.RegenerateToken(r.Context(), userUUID)
+	if err != nil {
+		if err.Error() == "calendar feeds are not configured" {
+			utils.RespondJSON(w, http.StatusServiceUnavailable, err.Error(), nil)
+		} else {
+			utils.RespondJSON(w, http.StatusInternalServerError, "Failed to regenerate calendar token", nil)
+		}
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"calendar": tokenResp})
+}
@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"task2/internal/app/dto"
+	"task2/internal/app/usecase"
+	"task2/internal/domain/entity"
+	"task2/internal/infrastructure/middleware"
+	"task2/pkg/utils"
+)
+
+// CustomFieldController handles HTTP requests for custom fields
+type CustomFieldController struct {
+	customFieldUseCase *usecase.CustomFieldUseCase
+}
+
+// NewCustomFieldController creates a new custom field controller
+func NewCustomFieldController(customFieldUseCase *usecase.CustomFieldUseCase) *CustomFieldController {
+	return &CustomFieldController{
+		customFieldUseCase: customFieldUseCase,
+	}
+}
+
+// CreateField handles creating a custom field
+func (c *CustomFieldController) CreateField(w http.ResponseWriter, r *http.Request) {
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.CustomFieldRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	// Create field
+	field, err := c.customFieldUseCase.CreateField(r.Context(), req, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusCreated, "Custom field created successfully", map[string]interface{}{"field": field})
+}
+
+// GetFields handles listing the current user's custom fields
+func (c *CustomFieldController) GetFields(w http.ResponseWriter, r *http.Request) {
+	fieldsResp, err := c.customFieldUseCase.GetFields(r.Context(), utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to get custom fields", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"fields": fieldsResp.Fields})
+}
+
+// GetField handles getting one of the current user's custom fields
+func (c *CustomFieldController) GetField(w http.ResponseWriter, r *http.Request) {
+	fieldUUID, ok := parsePathUUID(w, r, "id", "Invalid field UUID")
+	if !ok {
+		return
+	}
+	
+	field, err := c.customFieldUseCase.GetField(r.Context(), fieldUUID, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		utils.RespondJSON(w, http.StatusNotFound, "Custom field not found", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"field": field})
+}
+
+// UpdateField handles renaming one of the current user's custom fields or changing its options
+func (c *CustomFieldController) UpdateField(w http.ResponseWriter, r *http.Request) {
+	fieldUUID, ok := parsePathUUID(w, r, "id", "Invalid field UUID")
+	if !ok {
+		return
+	}
+	
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.UpdateCustomFieldRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	// Update field
+	field, err := c.customFieldUseCase.UpdateField(r.Context(), fieldUUID, req, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		if err.Error() == "custom field not found" {
+			utils.RespondJSON(w, http.StatusNotFound, "Custom field not found", nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "Custom field updated successfully", map[string]interface{}{"field": field})
+}
+
+// DeleteField handles deleting one of the current user's custom fields
+func (c *CustomFieldController) DeleteField(w http.ResponseWriter, r *http.Request) {
+	fieldUUID, ok := parsePathUUID(w, r, "id", "Invalid field UUID")
+	if !ok {
+		return
+	}
+	
+	if err := c.customFieldUseCase.DeleteField(r.Context(), fieldUUID, utils.GetUserUUIDFromRequest(r)); err != nil {
+		if err.Error() == "custom field not found" {
+			utils.RespondJSON(w, http.StatusNotFound, "Custom field not found", nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to delete custom field", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "Custom field deleted successfully", nil)
+}
+
+// SetValue handles setting a custom field on a task
+func (c *CustomFieldController) SetValue(w http.ResponseWriter, r *http.Request) {
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.SetCustomFieldValueRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	c.setValue(w, r, req.Value, "Custom field set successfully")
+}
+
+// ClearValue handles clearing a custom field on a task
+func (c *CustomFieldController) ClearValue(w http.ResponseWriter, r *http.Request) {
+	c.setValue(w, r, nil, "Custom field cleared successfully")
+}
+
+// setValue sets or, for a nil value, clears the custom field in the request path on the task in the path
+func (c *CustomFieldController) setValue(w http.ResponseWriter, r *http.Request, value any, message string) {
+	taskUUID, ok := parsePathUUID(w, r, "id", "Invalid task UUID")
+	if !ok {
+		return
+	}
+	fieldUUID, ok := parsePathUUID(w, r, "fieldID", "Invalid field UUID")
+	if !ok {
+		return
+	}
+	
+	task, err := c.customFieldUseCase.SetValue(r.Context(), taskUUID, fieldUUID, value, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrVersionConflict):
+			utils.RespondJSON(w, http.StatusPreconditionFailed, err.Error(), nil)
+		case err.Error() == "task not found" || err.Error() == "custom field not found":
+			utils.RespondJSON(w, http.StatusNotFound, err.Error(), nil)
+		default:
+			utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		}
+		return
+	}
+	
+	w.Header().Set("ETag", utils.ETag(task.Version))
+	utils.RespondJSON(w, http.StatusOK, message, map[string]interface{}{"task": task})
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+	"task2/internal/app/usecase"
+	"task2/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// NotificationController handles HTTP requests for notifications
+type NotificationController struct {
+	notificationUseCase *usecase.NotificationUseCase
+}
+
+// NewNotificationController creates a new notification controller
+func NewNotificationController(notificationUseCase *usecase.NotificationUseCase) *NotificationController {
+	return &NotificationController{
+		notificationUseCase: notificationUseCase,
+	}
+}
+
+// GetNotifications handles listing the current user's notifications
+func (c *NotificationController) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	// Get user UUID from context
+	userUUID := utils.GetUserUUIDFromRequest(r)
+	
+	// Get notifications
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+	notificationsResp, err := c.notificationUseCase.GetNotifications(r.Context(), userUUID, unreadOnly)
+	if err != nil {
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to get notifications", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{
+		"notifications": notificationsResp.Notifications,
+		"unread":        notificationsResp.Unread,
+	})
+}
+
+// MarkRead handles marking one of the current user's notifications as read
+func (c *NotificationController) MarkRead(w http.ResponseWriter, r *http.Request) {
+	// Extract notification UUID from path
+	uuidStr := strings.TrimPrefix(r.URL.Path, "/api/v1/notifications/")
+	uuidStr = strings.TrimSuffix(uuidStr, "/read")
+	notificationUUID, err := uuid.Parse(uuidStr)
+	if err != nil {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid notification UUID", nil)
+		return
+	}
+	
+	// Get user UUID from context
+	userUUID := utils.GetUserUUIDFromRequest(r)
+	
+	// Mark notification as read
+	notification, err := c.notificationUseCase.MarkRead(r.Context(), notificationUUID, userUUID)
+	if err != nil {
+		if err.Error() == "notification not found" {
+			utils.RespondJSON(w, http.StatusNotFound, "Notification not found", nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to mark notification as read", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "Notification marked as read", map[string]interface{}{"notification": notification})
+}
+
+// MarkAllRead handles marking all of the current user's notifications as read
+func (c *NotificationController) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	// Get user UUID from context
+	userUUID := utils.GetUserUUIDFromRequest(r)
+	
+	if err := c.notificationUseCase.MarkAllRead(r.Context(), userUUID); err != nil {
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to mark notifications as read", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "Notifications marked as read", nil)
+}
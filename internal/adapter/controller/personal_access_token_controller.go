@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+	"task2/internal/app/dto"
+	"task2/internal/app/usecase"
+	"task2/internal/infrastructure/middleware"
+	"task2/pkg/utils"
+)
+
+// PersonalAccessTokenController handles HTTP requests for personal access tokens
+type PersonalAccessTokenController struct {
+	tokenUseCase *usecase.PersonalAccessTokenUseCase
+}
+
+// NewPersonalAccessTokenController creates a new personal access token controller
+func NewPersonalAccessTokenController(tokenUseCase *usecase.PersonalAccessTokenUseCase) *PersonalAccessTokenController {
+	return &PersonalAccessTokenController{
+		tokenUseCase: tokenUseCase,
+	}
+}
+
+// CreateToken handles creating a personal access token
+func (c *PersonalAccessTokenController) CreateToken(w http.ResponseWriter, r *http.Request) {
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.CreatePersonalAccessTokenRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	token, err := c.tokenUseCase.CreateToken(r.Context(), utils.GetUserUUIDFromRequest(r), req)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "is not allowed for your role") {
+			utils.RespondJSON(w, http.StatusForbidden, err.Error(), nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusCreated, "Token created; copy it now, it will not be shown again", map[string]interface{}{"token": token})
+}
+
+// GetTokens handles listing the current user's personal access tokens
+func (c *PersonalAccessTokenController) GetTokens(w http.ResponseWriter, r *http.Request) {
+	tokensResp, err := c.tokenUseCase.GetTokens(r.Context(), utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to get tokens", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"tokens": tokensResp.Tokens})
+}
+
+// RevokeToken handles revoking one of the current user's personal access tokens
+func (c *PersonalAccessTokenController) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	tokenUUID, ok := parsePathUUID(w, r, "id", "Invalid token UUID")
+	if !ok {
+		return
+	}
+	
+	if err := c.tokenUseCase.RevokeToken(r.Context(), tokenUUID, utils.GetUserUUIDFromRequest(r)); err != nil {
+		if err.Error() == "token not found" {
+			utils.RespondJSON(w, http.StatusNotFound, "Token not found", nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to revoke token", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "Token revoked", nil)
+}
+
+// RotateToken handles giving one of the current user's personal access tokens a new secret
+func (c *PersonalAccessTokenController) RotateToken(w http.ResponseWriter, r *http.Request) {
+	tokenUUID, ok := parsePathUUID(w, r, "id", "Invalid token UUID")
+	if !ok {
+		return
+	}
+	
+	token, err := c.tokenUseCase.RotateToken(r.Context(), tokenUUID, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		switch err.Error() {
+		case "token not found":
+			utils.RespondJSON(w, http.StatusNotFound, "Token not found", nil)
+		case "token has expired":
+			utils.RespondJSON(w, http.StatusConflict, err.Error(), nil)
+		default:
+			utils.RespondJSON(w, http.StatusInternalServerError, "Failed to rotate token", nil)
+		}
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "Token rotated; copy it now, it will not be shown again", map[string]interface{}{"token": token})
+}
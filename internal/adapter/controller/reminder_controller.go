@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"net/http"
+	"task2/internal/app/dto"
+	"task2/internal/app/usecase"
+	"task2/internal/infrastructure/middleware"
+	"task2/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// ReminderController handles HTTP requests for task reminders
+type ReminderController struct {
+	reminderUseCase *usecase.ReminderUseCase
+}
+
+// NewReminderController creates a new reminder controller
+func NewReminderController(reminderUseCase *usecase.ReminderUseCase) *ReminderController {
+	return &ReminderController{
+		reminderUseCase: reminderUseCase,
+	}
+}
+
+// CreateReminder handles adding a reminder to a task
+func (c *ReminderController) CreateReminder(w http.ResponseWriter, r *http.Request) {
+	taskUUID, ok := parsePathUUID(w, r, "id", "Invalid task UUID")
+	if !ok {
+		return
+	}
+	
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.ReminderRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	// Create reminder
+	reminder, err := c.reminderUseCase.CreateReminder(r.Context(), taskUUID, req, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		if err.Error() == "task not found" {
+			utils.RespondJSON(w, http.StatusNotFound, "Task not found", nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusCreated, "Reminder created successfully", map[string]interface{}{"reminder": reminder})
+}
+
+// GetReminders handles listing a task's reminders
+func (c *ReminderController) GetReminders(w http.ResponseWriter, r *http.Request) {
+	taskUUID, ok := parsePathUUID(w, r, "id", "Invalid task UUID")
+	if !ok {
+		return
+	}
+	
+	reminders, err := c.reminderUseCase.GetReminders(r.Context(), taskUUID, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		if err.Error() == "task not found" {
+			utils.RespondJSON(w, http.StatusNotFound, "Task not found", nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to get reminders", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"reminders": reminders})
+}
+
+// DeleteReminder handles removing a reminder from a task
+func (c *ReminderController) DeleteReminder(w http.ResponseWriter, r *http.Request) {
+	taskUUID, ok := parsePathUUID(w, r, "id", "Invalid task UUID")
+	if !ok {
+		return
+	}
+	
+	reminderUUID, ok := parsePathUUID(w, r, "reminderID", "Invalid reminder UUID")
+	if !ok {
+		return
+	}
+	
+	err := c.reminderUseCase.DeleteReminder(r.Context(), taskUUID, reminderUUID, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		switch err.Error() {
+		case "task not found":
+			utils.RespondJSON(w, http.StatusNotFound, "Task not found", nil)
+		case "reminder not found":
+			utils.RespondJSON(w, http.StatusNotFound, "Reminder not found", nil)
+		default:
+			utils.RespondJSON(w, http.StatusForbidden, err.Error(), nil)
+		}
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "Reminder deleted successfully", nil)
+}
+
+// parsePathUUID parses a UUID from a named path wildcard, responding with message if it is invalid
+func parsePathUUID(w http.ResponseWriter, r *http.Request, name string, message string) (uuid.UUID, bool) {
+	value, err := uuid.Parse(r.PathValue(name))
+	if err != nil {
+		utils.RespondJSON(w, http.StatusBadRequest, message, nil)
+		return uuid.Nil, false
+	}
+	
+	return value, true
+}
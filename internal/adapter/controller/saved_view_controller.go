@@ -0,0 +1,209 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+	"task2/internal/app/dto"
+	"task2/internal/app/usecase"
+	"task2/internal/infrastructure/middleware"
+	"task2/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// SavedViewController handles HTTP requests for saved views
+type SavedViewController struct {
+	savedViewUseCase *usecase.SavedViewUseCase
+}
+
+// NewSavedViewController creates a new saved view controller
+func NewSavedViewController(savedViewUseCase *usecase.SavedViewUseCase) *SavedViewController {
+	return &SavedViewController{
+		savedViewUseCase: savedViewUseCase,
+	}
+}
+
+// CreateView handles creating a saved view
+func (c *SavedViewController) CreateView(w http.ResponseWriter, r *http.Request) {
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.SavedViewRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	// Create view
+	view, err := c.savedViewUseCase.CreateView(r.Context(), req, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusCreated, "View created successfully", map[string]interface{}{"view": view})
+}
+
+// GetViews handles listing the current user's saved views
+func (c *SavedViewController) GetViews(w http.ResponseWriter, r *http.Request) {
+	viewsResp, err := c.savedViewUseCase.GetViews(r.Context(), utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to get views", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"views": viewsResp.Views})
+}
+
+// GetView handles getting one of the current user's saved views
+func (c *SavedViewController) GetView(w http.ResponseWriter, r *http.Request) {
+	viewUUID, ok := parseViewUUID(w, r, "")
+	if !ok {
+		return
+	}
+	
+	view, err := c.savedViewUseCase.GetView(r.Context(), viewUUID, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		utils.RespondJSON(w, http.StatusNotFound, "View not found", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"view": view})
+}
+
+// UpdateView handles replacing one of the current user's saved views
+func (c *SavedViewController) UpdateView(w http.ResponseWriter, r *http.Request) {
+	viewUUID, ok := parseViewUUID(w, r, "")
+	if !ok {
+		return
+	}
+	
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.SavedViewRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	// Update view
+	view, err := c.savedViewUseCase.UpdateView(r.Context(), viewUUID, req, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		if err.Error() == "view not found" {
+			utils.RespondJSON(w, http.StatusNotFound, "View not found", nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "View updated successfully", map[string]interface{}{"view": view})
+}
+
+// DeleteView handles deleting one of the current user's saved views
+func (c *SavedViewController) DeleteView(w http.ResponseWriter, r *http.Request) {
+	viewUUID, ok := parseViewUUID(w, r, "")
+	if !ok {
+		return
+	}
+	
+	if err := c.savedViewUseCase.DeleteView(r.Context(), viewUUID, utils.GetUserUUIDFromRequest(r)); err != nil {
+		if err.Error() == "view not found" {
+			utils.RespondJSON(w, http.StatusNotFound, "View not found", nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to delete view", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "View deleted successfully", nil)
+}
+
+// PinView handles making a saved view the current user's default task list
+func (c *SavedViewController) PinView(w http.ResponseWriter, r *http.Request) {
+	viewUUID, ok := parseViewUUID(w, r, "/pin")
+	if !ok {
+		return
+	}
+	
+	view, err := c.savedViewUseCase.PinView(r.Context(), viewUUID, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		if err.Error() == "view not found" {
+			utils.RespondJSON(w, http.StatusNotFound, "View not found", nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to pin view", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "View pinned successfully", map[string]interface{}{"view": view})
+}
+
+// UnpinView handles removing a saved view as the current user's default task list
+func (c *SavedViewController) UnpinView(w http.ResponseWriter, r *http.Request) {
+	viewUUID, ok := parseViewUUID(w, r, "/pin")
+	if !ok {
+		return
+	}
+	
+	view, err := c.savedViewUseCase.UnpinView(r.Context(), viewUUID, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		if err.Error() == "view not found" {
+			utils.RespondJSON(w, http.StatusNotFound, "View not found", nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to unpin view", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "View unpinned successfully", map[string]interface{}{"view": view})
+}
+
+// GetViewTasks handles running one of the current user's saved views
+func (c *SavedViewController) GetViewTasks(w http.ResponseWriter, r *http.Request) {
+	viewUUID, ok := parseViewUUID(w, r, "/tasks")
+	if !ok {
+		return
+	}
+	
+	tasksResp, err := c.savedViewUseCase.GetViewTasks(r.Context(), viewUUID, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		if err.Error() == "view not found" {
+			utils.RespondJSON(w, http.StatusNotFound, "View not found", nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to get view tasks", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{
+		"view":    tasksResp.View,
+		"columns": tasksResp.Columns,
+		"tasks":   tasksResp.Tasks,
+	})
+}
+
+// GetDefaultTasks handles getting the current user's default task list
+func (c *SavedViewController) GetDefaultTasks(w http.ResponseWriter, r *http.Request) {
+	tasksResp, err := c.savedViewUseCase.GetDefaultTasks(r.Context(), utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to get tasks", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{
+		"view":    tasksResp.View,
+		"columns": tasksResp.Columns,
+		"tasks":   tasksResp.Tasks,
+	})
+}
+
+// parseViewUUID extracts the view UUID from the request path, writing a 400 response if it is invalid
+func parseViewUUID(w http.ResponseWriter, r *http.Request, suffix string) (uuid.UUID, bool) {
+	uuidStr := strings.TrimPrefix(r.URL.Path, "/api/v1/views/")
+	uuidStr = strings.TrimSuffix(uuidStr, suffix)
+	viewUUID, err := uuid.Parse(uuidStr)
+	if err != nil {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid view UUID", nil)
+		return uuid.Nil, false
+	}
+	
+	return viewUUID, true
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"net/http"
+	"task2/internal/app/dto"
+	"task2/internal/app/usecase"
+	"task2/internal/infrastructure/middleware"
+	"task2/pkg/utils"
+)
+
+// SnoozeController handles HTTP requests for snoozing tasks
+type SnoozeController struct {
+	snoozeUseCase *usecase.SnoozeUseCase
+}
+
+// NewSnoozeController creates a new snooze controller
+func NewSnoozeController(snoozeUseCase *usecase.SnoozeUseCase) *SnoozeController {
+	return &SnoozeController{
+		snoozeUseCase: snoozeUseCase,
+	}
+}
+
+// SnoozeTask handles snoozing a task for the current user
+func (c *SnoozeController) SnoozeTask(w http.ResponseWriter, r *http.Request) {
+	taskUUID, ok := parsePathUUID(w, r, "id", "Invalid task UUID")
+	if !ok {
+		return
+	}
+	
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.SnoozeRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	// Snooze task
+	snooze, err := c.snoozeUseCase.SnoozeTask(r.Context(), taskUUID, req, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		if err.Error() == "task not found" {
+			utils.RespondJSON(w, http.StatusNotFound, "Task not found", nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "Task snoozed successfully", map[string]interface{}{"snooze": snooze})
+}
+
+// UnsnoozeTask handles ending the current user's snooze of a task
+func (c *SnoozeController) UnsnoozeTask(w http.ResponseWriter, r *http.Request) {
+	taskUUID, ok := parsePathUUID(w, r, "id", "Invalid task UUID")
+	if !ok {
+		return
+	}
+	
+	if err := c.snoozeUseCase.UnsnoozeTask(r.Context(), taskUUID, utils.GetUserUUIDFromRequest(r)); err != nil {
+		if err.Error() == "task is not snoozed" {
+			utils.RespondJSON(w, http.StatusNotFound, err.Error(), nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to unsnooze task", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "Task unsnoozed successfully", nil)
+}
@@ -1,10 +1,17 @@
 package controller
 
 import (
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 	"task2/internal/app/dto"
 	"task2/internal/app/usecase"
+	"task2/internal/domain/entity"
 	"task2/internal/infrastructure/middleware"
 	"task2/pkg/utils"
 
@@ -47,6 +54,44 @@ func (c *TaskController) CreateTask(w http.ResponseWriter, r *http.Request) {
 	utils.RespondJSON(w, http.StatusCreated, "", map[string]interface{}{"task": task})
 }
 
+// QuickAddTask handles creating a task from a one-line description
+func (c *TaskController) QuickAddTask(w http.ResponseWriter, r *http.Request) {
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.QuickAddRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	// Parse dry_run flag
+	dryRun := false
+	if dryRunStr := r.URL.Query().Get("dry_run"); dryRunStr != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunStr)
+		if err != nil {
+			utils.RespondJSON(w, http.StatusBadRequest, "dry_run must be true or false", nil)
+			return
+		}
+	}
+	
+	// Quick-add task
+	resp, err := c.taskUseCase.QuickAddTask(r.Context(), req, dryRun, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	
+	if dryRun {
+		utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"interpretation": resp.Interpretation})
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusCreated, "", map[string]interface{}{
+		"task":           resp.Task,
+		"interpretation": resp.Interpretation,
+	})
+}
+
 // GetTaskByID handles getting a task by ID
 func (c *TaskController) GetTaskByID(w http.ResponseWriter, r *http.Request) {
 	// Extract task UUID from path
@@ -64,15 +109,29 @@ func (c *TaskController) GetTaskByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	
+	// Tag the response with the task version
+	etag := utils.ETag(task.Version)
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	
 	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"task": task})
 }
 
-// GetAllTasks handles getting all tasks
+// GetAllTasks handles getting all tasks, or the visible tasks matching the filter and sort in the query string
 func (c *TaskController) GetAllTasks(w http.ResponseWriter, r *http.Request) {
+	query, err := parseTaskListQuery(r)
+	if err != nil {
+		utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	
 	// Get all tasks
-	tasksResp, err := c.taskUseCase.GetAllTasks(r.Context())
+	tasksResp, err := c.taskUseCase.GetAllTasks(r.Context(), utils.GetUserUUIDFromRequest(r), query)
 	if err != nil {
-		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to fetch tasks", nil)
+		respondTaskListError(w, err, "Failed to fetch tasks")
 		return
 	}
 	
@@ -84,10 +143,16 @@ func (c *TaskController) GetTasksCreatedByUser(w http.ResponseWriter, r *http.Re
 	// Get user UUID from context
 	userUUID := utils.GetUserUUIDFromRequest(r)
 	
+	query, err := parseTaskListQuery(r)
+	if err != nil {
+		utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	
 	// Get tasks created by user
-	tasksResp, err := c.taskUseCase.GetTasksCreatedByUser(r.Context(), userUUID)
+	tasksResp, err := c.taskUseCase.GetTasksCreatedByUser(r.Context(), userUUID, query)
 	if err != nil {
-		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to fetch tasks created by user", nil)
+		respondTaskListError(w, err, "Failed to fetch tasks created by user")
 		return
 	}
 	
@@ -99,16 +164,44 @@ func (c *TaskController) GetTasksAssignedToUser(w http.ResponseWriter, r *http.R
 	// Get user UUID from context
 	userUUID := utils.GetUserUUIDFromRequest(r)
 	
+	// Parse include_snoozed flag
+	includeSnoozed := false
+	if includeSnoozedStr := r.URL.Query().Get("include_snoozed"); includeSnoozedStr != "" {
+		var err error
+		includeSnoozed, err = strconv.ParseBool(includeSnoozedStr)
+		if err != nil {
+			utils.RespondJSON(w, http.StatusBadRequest, "include_snoozed must be true or false", nil)
+			return
+		}
+	}
+	
+	query, err := parseTaskListQuery(r)
+	if err != nil {
+		utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	
 	// Get tasks assigned to user
-	tasksResp, err := c.taskUseCase.GetTasksAssignedToUser(r.Context(), userUUID)
+	tasksResp, err := c.taskUseCase.GetTasksAssignedToUser(r.Context(), userUUID, includeSnoozed, query)
 	if err != nil {
-		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to fetch tasks assigned to user", nil)
+		respondTaskListError(w, err, "Failed to fetch tasks assigned to user")
 		return
 	}
 	
 	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"tasks": tasksResp.Tasks})
 }
 
+// respondTaskListError responds to a failed task list, with a bad request for a filter or sort the user got wrong
+func respondTaskListError(w http.ResponseWriter, err error, message string) {
+	if errors.Is(err, entity.ErrInvalidTaskQuery) {
+		utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	
+	log.Printf("Failed to list tasks: %v", err)
+	utils.RespondJSON(w, http.StatusInternalServerError, message, nil)
+}
+
 // CompleteTask handles completing a task
 func (c *TaskController) CompleteTask(w http.ResponseWriter, r *http.Request) {
 	// Extract task UUID from path
@@ -126,13 +219,303 @@ func (c *TaskController) CompleteTask(w http.ResponseWriter, r *http.Request) {
 	// Complete task
 	task, err := c.taskUseCase.CompleteTask(r.Context(), taskUUID, userUUID)
 	if err != nil {
-		utils.RespondJSON(w, http.StatusForbidden, err.Error(), nil)
+		if errors.Is(err, entity.ErrVersionConflict) {
+			utils.RespondJSON(w, http.StatusPreconditionFailed, err.Error(), nil)
+		} else {
+			utils.RespondJSON(w, http.StatusForbidden, err.Error(), nil)
+		}
 		return
 	}
 	
+	w.Header().Set("ETag", utils.ETag(task.Version))
 	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"task": task})
 }
 
+// GetTasksAwaitingReview handles getting tasks awaiting the current user's approval
+func (c *TaskController) GetTasksAwaitingReview(w http.ResponseWriter, r *http.Request) {
+	// Get user UUID from context
+	userUUID := utils.GetUserUUIDFromRequest(r)
+	
+	// Get tasks awaiting review
+	tasksResp, err := c.taskUseCase.GetTasksAwaitingReview(r.Context(), userUUID)
+	if err != nil {
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to fetch tasks awaiting review", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"tasks": tasksResp.Tasks})
+}
+
+// SetReviewers handles setting who must approve a task before it completes
+func (c *TaskController) SetReviewers(w http.ResponseWriter, r *http.Request) {
+	taskUUID, ok := parseTaskUUID(w, r, "/reviewers")
+	if !ok {
+		return
+	}
+	
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.SetReviewersRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	// Set reviewers
+	task, err := c.taskUseCase.SetReviewers(r.Context(), taskUUID, req, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrVersionConflict):
+			utils.RespondJSON(w, http.StatusPreconditionFailed, err.Error(), nil)
+		case err.Error() == "task not found" || err.Error() == "user not found":
+			utils.RespondJSON(w, http.StatusNotFound, err.Error(), nil)
+		case err.Error() == "only the task creator can set reviewers":
+			utils.RespondJSON(w, http.StatusForbidden, err.Error(), nil)
+		default:
+			utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		}
+		return
+	}
+	
+	w.Header().Set("ETag", utils.ETag(task.Version))
+	utils.RespondJSON(w, http.StatusOK, "Reviewers updated successfully", map[string]interface{}{"task": task})
+}
+
+// ApproveTask handles a reviewer approving a task awaiting approval
+func (c *TaskController) ApproveTask(w http.ResponseWriter, r *http.Request) {
+	c.reviewTask(w, r, "/approve", entity.ApprovalDecisionApproved)
+}
+
+// RejectTask handles a reviewer rejecting a task awaiting approval
+func (c *TaskController) RejectTask(w http.ResponseWriter, r *http.Request) {
+	c.reviewTask(w, r, "/reject", entity.ApprovalDecisionRejected)
+}
+
+// reviewTask records the current user's decision on a task awaiting approval
+func (c *TaskController) reviewTask(w http.ResponseWriter, r *http.Request, suffix string, decision string) {
+	taskUUID, ok := parseTaskUUID(w, r, suffix)
+	if !ok {
+		return
+	}
+	
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.ReviewTaskRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	// Record decision
+	task, err := c.taskUseCase.ReviewTask(r.Context(), taskUUID, decision, req, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrVersionConflict):
+			utils.RespondJSON(w, http.StatusPreconditionFailed, err.Error(), nil)
+		case err.Error() == "task not found":
+			utils.RespondJSON(w, http.StatusNotFound, err.Error(), nil)
+		case err.Error() == "only reviewers can approve or reject this task" || err.Error() == "you cannot review your own task":
+			utils.RespondJSON(w, http.StatusForbidden, err.Error(), nil)
+		case err.Error() == "task is not awaiting approval" || err.Error() == "you have already reviewed this task":
+			utils.RespondJSON(w, http.StatusConflict, err.Error(), nil)
+		default:
+			utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		}
+		return
+	}
+	
+	w.Header().Set("ETag", utils.ETag(task.Version))
+	utils.RespondJSON(w, http.StatusOK, "Task "+decision, map[string]interface{}{"task": task})
+}
+
+// GetApprovals handles listing every review decision on a task
+func (c *TaskController) GetApprovals(w http.ResponseWriter, r *http.Request) {
+	taskUUID, ok := parseTaskUUID(w, r, "/approvals")
+	if !ok {
+		return
+	}
+	
+	approvals, err := c.taskUseCase.GetApprovals(r.Context(), taskUUID, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		if err.Error() == "task not found" {
+			utils.RespondJSON(w, http.StatusNotFound, "Task not found", nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to get approvals", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"approvals": approvals})
+}
+
+// GetTrash handles getting the deleted tasks the current user created
+func (c *TaskController) GetTrash(w http.ResponseWriter, r *http.Request) {
+	// Get user UUID from context
+	userUUID := utils.GetUserUUIDFromRequest(r)
+	
+	// Get deleted tasks
+	tasksResp, err := c.taskUseCase.GetTrash(r.Context(), userUUID)
+	if err != nil {
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to fetch trash", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"tasks": tasksResp.Tasks})
+}
+
+// RestoreTask handles restoring a deleted task from the trash
+func (c *TaskController) RestoreTask(w http.ResponseWriter, r *http.Request) {
+	// Extract task UUID from path
+	uuidStr := strings.TrimPrefix(r.URL.Path, "/api/v1/tasks/")
+	uuidStr = strings.TrimSuffix(uuidStr, "/restore")
+	taskUUID, err := uuid.Parse(uuidStr)
+	if err != nil {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid task UUID", nil)
+		return
+	}
+	
+	// Get user UUID from context
+	userUUID := utils.GetUserUUIDFromRequest(r)
+	
+	// Restore task
+	task, err := c.taskUseCase.RestoreTask(r.Context(), taskUUID, userUUID)
+	if err != nil {
+		if errors.Is(err, entity.ErrVersionConflict) {
+			utils.RespondJSON(w, http.StatusPreconditionFailed, err.Error(), nil)
+		} else if err.Error() == "task not found in trash" {
+			utils.RespondJSON(w, http.StatusNotFound, err.Error(), nil)
+		} else {
+			utils.RespondJSON(w, http.StatusInternalServerError, "Failed to restore task", nil)
+		}
+		return
+	}
+	
+	w.Header().Set("ETag", utils.ETag(task.Version))
+	utils.RespondJSON(w, http.StatusOK, "Task restored successfully", map[string]interface{}{"task": task})
+}
+
+// PurgeTask handles permanently deleting a task from the trash
+func (c *TaskController) PurgeTask(w http.ResponseWriter, r *http.Request) {
+	// Extract task UUID from path
+	uuidStr := strings.TrimPrefix(r.URL.Path, "/api/v1/trash/")
+	taskUUID, err := uuid.Parse(uuidStr)
+	if err != nil {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid task UUID", nil)
+		return
+	}
+	
+	// Get user UUID from context
+	userUUID := utils.GetUserUUIDFromRequest(r)
+	
+	// Purge task
+	if err := c.taskUseCase.PurgeTask(r.Context(), taskUUID, userUUID); err != nil {
+		if err.Error() == "task not found in trash" {
+			utils.RespondJSON(w, http.StatusNotFound, err.Error(), nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to delete task permanently", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "Task deleted permanently", nil)
+}
+
+// DuplicateTask handles copying a task into a new task created by the current user
+func (c *TaskController) DuplicateTask(w http.ResponseWriter, r *http.Request) {
+	// Extract task UUID from path
+	uuidStr := strings.TrimPrefix(r.URL.Path, "/api/v1/tasks/")
+	uuidStr = strings.TrimSuffix(uuidStr, "/duplicate")
+	taskUUID, err := uuid.Parse(uuidStr)
+	if err != nil {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid task UUID", nil)
+		return
+	}
+	
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.DuplicateTaskRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	// Get user UUID from context
+	userUUID := utils.GetUserUUIDFromRequest(r)
+	
+	// Duplicate task
+	task, err := c.taskUseCase.DuplicateTask(r.Context(), taskUUID, req, userUUID)
+	if err != nil {
+		if err.Error() == "task not found" {
+			utils.RespondJSON(w, http.StatusNotFound, "Task not found", nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to duplicate task", nil)
+		return
+	}
+	
+	w.Header().Set("ETag", utils.ETag(task.Version))
+	utils.RespondJSON(w, http.StatusCreated, "Task duplicated successfully", map[string]interface{}{"task": task})
+}
+
+// LinkTask handles linking a task to another task
+func (c *TaskController) LinkTask(w http.ResponseWriter, r *http.Request) {
+	taskUUID, ok := parsePathUUID(w, r, "id", "Invalid task UUID")
+	if !ok {
+		return
+	}
+	
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.LinkTaskRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	// Link tasks
+	task, err := c.taskUseCase.LinkTask(r.Context(), taskUUID, req, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrVersionConflict):
+			utils.RespondJSON(w, http.StatusPreconditionFailed, err.Error(), nil)
+		case err.Error() == "task not found" || err.Error() == "linked task not found":
+			utils.RespondJSON(w, http.StatusNotFound, err.Error(), nil)
+		case err.Error() == "you are not authorized to close this duplicate":
+			utils.RespondJSON(w, http.StatusForbidden, err.Error(), nil)
+		case err.Error() == "tasks are already linked":
+			utils.RespondJSON(w, http.StatusConflict, err.Error(), nil)
+		default:
+			utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		}
+		return
+	}
+	
+	w.Header().Set("ETag", utils.ETag(task.Version))
+	utils.RespondJSON(w, http.StatusCreated, "Tasks linked successfully", map[string]interface{}{"task": task})
+}
+
+// UnlinkTask handles deleting a link between tasks
+func (c *TaskController) UnlinkTask(w http.ResponseWriter, r *http.Request) {
+	taskUUID, ok := parsePathUUID(w, r, "id", "Invalid task UUID")
+	if !ok {
+		return
+	}
+	linkUUID, ok := parsePathUUID(w, r, "linkID", "Invalid link UUID")
+	if !ok {
+		return
+	}
+	
+	// Unlink tasks
+	task, err := c.taskUseCase.UnlinkTask(r.Context(), taskUUID, linkUUID, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		if err.Error() == "task not found" || err.Error() == "link not found" {
+			utils.RespondJSON(w, http.StatusNotFound, err.Error(), nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to delete link", nil)
+		return
+	}
+	
+	w.Header().Set("ETag", utils.ETag(task.Version))
+	utils.RespondJSON(w, http.StatusOK, "Link deleted successfully", map[string]interface{}{"task": task})
+}
+
 // DeleteTask handles deleting a task
 func (c *TaskController) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	// Extract task UUID from path
@@ -151,6 +534,8 @@ func (c *TaskController) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		if err.Error() == "only the task creator can delete the task" {
 			utils.RespondJSON(w, http.StatusForbidden, err.Error(), nil)
+		} else if errors.Is(err, entity.ErrVersionConflict) {
+			utils.RespondJSON(w, http.StatusPreconditionFailed, err.Error(), nil)
 		} else {
 			utils.RespondJSON(w, http.StatusInternalServerError, "Failed to delete task", nil)
 		}
@@ -189,9 +574,295 @@ func (c *TaskController) AssignTask(w http.ResponseWriter, r *http.Request) {
 	// Assign task
 	task, err := c.taskUseCase.AssignTask(r.Context(), taskUUID, assignedUserUUID, requestorUUID)
 	if err != nil {
-		utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		if errors.Is(err, entity.ErrVersionConflict) {
+			utils.RespondJSON(w, http.StatusPreconditionFailed, err.Error(), nil)
+		} else {
+			utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		}
 		return
 	}
 	
+	w.Header().Set("ETag", utils.ETag(task.Version))
 	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"task": task})
-}
\ No newline at end of file
+}
+
+// BulkTaskOperation handles applying one operation to many tasks
+func (c *TaskController) BulkTaskOperation(w http.ResponseWriter, r *http.Request) {
+	// Get user UUID from context
+	userUUID := utils.GetUserUUIDFromRequest(r)
+	
+	// Get request body from context
+	ctx := r.Context()
+	val := ctx.Value(middleware.BindKey)
+	bulkReq, ok := val.(*dto.BulkTaskRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request body", nil)
+		return
+	}
+	
+	// Apply the operation
+	bulkResp, err := c.taskUseCase.BulkTaskOperation(ctx, bulkReq, userUUID)
+	if err != nil {
+		utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	
+	// An all-or-nothing batch that was rolled back is reported as a failure
+	if bulkResp.RolledBack {
+		utils.RespondJSON(w, http.StatusUnprocessableEntity, "Bulk operation rolled back", map[string]interface{}{"bulk": bulkResp})
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"bulk": bulkResp})
+}
+
+// maxImportSize is the largest import file accepted, in bytes
+const maxImportSize = 10 << 20
+
+// ExportTasks handles exporting the user's visible tasks as CSV or NDJSON
+func (c *TaskController) ExportTasks(w http.ResponseWriter, r *http.Request) {
+	// Get export format
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		format = formatCSV
+	}
+	if format != formatCSV && format != formatNDJSON {
+		utils.RespondJSON(w, http.StatusBadRequest, "format must be csv or ndjson", nil)
+		return
+	}
+	
+	// Get user UUID from context
+	userUUID := utils.GetUserUUIDFromRequest(r)
+	
+	// Stream the file; the response starts with the first record, so a failure
+	// before any task is loaded can still be reported as an error response
+	var out taskRecordWriter
+	err := c.taskUseCase.ExportTasks(r.Context(), userUUID, func(record dto.TaskRecord) error {
+		if out == nil {
+			out = startTaskExport(w, format)
+		}
+		return out.Write(record)
+	})
+	if err != nil && out == nil {
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to export tasks", nil)
+		return
+	}
+	
+	if err == nil {
+		if out == nil {
+			out = startTaskExport(w, format)
+		}
+		err = out.Flush()
+	}
+	
+	if err != nil {
+		log.Printf("ExportTasks: Failed to write export: %v", err)
+	}
+}
+
+// startTaskExport sets the download headers for an export and creates the writer for its format
+func startTaskExport(w http.ResponseWriter, format string) taskRecordWriter {
+	filename := "tasks-" + time.Now().Format("2006-01-02") + "." + format
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	
+	if format == formatCSV {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		return newCSVTaskRecordWriter(w)
+	}
+	
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	return newNDJSONTaskRecordWriter(w)
+}
+
+// ImportTasks handles importing tasks from a CSV or NDJSON file
+func (c *TaskController) ImportTasks(w http.ResponseWriter, r *http.Request) {
+	// Get import format from the query or the content type
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		contentType := r.Header.Get("Content-Type")
+		switch {
+		case strings.HasPrefix(contentType, "text/csv"):
+			format = formatCSV
+		case strings.HasPrefix(contentType, "application/x-ndjson"), strings.HasPrefix(contentType, "application/ndjson"):
+			format = formatNDJSON
+		}
+	}
+	if format != formatCSV && format != formatNDJSON {
+		utils.RespondJSON(w, http.StatusBadRequest, "format must be csv or ndjson", nil)
+		return
+	}
+	
+	// Parse dry-run flag
+	dryRun := false
+	if dryRunStr := r.URL.Query().Get("dry_run"); dryRunStr != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunStr)
+		if err != nil {
+			utils.RespondJSON(w, http.StatusBadRequest, "dry_run must be true or false", nil)
+			return
+		}
+	}
+	
+	// Decode rows
+	body := http.MaxBytesReader(w, r.Body, maxImportSize)
+	defer body.Close()
+	
+	var rows []dto.TaskImportRow
+	var err error
+	if format == formatCSV {
+		rows, err = readTaskRecordsCSV(body)
+	} else {
+		rows, err = readTaskRecordsNDJSON(body)
+	}
+	if err != nil {
+		utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	
+	// Get user UUID from context
+	userUUID := utils.GetUserUUIDFromRequest(r)
+	
+	// Import tasks
+	importResp, err := c.taskUseCase.ImportTasks(r.Context(), rows, dryRun, userUUID)
+	if err != nil {
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to import tasks", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"import": importResp})
+}
+
+// parseTaskUUID parses the task UUID from a /api/v1/tasks/{id}{suffix} path, responding with an error if it is invalid
+func parseTaskUUID(w http.ResponseWriter, r *http.Request, suffix string) (uuid.UUID, bool) {
+	uuidStr := strings.TrimPrefix(r.URL.Path, "/api/v1/tasks/")
+	uuidStr = strings.TrimSuffix(uuidStr, suffix)
+	taskUUID, err := uuid.Parse(uuidStr)
+	if err != nil {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid task UUID", nil)
+		return uuid.Nil, false
+	}
+	
+	return taskUUID, true
+}
+
+// taskListFieldPrefix starts the query parameters that filter a task list by a custom field, as in field.<field_id>=value
+const taskListFieldPrefix = "field."
+
+// maxTaskListFieldFilters is the most custom fields a task list can be filtered by, as for a saved view
+const maxTaskListFieldFilters = 20
+
+// parseTaskListQuery reads a task list's filter and sort from the query string, using the parameter names of a
+// saved view's filter and sort. It returns nil when the query has none of them.
+func parseTaskListQuery(r *http.Request) (*dto.TaskListQuery, error) {
+	values := r.URL.Query()
+	query := &dto.TaskListQuery{}
+	found := false
+	
+	parseBool := func(name string) (*bool, error) {
+		text := values.Get(name)
+		if text == "" {
+			return nil, nil
+		}
+		found = true
+		value, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be true or false", name)
+		}
+		return &value, nil
+	}
+	parseUUID := func(name string) (*uuid.UUID, error) {
+		text := values.Get(name)
+		if text == "" {
+			return nil, nil
+		}
+		found = true
+		value, err := uuid.Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a UUID", name)
+		}
+		return &value, nil
+	}
+	parseTime := func(name string) (*time.Time, error) {
+		text := values.Get(name)
+		if text == "" {
+			return nil, nil
+		}
+		found = true
+		value, err := time.Parse(time.RFC3339, text)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be an RFC 3339 time", name)
+		}
+		return &value, nil
+	}
+	
+	var err error
+	if query.Filter.Completed, err = parseBool("completed"); err != nil {
+		return nil, err
+	}
+	if query.Filter.HasDueDate, err = parseBool("has_due_date"); err != nil {
+		return nil, err
+	}
+	if query.Filter.CreatedBy, err = parseUUID("created_by"); err != nil {
+		return nil, err
+	}
+	if query.Filter.AssignedTo, err = parseUUID("assigned_to"); err != nil {
+		return nil, err
+	}
+	if query.Filter.DueBefore, err = parseTime("due_before"); err != nil {
+		return nil, err
+	}
+	if query.Filter.DueAfter, err = parseTime("due_after"); err != nil {
+		return nil, err
+	}
+	if query.Sort.CustomFieldID, err = parseUUID("custom_field_id"); err != nil {
+		return nil, err
+	}
+	
+	if search := values.Get("search"); search != "" {
+		found = true
+		if len([]rune(search)) > 200 {
+			return nil, errors.New("search must be at most 200 characters")
+		}
+		query.Filter.Search = search
+	}
+	
+	if field := values.Get("sort"); field != "" {
+		found = true
+		query.Sort.Field = field
+	}
+	if order := values.Get("order"); order != "" {
+		found = true
+		if order != "asc" && order != "desc" {
+			return nil, errors.New("order must be asc or desc")
+		}
+		query.Sort.Order = order
+		if query.Sort.Field == "" {
+			query.Sort.Field = entity.SortByCreatedAt
+		}
+	}
+	
+	// Custom field filters, in a stable order
+	for name, fieldValues := range values {
+		if !strings.HasPrefix(name, taskListFieldPrefix) {
+			continue
+		}
+		found = true
+		fieldID, err := uuid.Parse(strings.TrimPrefix(name, taskListFieldPrefix))
+		if err != nil {
+			return nil, fmt.Errorf("%s must name a custom field by its UUID", name)
+		}
+		query.Filter.CustomFields = append(query.Filter.CustomFields, dto.CustomFieldFilter{FieldID: fieldID, Value: fieldValues[0]})
+	}
+	if len(query.Filter.CustomFields) > maxTaskListFieldFilters {
+		return nil, fmt.Errorf("a task list can filter by at most %d custom fields", maxTaskListFieldFilters)
+	}
+	slices.SortFunc(query.Filter.CustomFields, func(a, b dto.CustomFieldFilter) int {
+		return strings.Compare(a.FieldID.String(), b.FieldID.String())
+	})
+	
+	if !found {
+		return nil, nil
+	}
+	
+	return query, nil
+}
@@ -0,0 +1,306 @@
+package controller
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"task2/internal/app/dto"
+)
+
+// Supported import/export formats
+const (
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+)
+
+// utf8BOM lets spreadsheet applications such as Excel detect UTF-8 CSV files
+const utf8BOM = "\ufeff"
+
+// memberSeparator separates member emails within a single CSV field
+const memberSeparator = ";"
+
+// formulasEscapedColumn marks CSV rows written by the export, whose text cells may carry the quote
+// escapeFormula adds. Imports only remove the quote from rows marked true; other files are read verbatim.
+const formulasEscapedColumn = "formulas_escaped"
+
+// taskRecordColumns lists the CSV columns in export order
+var taskRecordColumns = []string{
+	"external_id",
+	"id",
+	"title",
+	"description",
+	"completed",
+	"due_date",
+	"created_by",
+	"assigned_to",
+	"members",
+	"created_at",
+	"updated_at",
+	formulasEscapedColumn,
+}
+
+// taskRecordWriter writes task records one at a time in an export format
+type taskRecordWriter interface {
+	// Write writes one task record
+	Write(record dto.TaskRecord) error
+
+	// Flush writes any buffered records
+	Flush() error
+}
+
+// csvTaskRecordWriter writes task records as CSV with a header row
+type csvTaskRecordWriter struct {
+	w             io.Writer
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+// newCSVTaskRecordWriter creates a CSV task record writer
+func newCSVTaskRecordWriter(w io.Writer) *csvTaskRecordWriter {
+	return &csvTaskRecordWriter{
+		w:      w,
+		writer: csv.NewWriter(w),
+	}
+}
+
+// writeHeader writes the byte order mark and header row before the first record
+func (cw *csvTaskRecordWriter) writeHeader() error {
+	if cw.headerWritten {
+		return nil
+	}
+	cw.headerWritten = true
+
+	if _, err := io.WriteString(cw.w, utf8BOM); err != nil {
+		return err
+	}
+	return cw.writer.Write(taskRecordColumns)
+}
+
+// Write writes one task record as a CSV row. Free-text cells are escaped so spreadsheets do not run them as formulas.
+func (cw *csvTaskRecordWriter) Write(record dto.TaskRecord) error {
+	var id, dueDate, createdAt, updatedAt string
+	if record.ID != nil {
+		id = record.ID.String()
+	}
+	if record.DueDate != nil {
+		dueDate = record.DueDate.Format(time.RFC3339)
+	}
+	if record.CreatedAt != nil {
+		createdAt = record.CreatedAt.Format(time.RFC3339)
+	}
+	if record.UpdatedAt != nil {
+		updatedAt = record.UpdatedAt.Format(time.RFC3339)
+	}
+
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+
+	return cw.writer.Write([]string{
+		escapeFormula(record.ExternalID),
+		id,
+		escapeFormula(record.Title),
+		escapeFormula(record.Description),
+		strconv.FormatBool(record.Completed),
+		dueDate,
+		escapeFormula(record.CreatedBy),
+		escapeFormula(record.AssignedTo),
+		escapeFormula(strings.Join(record.Members, memberSeparator)),
+		createdAt,
+		updatedAt,
+		"true",
+	})
+}
+
+// Flush writes any buffered CSV rows, and the header row if no record was written
+func (cw *csvTaskRecordWriter) Flush() error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+
+	cw.writer.Flush()
+	return cw.writer.Error()
+}
+
+// ndjsonTaskRecordWriter writes task records as newline-delimited JSON
+type ndjsonTaskRecordWriter struct {
+	encoder *json.Encoder
+}
+
+// newNDJSONTaskRecordWriter creates an NDJSON task record writer
+func newNDJSONTaskRecordWriter(w io.Writer) *ndjsonTaskRecordWriter {
+	return &ndjsonTaskRecordWriter{encoder: json.NewEncoder(w)}
+}
+
+// Write writes one task record as a JSON line
+func (nw *ndjsonTaskRecordWriter) Write(record dto.TaskRecord) error {
+	return nw.encoder.Encode(record)
+}
+
+// Flush does nothing; every record is written as soon as it is encoded
+func (nw *ndjsonTaskRecordWriter) Flush() error {
+	return nil
+}
+
+// formulaPrefixes are the characters that make spreadsheet applications treat a cell as a formula
+const formulaPrefixes = "=+-@\t\r"
+
+// escapeFormula prefixes a CSV cell that a spreadsheet would run as a formula with a single quote,
+// which makes the spreadsheet show it as text (CSV injection). Cells that already start with a quote
+// are prefixed too, so unescapeFormula can tell the added quote apart.
+func escapeFormula(value string) string {
+	if value != "" && (value[0] == '\'' || strings.ContainsRune(formulaPrefixes, rune(value[0]))) {
+		return "'" + value
+	}
+	return value
+}
+
+// unescapeFormula removes the quote escapeFormula adds, so exported files import unchanged.
+// Only call it on cells of rows marked in formulasEscapedColumn.
+func unescapeFormula(value string) string {
+	if len(value) > 1 && value[0] == '\'' && (value[1] == '\'' || strings.ContainsRune(formulaPrefixes, rune(value[1]))) {
+		return value[1:]
+	}
+	return value
+}
+
+// readTaskRecordsCSV decodes import rows from a CSV file with a header row.
+// Columns are matched by name; unknown columns are ignored.
+func readTaskRecordsCSV(r io.Reader) ([]dto.TaskImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	// Read header
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("import file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, utf8BOM)
+		}
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New("import file must have a title column")
+	}
+
+	// Read rows
+	var rows []dto.TaskImportRow
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		raw := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(fields) {
+				return ""
+			}
+			return fields[i]
+		}
+
+		escaped := strings.TrimSpace(raw(formulasEscapedColumn)) == "true"
+		field := func(name string) string {
+			if escaped {
+				return unescapeFormula(raw(name))
+			}
+			return raw(name)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := dto.TaskImportRow{
+			Line: line,
+			Record: dto.TaskRecord{
+				ExternalID:  strings.TrimSpace(field("external_id")),
+				Title:       strings.TrimSpace(field("title")),
+				Description: field("description"),
+				AssignedTo:  strings.TrimSpace(field("assigned_to")),
+			},
+		}
+
+		if completed := strings.TrimSpace(field("completed")); completed != "" {
+			value, err := strconv.ParseBool(completed)
+			if err != nil {
+				row.Errors = append(row.Errors, "completed must be true or false")
+			}
+			row.Record.Completed = value
+		}
+
+		if dueDate := strings.TrimSpace(field("due_date")); dueDate != "" {
+			value, err := parseDueDate(dueDate)
+			if err != nil {
+				row.Errors = append(row.Errors, "due_date must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+			} else {
+				row.Record.DueDate = &value
+			}
+		}
+
+		for _, email := range strings.Split(field("members"), memberSeparator) {
+			if email = strings.TrimSpace(email); email != "" {
+				row.Record.Members = append(row.Record.Members, email)
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// parseDueDate parses a due date written as an RFC 3339 timestamp or a plain date
+func parseDueDate(value string) (time.Time, error) {
+	if dueDate, err := time.Parse(time.RFC3339, value); err == nil {
+		return dueDate, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+// readTaskRecordsNDJSON decodes import rows from newline-delimited JSON, one task per line
+func readTaskRecordsNDJSON(r io.Reader) ([]dto.TaskImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var rows []dto.TaskImportRow
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		row := dto.TaskImportRow{Line: line}
+		if err := json.Unmarshal([]byte(text), &row.Record); err != nil {
+			row.Errors = append(row.Errors, "invalid JSON")
+		}
+
+		rows = append(rows, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("invalid NDJSON: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("import file is empty")
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"task2/internal/app/dto"
+	"task2/internal/app/usecase"
+	"task2/internal/domain/entity"
+	"task2/internal/infrastructure/middleware"
+	"task2/pkg/utils"
+)
+
+// TransferController handles HTTP requests for task ownership transfers
+type TransferController struct {
+	transferUseCase *usecase.TransferUseCase
+}
+
+// NewTransferController creates a new ownership transfer controller
+func NewTransferController(transferUseCase *usecase.TransferUseCase) *TransferController {
+	return &TransferController{
+		transferUseCase: transferUseCase,
+	}
+}
+
+// RequestTransfer handles asking a user to take over a task
+func (c *TransferController) RequestTransfer(w http.ResponseWriter, r *http.Request) {
+	taskUUID, ok := parsePathUUID(w, r, "id", "Invalid task UUID")
+	if !ok {
+		return
+	}
+	
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.TransferRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	// Request transfer
+	transfer, err := c.transferUseCase.RequestTransfer(r.Context(), taskUUID, req, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrVersionConflict):
+			utils.RespondJSON(w, http.StatusPreconditionFailed, err.Error(), nil)
+		case err.Error() == "task not found" || err.Error() == "user not found":
+			utils.RespondJSON(w, http.StatusNotFound, err.Error(), nil)
+		case err.Error() == "only the task creator can transfer the task" || err.Error() == "only admins and managers can transfer a task without consent":
+			utils.RespondJSON(w, http.StatusForbidden, err.Error(), nil)
+		default:
+			utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		}
+		return
+	}
+	
+	if transfer.Status == entity.TransferStatusAccepted {
+		utils.RespondJSON(w, http.StatusOK, "Task transferred successfully", map[string]interface{}{"transfer": transfer})
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusCreated, "Transfer requested successfully", map[string]interface{}{"transfer": transfer})
+}
+
+// GetTransfers handles listing pending transfers the current user requested or has to answer
+func (c *TransferController) GetTransfers(w http.ResponseWriter, r *http.Request) {
+	transfers, err := c.transferUseCase.GetPendingTransfers(r.Context(), utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to get transfers", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"transfers": transfers})
+}
+
+// AcceptTransfer handles accepting a transfer addressed to the current user
+func (c *TransferController) AcceptTransfer(w http.ResponseWriter, r *http.Request) {
+	c.respond(w, r, true)
+}
+
+// DeclineTransfer handles declining a transfer addressed to the current user
+func (c *TransferController) DeclineTransfer(w http.ResponseWriter, r *http.Request) {
+	c.respond(w, r, false)
+}
+
+// respond answers a transfer addressed to the current user
+func (c *TransferController) respond(w http.ResponseWriter, r *http.Request, accept bool) {
+	transferUUID, ok := parsePathUUID(w, r, "id", "Invalid transfer UUID")
+	if !ok {
+		return
+	}
+	
+	transfer, err := c.transferUseCase.RespondToTransfer(r.Context(), transferUUID, utils.GetUserUUIDFromRequest(r), accept)
+	if err != nil {
+		if err.Error() == "transfer not found" || err.Error() == "task not found" {
+			utils.RespondJSON(w, http.StatusNotFound, err.Error(), nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusConflict, err.Error(), nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "Transfer "+transfer.Status, map[string]interface{}{"transfer": transfer})
+}
+
+// CancelTransfer handles withdrawing a pending transfer the current user requested
+func (c *TransferController) CancelTransfer(w http.ResponseWriter, r *http.Request) {
+	transferUUID, ok := parsePathUUID(w, r, "id", "Invalid transfer UUID")
+	if !ok {
+		return
+	}
+	
+	transfer, err := c.transferUseCase.CancelTransfer(r.Context(), transferUUID, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		if err.Error() == "transfer not found" {
+			utils.RespondJSON(w, http.StatusNotFound, "Transfer not found", nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusConflict, err.Error(), nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "Transfer cancelled", map[string]interface{}{"transfer": transfer})
+}
+
+// TransferAllTasks handles moving every task created by a user to another user
+func (c *TransferController) TransferAllTasks(w http.ResponseWriter, r *http.Request) {
+	fromUserUUID, ok := parsePathUUID(w, r, "id", "Invalid user UUID")
+	if !ok {
+		return
+	}
+	
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.BulkTransferRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	result, err := c.transferUseCase.TransferAllTasks(r.Context(), fromUserUUID, req, utils.GetUserUUIDFromRequest(r))
+	if err != nil {
+		switch err.Error() {
+		case "admin access required":
+			utils.RespondJSON(w, http.StatusForbidden, err.Error(), nil)
+		case "user not found":
+			utils.RespondJSON(w, http.StatusNotFound, err.Error(), nil)
+		case "cannot transfer tasks to the same user":
+			utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		default:
+			utils.RespondJSON(w, http.StatusInternalServerError, "Failed to transfer tasks", nil)
+		}
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "Tasks transferred successfully", map[string]interface{}{
+		"transferred": result.Transferred,
+		"task_ids":    result.TaskIDs,
+	})
+}
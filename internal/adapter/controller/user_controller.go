@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"encoding/json"
 	"log"
 	"net/http"
 	"time"
@@ -78,21 +79,16 @@ func (c *UserController) Login(w http.ResponseWriter, r *http.Request) {
 	loginResp, err := c.userUseCase.Login(ctx, loginReq)
 	if err != nil {
 		log.Printf("Login: Failed to login: %v", err)
+		if err.Error() == "email address is not verified" || err.Error() == "account is suspended" {
+			utils.RespondJSON(w, http.StatusForbidden, err.Error(), nil)
+			return
+		}
 		utils.RespondJSON(w, http.StatusUnauthorized, err.Error(), nil)
 		return
 	}
 	
-	// Set token in cookie
-	cookie := &http.Cookie{
-		Name:     "Authorization",
-		Value:    loginResp.Token,
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   false, // Set to true in production with HTTPS
-		SameSite: http.SameSiteLaxMode,
-		Expires:  time.Now().Add(7 * 24 * time.Hour), // 7 days
-	}
-	http.SetCookie(w, cookie)
+	// Set tokens in cookies
+	setTokenCookies(w, &loginResp.TokenResponse)
 	
 	log.Printf("Login: Set Authorization cookie with token")
 	
@@ -116,11 +112,212 @@ func (c *UserController) Login(w http.ResponseWriter, r *http.Request) {
 	
 	// Send response
 	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{
-		"user":  loginResp.User,
-		"token": loginResp.Token,
+		"user":               loginResp.User,
+		"token":              loginResp.Token,
+		"expires_at":         loginResp.ExpiresAt,
+		"refresh_token":      loginResp.RefreshToken,
+		"refresh_expires_at": loginResp.RefreshExpiresAt,
+	})
+}
+
+// RefreshToken handles exchanging a refresh token, from the body or the RefreshToken cookie, for new tokens
+func (c *UserController) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	// Get refresh token from the body, if any
+	var req dto.RefreshTokenRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondJSON(w, http.StatusBadRequest, "Invalid JSON", nil)
+			return
+		}
+	}
+	
+	// Fall back to the cookie
+	if req.RefreshToken == "" {
+		if cookie, err := r.Cookie("RefreshToken"); err == nil {
+			req.RefreshToken = cookie.Value
+		}
+	}
+	if req.RefreshToken == "" {
+		utils.RespondJSON(w, http.StatusBadRequest, "refresh_token is required", nil)
+		return
+	}
+	
+	// Refresh tokens
+	tokens, err := c.userUseCase.RefreshToken(r.Context(), req.RefreshToken)
+	if err != nil {
+		utils.RespondJSON(w, http.StatusUnauthorized, err.Error(), nil)
+		return
+	}
+	
+	setTokenCookies(w, tokens)
+	
+	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{
+		"token":              tokens.Token,
+		"expires_at":         tokens.ExpiresAt,
+		"refresh_token":      tokens.RefreshToken,
+		"refresh_expires_at": tokens.RefreshExpiresAt,
+	})
+}
+
+// Logout handles ending the current session; the refresh token is taken from the body or the RefreshToken cookie
+func (c *UserController) Logout(w http.ResponseWriter, r *http.Request) {
+	// Get refresh token from the body, if any
+	var req dto.RefreshTokenRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondJSON(w, http.StatusBadRequest, "Invalid JSON", nil)
+			return
+		}
+	}
+	
+	// Fall back to the cookie
+	if req.RefreshToken == "" {
+		if cookie, err := r.Cookie("RefreshToken"); err == nil {
+			req.RefreshToken = cookie.Value
+		}
+	}
+	
+	// Logout
+	err := c.userUseCase.Logout(r.Context(), utils.GetUserUUIDFromRequest(r), utils.GetAccessTokenFromRequest(r), req.RefreshToken)
+	if err != nil {
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to log out", nil)
+		return
+	}
+	
+	clearTokenCookies(w)
+	
+	utils.RespondJSON(w, http.StatusOK, "Logged out", nil)
+}
+
+// LogoutEverywhere handles ending every session of the current user
+func (c *UserController) LogoutEverywhere(w http.ResponseWriter, r *http.Request) {
+	if err := c.userUseCase.LogoutEverywhere(r.Context(), utils.GetUserUUIDFromRequest(r)); err != nil {
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to log out", nil)
+		return
+	}
+	
+	clearTokenCookies(w)
+	
+	utils.RespondJSON(w, http.StatusOK, "Logged out everywhere", nil)
+}
+
+// setTokenCookies sets the access token cookie and the refresh token cookie, which is only sent to the token routes
+func setTokenCookies(w http.ResponseWriter, tokens *dto.TokenResponse) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     "Authorization",
+		Value:    tokens.Token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   false, // Set to true in production with HTTPS
+		SameSite: http.SameSiteLaxMode,
+		Expires:  tokens.ExpiresAt,
+	})
+	
+	http.SetCookie(w, &http.Cookie{
+		Name:     "RefreshToken",
+		Value:    tokens.RefreshToken,
+		Path:     "/api/v1/token",
+		HttpOnly: true,
+		Secure:   false, // Set to true in production with HTTPS
+		SameSite: http.SameSiteStrictMode,
+		Expires:  tokens.RefreshExpiresAt,
+	})
+}
+
+// clearTokenCookies removes the cookies set by setTokenCookies
+func clearTokenCookies(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     "Authorization",
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		MaxAge:   -1,
+	})
+	
+	http.SetCookie(w, &http.Cookie{
+		Name:     "RefreshToken",
+		Value:    "",
+		Path:     "/api/v1/token",
+		HttpOnly: true,
+		MaxAge:   -1,
 	})
 }
 
+// VerifyEmail handles verifying an email address with the token from a verification link
+func (c *UserController) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		utils.RespondJSON(w, http.StatusBadRequest, "token is required", nil)
+		return
+	}
+	
+	// Verify email
+	user, err := c.userUseCase.VerifyEmail(r.Context(), token)
+	if err != nil {
+		utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "Email address verified", map[string]interface{}{"user": user})
+}
+
+// ResendVerificationEmail handles sending another email verification link
+func (c *UserController) ResendVerificationEmail(w http.ResponseWriter, r *http.Request) {
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.ResendVerificationRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	// Resend verification email
+	if err := c.userUseCase.ResendVerificationEmail(r.Context(), req.Email); err != nil {
+		utils.RespondJSON(w, http.StatusInternalServerError, err.Error(), nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusAccepted, "If the address belongs to an unverified account, a verification email is on its way", nil)
+}
+
+// ForgotPassword handles emailing a password reset link
+func (c *UserController) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.ForgotPasswordRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	// Send reset link
+	if err := c.userUseCase.ForgotPassword(r.Context(), req.Email); err != nil {
+		log.Printf("ForgotPassword: Failed to send reset link: %v", err)
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to send password reset email", nil)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusAccepted, "If the address belongs to an account, a password reset email is on its way", nil)
+}
+
+// ResetPassword handles setting a new password with a password reset token
+func (c *UserController) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.ResetPasswordRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	// Reset password
+	if err := c.userUseCase.ResetPassword(r.Context(), req); err != nil {
+		utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	
+	clearTokenCookies(w)
+	
+	utils.RespondJSON(w, http.StatusOK, "Password reset; log in with your new password", nil)
+}
+
 // GetProfile handles getting the user's profile
 func (c *UserController) GetProfile(w http.ResponseWriter, r *http.Request) {
 	// Get user UUID from context
@@ -136,6 +333,62 @@ func (c *UserController) GetProfile(w http.ResponseWriter, r *http.Request) {
 	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"user": user})
 }
 
+// UpdateProfile handles updating the user's profile
+func (c *UserController) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.UpdateProfileRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	// Update profile
+	user, err := c.userUseCase.UpdateUser(r.Context(), utils.GetUserUUIDFromRequest(r), req)
+	if err != nil {
+		switch err.Error() {
+		case "user not found":
+			utils.RespondJSON(w, http.StatusNotFound, err.Error(), nil)
+		case "email already registered by another user":
+			utils.RespondJSON(w, http.StatusConflict, err.Error(), nil)
+		default:
+			utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		}
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"user": user})
+}
+
+// ChangePassword handles changing the user's password, which logs out every other session
+func (c *UserController) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.ChangePasswordRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	// Change password
+	tokens, err := c.userUseCase.ChangePassword(r.Context(), utils.GetUserUUIDFromRequest(r), req)
+	if err != nil {
+		if err.Error() == "current password is incorrect" {
+			utils.RespondJSON(w, http.StatusForbidden, err.Error(), nil)
+			return
+		}
+		utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	
+	setTokenCookies(w, tokens)
+	
+	utils.RespondJSON(w, http.StatusOK, "Password changed; other sessions have been logged out", map[string]interface{}{
+		"token":              tokens.Token,
+		"expires_at":         tokens.ExpiresAt,
+		"refresh_token":      tokens.RefreshToken,
+		"refresh_expires_at": tokens.RefreshExpiresAt,
+	})
+}
+
 // GetAllUsers handles getting all users
 func (c *UserController) GetAllUsers(w http.ResponseWriter, r *http.Request) {
 	// Get all users
@@ -148,6 +401,91 @@ func (c *UserController) GetAllUsers(w http.ResponseWriter, r *http.Request) {
 	utils.RespondJSON(w, http.StatusOK, "", map[string]interface{}{"users": usersResp.Users})
 }
 
+// ChangeRole handles changing a user's role
+func (c *UserController) ChangeRole(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := parsePathUUID(w, r, "id", "Invalid user UUID")
+	if !ok {
+		return
+	}
+	
+	// Get request from context
+	req, ok := r.Context().Value(middleware.BindKey).(*dto.ChangeRoleRequest)
+	if !ok {
+		utils.RespondJSON(w, http.StatusBadRequest, "Invalid request", nil)
+		return
+	}
+	
+	user, err := c.userUseCase.ChangeUserRole(r.Context(), userUUID, req)
+	if err != nil {
+		respondUserAccessError(w, err)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "Role changed", map[string]interface{}{"user": user})
+}
+
+// SuspendUser handles suspending a user
+func (c *UserController) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := parsePathUUID(w, r, "id", "Invalid user UUID")
+	if !ok {
+		return
+	}
+	
+	user, err := c.userUseCase.SuspendUser(r.Context(), userUUID)
+	if err != nil {
+		respondUserAccessError(w, err)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "User suspended and logged out", map[string]interface{}{"user": user})
+}
+
+// ReactivateUser handles lifting a user's suspension
+func (c *UserController) ReactivateUser(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := parsePathUUID(w, r, "id", "Invalid user UUID")
+	if !ok {
+		return
+	}
+	
+	user, err := c.userUseCase.ReactivateUser(r.Context(), userUUID)
+	if err != nil {
+		respondUserAccessError(w, err)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "User reactivated", map[string]interface{}{"user": user})
+}
+
+// DeleteUser handles deleting a user
+func (c *UserController) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	userUUID, ok := parsePathUUID(w, r, "id", "Invalid user UUID")
+	if !ok {
+		return
+	}
+	
+	if err := c.userUseCase.DeleteUser(r.Context(), userUUID); err != nil {
+		respondUserAccessError(w, err)
+		return
+	}
+	
+	utils.RespondJSON(w, http.StatusOK, "User deleted", nil)
+}
+
+// respondUserAccessError maps an error from changing a user's role or status to a response
+func respondUserAccessError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case "user not found":
+		utils.RespondJSON(w, http.StatusNotFound, "User not found", nil)
+	case "cannot remove the last admin":
+		utils.RespondJSON(w, http.StatusConflict, err.Error(), nil)
+	case "invalid role":
+		utils.RespondJSON(w, http.StatusBadRequest, err.Error(), nil)
+	default:
+		log.Printf("Failed to update user access: %v", err)
+		utils.RespondJSON(w, http.StatusInternalServerError, "Failed to update user", nil)
+	}
+}
+
 // DebugCookie handles the debug cookie endpoint
 func (c *UserController) DebugCookie(w http.ResponseWriter, r *http.Request) {
 	// Return response
@@ -155,4 +493,4 @@ func (c *UserController) DebugCookie(w http.ResponseWriter, r *http.Request) {
 		"message": "Debug cookie set",
 		"cookies": r.Cookies(),
 	})
-}
\ No newline at end of file
+}
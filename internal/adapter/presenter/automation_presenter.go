@@ -0,0 +1,87 @@
+package presenter
+
+import (
+	"task2/internal/app/dto"
+	"task2/internal/domain/entity"
+)
+
+// AutomationPresenter converts between domain entities and DTOs
+type AutomationPresenter struct{}
+
+// NewAutomationPresenter creates a new automation presenter
+func NewAutomationPresenter() *AutomationPresenter {
+	return &AutomationPresenter{}
+}
+
+// ToDTO converts a rule entity to a DTO
+func (p *AutomationPresenter) ToDTO(rule *entity.AutomationRule) *dto.AutomationRuleResponse {
+	if rule == nil {
+		return nil
+	}
+	
+	ruleResponse := &dto.AutomationRuleResponse{
+		ID:          rule.UUID,
+		Name:        rule.Name,
+		Trigger:     rule.Trigger,
+		OverdueDays: rule.OverdueDays,
+		Conditions:  make([]dto.AutomationCondition, len(rule.Conditions)),
+		Actions:     make([]dto.AutomationAction, len(rule.Actions)),
+		Enabled:     rule.Enabled,
+		CreatedAt:   rule.CreatedAt,
+		UpdatedAt:   rule.UpdatedAt,
+	}
+	
+	for i, condition := range rule.Conditions {
+		ruleResponse.Conditions[i] = dto.AutomationCondition(condition)
+	}
+	for i, action := range rule.Actions {
+		ruleResponse.Actions[i] = dto.AutomationAction(action)
+	}
+	
+	return ruleResponse
+}
+
+// ToDTOList converts a list of rule entities to DTOs
+func (p *AutomationPresenter) ToDTOList(rules []*entity.AutomationRule) *dto.AutomationRulesResponse {
+	response := &dto.AutomationRulesResponse{
+		Rules: make([]dto.AutomationRuleResponse, len(rules)),
+	}
+	
+	for i, rule := range rules {
+		response.Rules[i] = *p.ToDTO(rule)
+	}
+	
+	return response
+}
+
+// ToEntity converts rule request conditions and actions to domain values
+func (p *AutomationPresenter) ToEntity(req *dto.AutomationRuleRequest) ([]entity.RuleCondition, []entity.RuleAction) {
+	conditions := make([]entity.RuleCondition, len(req.Conditions))
+	for i, condition := range req.Conditions {
+		conditions[i] = entity.RuleCondition(condition)
+	}
+	
+	actions := make([]entity.RuleAction, len(req.Actions))
+	for i, action := range req.Actions {
+		actions[i] = entity.RuleAction(action)
+	}
+	
+	return conditions, actions
+}
+
+// ToExecutionDTOList converts a rule's execution log to DTOs
+func (p *AutomationPresenter) ToExecutionDTOList(executions []*entity.AutomationExecution) []dto.AutomationExecutionResponse {
+	responses := make([]dto.AutomationExecutionResponse, len(executions))
+	for i, execution := range executions {
+		responses[i] = dto.AutomationExecutionResponse{
+			ID:        execution.UUID,
+			TaskID:    execution.TaskID,
+			Trigger:   execution.Trigger,
+			Status:    execution.Status,
+			Error:     execution.Error,
+			CreatedAt: execution.CreatedAt,
+		}
+	}
+	
+	return responses
+}
@@ -0,0 +1,132 @@
+package presenter
+
+import (
+	"strings"
+	"time"
+	"task2/internal/domain/entity"
+)
+
+// Calendar component types
+const (
+	CalendarEvent = "event"
+	CalendarTodo  = "todo"
+)
+
+// calendarUIDDomain makes task UIDs globally unique as required by RFC 5545
+const calendarUIDDomain = "task-app"
+
+// icsTimeFormat is the UTC DATE-TIME format used in iCalendar files
+const icsTimeFormat = "20060102T150405Z"
+
+// icsMaxLineLength is the maximum line length in octets before folding
+const icsMaxLineLength = 75
+
+// CalendarPresenter renders tasks as an iCalendar (RFC 5545) feed
+type CalendarPresenter struct{}
+
+// NewCalendarPresenter creates a new calendar presenter
+func NewCalendarPresenter() *CalendarPresenter {
+	return &CalendarPresenter{}
+}
+
+// ToICS renders tasks as VEVENT or VTODO components of a calendar.
+// Tasks without a due date are skipped.
+func (p *CalendarPresenter) ToICS(calendarName string, tasks []*entity.Task, component string) string {
+	var b strings.Builder
+
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//Task App//Tasks//EN")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "METHOD:PUBLISH")
+	writeICSLine(&b, "X-WR-CALNAME:"+escapeICSText(calendarName))
+	writeICSLine(&b, "REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+	writeICSLine(&b, "X-PUBLISHED-TTL:PT1H")
+
+	for _, task := range tasks {
+		if task.DueDate == nil {
+			continue
+		}
+
+		if component == CalendarTodo {
+			writeICSLine(&b, "BEGIN:VTODO")
+		} else {
+			writeICSLine(&b, "BEGIN:VEVENT")
+		}
+
+		// The task UUID keeps the UID stable across feed refreshes
+		writeICSLine(&b, "UID:"+task.UUID.String()+"@"+calendarUIDDomain)
+		writeICSLine(&b, "DTSTAMP:"+formatICSTime(task.UpdatedAt))
+		writeICSLine(&b, "CREATED:"+formatICSTime(task.CreatedAt))
+		writeICSLine(&b, "LAST-MODIFIED:"+formatICSTime(task.UpdatedAt))
+		writeICSLine(&b, "SUMMARY:"+escapeICSText(task.Title))
+		if task.Description != "" {
+			writeICSLine(&b, "DESCRIPTION:"+escapeICSText(task.Description))
+		}
+
+		if component == CalendarTodo {
+			writeICSLine(&b, "DUE:"+formatICSTime(*task.DueDate))
+			if task.Completed {
+				writeICSLine(&b, "STATUS:COMPLETED")
+				writeICSLine(&b, "PERCENT-COMPLETE:100")
+			} else {
+				writeICSLine(&b, "STATUS:NEEDS-ACTION")
+			}
+			writeICSLine(&b, "END:VTODO")
+		} else {
+			// Deadlines are shown at the due time without blocking free/busy time
+			writeICSLine(&b, "DTSTART:"+formatICSTime(*task.DueDate))
+			writeICSLine(&b, "TRANSP:TRANSPARENT")
+			writeICSLine(&b, "STATUS:CONFIRMED")
+			writeICSLine(&b, "END:VEVENT")
+		}
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+
+	return b.String()
+}
+
+// formatICSTime formats a time as a UTC iCalendar DATE-TIME
+func formatICSTime(t time.Time) string {
+	return t.UTC().Format(icsTimeFormat)
+}
+
+// escapeICSText escapes a TEXT property value
+func escapeICSText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	).Replace(s)
+}
+
+// writeICSLine writes a content line terminated by CRLF, folding it at 75 octets
+// without splitting multi-byte characters
+func writeICSLine(b *strings.Builder, line string) {
+	limit := icsMaxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+
+		// Continuation lines start with a space, which counts towards the limit
+		limit = icsMaxLineLength - 1
+	}
+
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+// isRuneStart reports whether a byte starts a UTF-8 encoded character
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
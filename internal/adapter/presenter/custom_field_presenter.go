@@ -0,0 +1,43 @@
+package presenter
+
+import (
+	"task2/internal/app/dto"
+	"task2/internal/domain/entity"
+)
+
+// CustomFieldPresenter converts between domain entities and DTOs
+type CustomFieldPresenter struct{}
+
+// NewCustomFieldPresenter creates a new custom field presenter
+func NewCustomFieldPresenter() *CustomFieldPresenter {
+	return &CustomFieldPresenter{}
+}
+
+// ToDTO converts a field entity to a DTO
+func (p *CustomFieldPresenter) ToDTO(field *entity.CustomField) *dto.CustomFieldResponse {
+	if field == nil {
+		return nil
+	}
+	
+	return &dto.CustomFieldResponse{
+		ID:        field.UUID,
+		Name:      field.Name,
+		Type:      field.Type,
+		Options:   field.Options,
+		CreatedAt: field.CreatedAt,
+		UpdatedAt: field.UpdatedAt,
+	}
+}
+
+// ToDTOList converts a list of field entities to DTOs
+func (p *CustomFieldPresenter) ToDTOList(fields []*entity.CustomField) *dto.CustomFieldsResponse {
+	response := &dto.CustomFieldsResponse{
+		Fields: make([]dto.CustomFieldResponse, len(fields)),
+	}
+	
+	for i, field := range fields {
+		response.Fields[i] = *p.ToDTO(field)
+	}
+	
+	return response
+}
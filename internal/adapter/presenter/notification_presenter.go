@@ -0,0 +1,48 @@
+package presenter
+
+import (
+	"task2/internal/app/dto"
+	"task2/internal/domain/entity"
+)
+
+// NotificationPresenter converts between domain entities and DTOs
+type NotificationPresenter struct{}
+
+// NewNotificationPresenter creates a new notification presenter
+func NewNotificationPresenter() *NotificationPresenter {
+	return &NotificationPresenter{}
+}
+
+// ToDTO converts a notification entity to a DTO
+func (p *NotificationPresenter) ToDTO(notification *entity.Notification) *dto.NotificationResponse {
+	if notification == nil {
+		return nil
+	}
+	
+	return &dto.NotificationResponse{
+		ID:        notification.UUID,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Message:   notification.Message,
+		TaskID:    notification.TaskID,
+		Read:      notification.IsRead(),
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+}
+
+// ToDTOList converts a list of notification entities to DTOs
+func (p *NotificationPresenter) ToDTOList(notifications []*entity.Notification) *dto.NotificationsResponse {
+	response := &dto.NotificationsResponse{
+		Notifications: make([]dto.NotificationResponse, 0, len(notifications)),
+	}
+	
+	for _, notification := range notifications {
+		response.Notifications = append(response.Notifications, *p.ToDTO(notification))
+		if !notification.IsRead() {
+			response.Unread++
+		}
+	}
+	
+	return response
+}
@@ -0,0 +1,50 @@
+package presenter
+
+import (
+	"task2/internal/app/dto"
+	"task2/internal/domain/entity"
+)
+
+// PersonalAccessTokenPresenter converts between domain entities and DTOs
+type PersonalAccessTokenPresenter struct{}
+
+// NewPersonalAccessTokenPresenter creates a new personal access token presenter
+func NewPersonalAccessTokenPresenter() *PersonalAccessTokenPresenter {
+	return &PersonalAccessTokenPresenter{}
+}
+
+// ToDTO converts a personal access token entity to a DTO, without its secret
+func (p *PersonalAccessTokenPresenter) ToDTO(token *entity.PersonalAccessToken) *dto.PersonalAccessTokenResponse {
+	if token == nil {
+		return nil
+	}
+	
+	scopes := make([]string, len(token.Scopes))
+	for i, scope := range token.Scopes {
+		scopes[i] = string(scope)
+	}
+	
+	return &dto.PersonalAccessTokenResponse{
+		ID:         token.UUID,
+		Name:       token.Name,
+		Scopes:     scopes,
+		Hint:       token.Hint,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		LastUsedIP: token.LastUsedIP,
+		CreatedAt:  token.CreatedAt,
+		UpdatedAt:  token.UpdatedAt,
+	}
+}
+
+// ToDTOList converts a list of personal access token entities to DTOs
+func (p *PersonalAccessTokenPresenter) ToDTOList(tokens []*entity.PersonalAccessToken) *dto.PersonalAccessTokensResponse {
+	responses := make([]dto.PersonalAccessTokenResponse, len(tokens))
+	for i, token := range tokens {
+		responses[i] = *p.ToDTO(token)
+	}
+	
+	return &dto.PersonalAccessTokensResponse{
+		Tokens: responses,
+	}
+}
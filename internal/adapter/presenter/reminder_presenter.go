@@ -0,0 +1,43 @@
+package presenter
+
+import (
+	"task2/internal/app/dto"
+	"task2/internal/domain/entity"
+)
+
+// ReminderPresenter converts between domain entities and DTOs
+type ReminderPresenter struct{}
+
+// NewReminderPresenter creates a new reminder presenter
+func NewReminderPresenter() *ReminderPresenter {
+	return &ReminderPresenter{}
+}
+
+// ToDTO converts a reminder entity on the given task to a DTO
+func (p *ReminderPresenter) ToDTO(reminder *entity.Reminder, task *entity.Task) *dto.ReminderResponse {
+	if reminder == nil {
+		return nil
+	}
+	
+	return &dto.ReminderResponse{
+		ID:               reminder.UUID,
+		TaskID:           reminder.TaskID,
+		CreatedBy:        reminder.CreatedByID,
+		RemindAt:         reminder.RemindAt,
+		MinutesBeforeDue: reminder.MinutesBeforeDue,
+		FireAt:           reminder.FireAt(task.DueDate),
+		Recipients:       reminder.Recipients,
+		Sent:             reminder.IsSent(),
+		SentAt:           reminder.SentAt,
+		CreatedAt:        reminder.CreatedAt,
+	}
+}
+
+// ToDTOList converts a task's reminder entities to DTOs
+func (p *ReminderPresenter) ToDTOList(reminders []*entity.Reminder, task *entity.Task) []dto.ReminderResponse {
+	result := make([]dto.ReminderResponse, 0, len(reminders))
+	for _, reminder := range reminders {
+		result = append(result, *p.ToDTO(reminder, task))
+	}
+	return result
+}
@@ -0,0 +1,103 @@
+package presenter
+
+import (
+	"task2/internal/app/dto"
+	"task2/internal/domain/entity"
+)
+
+// Sort orders
+const (
+	sortAscending  = "asc"
+	sortDescending = "desc"
+)
+
+// SavedViewPresenter converts between domain entities and DTOs
+type SavedViewPresenter struct{}
+
+// NewSavedViewPresenter creates a new saved view presenter
+func NewSavedViewPresenter() *SavedViewPresenter {
+	return &SavedViewPresenter{}
+}
+
+// ToDTO converts a view entity to a DTO
+func (p *SavedViewPresenter) ToDTO(view *entity.SavedView) *dto.SavedViewResponse {
+	if view == nil {
+		return nil
+	}
+	
+	order := sortAscending
+	if view.Sort.Descending {
+		order = sortDescending
+	}
+	
+	return &dto.SavedViewResponse{
+		ID:        view.UUID,
+		Name:      view.Name,
+		Filter:    toTaskFilterDTO(view.Filter),
+		Sort:      dto.TaskSort{Field: view.Sort.Field, Order: order, CustomFieldID: view.Sort.CustomFieldID},
+		Columns:   view.Columns,
+		Pinned:    view.Pinned,
+		CreatedAt: view.CreatedAt,
+		UpdatedAt: view.UpdatedAt,
+	}
+}
+
+// ToDTOList converts a list of view entities to DTOs
+func (p *SavedViewPresenter) ToDTOList(views []*entity.SavedView) *dto.SavedViewsResponse {
+	response := &dto.SavedViewsResponse{
+		Views: make([]dto.SavedViewResponse, len(views)),
+	}
+	
+	for i, view := range views {
+		response.Views[i] = *p.ToDTO(view)
+	}
+	
+	return response
+}
+
+// ToEntity converts a view request's filter and sort to domain values
+func (p *SavedViewPresenter) ToEntity(req *dto.SavedViewRequest) (entity.TaskFilter, entity.TaskSort) {
+	return p.ToTaskQuery(req.Filter, req.Sort)
+}
+
+// ToTaskQuery converts a task filter and sort to domain values
+func (p *SavedViewPresenter) ToTaskQuery(filterDTO dto.TaskFilter, sortDTO dto.TaskSort) (entity.TaskFilter, entity.TaskSort) {
+	sort := entity.TaskSort{
+		Field:         sortDTO.Field,
+		Descending:    sortDTO.Order == sortDescending,
+		CustomFieldID: sortDTO.CustomFieldID,
+	}
+	
+	filter := entity.TaskFilter{
+		Completed:  filterDTO.Completed,
+		CreatedBy:  filterDTO.CreatedBy,
+		AssignedTo: filterDTO.AssignedTo,
+		Search:     filterDTO.Search,
+		DueBefore:  filterDTO.DueBefore,
+		DueAfter:   filterDTO.DueAfter,
+		HasDueDate: filterDTO.HasDueDate,
+	}
+	for _, fieldFilter := range filterDTO.CustomFields {
+		filter.CustomFields = append(filter.CustomFields, entity.CustomFieldFilter(fieldFilter))
+	}
+	
+	return filter, sort
+}
+
+// toTaskFilterDTO converts a domain task filter to a DTO
+func toTaskFilterDTO(filter entity.TaskFilter) dto.TaskFilter {
+	filterDTO := dto.TaskFilter{
+		Completed:  filter.Completed,
+		CreatedBy:  filter.CreatedBy,
+		AssignedTo: filter.AssignedTo,
+		Search:     filter.Search,
+		DueBefore:  filter.DueBefore,
+		DueAfter:   filter.DueAfter,
+		HasDueDate: filter.HasDueDate,
+	}
+	for _, fieldFilter := range filter.CustomFields {
+		filterDTO.CustomFields = append(filterDTO.CustomFields, dto.CustomFieldFilter(fieldFilter))
+	}
+	
+	return filterDTO
+}
@@ -0,0 +1,26 @@
+package presenter
+
+import (
+	"task2/internal/app/dto"
+	"task2/internal/domain/entity"
+)
+
+// SnoozePresenter converts between domain entities and DTOs
+type SnoozePresenter struct{}
+
+// NewSnoozePresenter creates a new snooze presenter
+func NewSnoozePresenter() *SnoozePresenter {
+	return &SnoozePresenter{}
+}
+
+// ToDTO converts a snooze entity to a DTO
+func (p *SnoozePresenter) ToDTO(snooze *entity.Snooze) *dto.SnoozeResponse {
+	if snooze == nil {
+		return nil
+	}
+	
+	return &dto.SnoozeResponse{
+		TaskID: snooze.TaskID,
+		Until:  snooze.Until,
+	}
+}
@@ -3,14 +3,24 @@ package presenter
 import (
 	"task2/internal/app/dto"
 	"task2/internal/domain/entity"
+	"task2/pkg/markdown"
 )
 
 // TaskPresenter converts between domain entities and DTOs
-type TaskPresenter struct{}
+type TaskPresenter struct {
+	markdownRenderer *markdown.Renderer
+}
 
-// NewTaskPresenter creates a new task presenter
+// NewTaskPresenter creates a new task presenter that renders descriptions with the default allow-list
 func NewTaskPresenter() *TaskPresenter {
-	return &TaskPresenter{}
+	return &TaskPresenter{
+		markdownRenderer: markdown.NewRenderer(nil, nil),
+	}
+}
+
+// SetMarkdownRenderer sets the renderer used for description_html
+func (p *TaskPresenter) SetMarkdownRenderer(renderer *markdown.Renderer) {
+	p.markdownRenderer = renderer
 }
 
 // ToDTO converts a task entity to a DTO
@@ -21,13 +31,21 @@ func (p *TaskPresenter) ToDTO(task *entity.Task) *dto.TaskResponse {
 	
 	// Create task response
 	taskResponse := &dto.TaskResponse{
-		ID:          task.UUID,
-		Title:       task.Title,
-		Description: task.Description,
-		Completed:   task.Completed,
-		CreatedAt:   task.CreatedAt,
-		UpdatedAt:   task.UpdatedAt,
-		DeletedAt:   task.DeletedAt,
+		ID:              task.UUID,
+		Key:             task.Key,
+		Title:           task.Title,
+		Description:     task.Description,
+		DescriptionHTML: p.markdownRenderer.Render(task.Description),
+		Completed:       task.Completed,
+		DueDate:         task.DueDate,
+		
+		AwaitingApproval:  task.AwaitingApproval,
+		RequiredApprovals: task.RequiredApprovals,
+		
+		Version:   task.Version,
+		CreatedAt: task.CreatedAt,
+		UpdatedAt: task.UpdatedAt,
+		DeletedAt: task.DeletedAt,
 	}
 	
 	// Add created by
@@ -60,9 +78,78 @@ func (p *TaskPresenter) ToDTO(task *entity.Task) *dto.TaskResponse {
 		}
 	}
 	
+	// Add mentioned users, without their email, as anyone can write a mention
+	if len(task.Mentions) > 0 {
+		taskResponse.Mentions = make([]dto.UserInfo, len(task.Mentions))
+		for i, user := range task.Mentions {
+			taskResponse.Mentions[i] = dto.UserInfo{
+				ID:   user.UUID,
+				Name: user.Name,
+			}
+		}
+	}
+	
+	// Add reviewers
+	if len(task.Reviewers) > 0 {
+		taskResponse.Reviewers = make([]dto.UserSummary, len(task.Reviewers))
+		for i, user := range task.Reviewers {
+			taskResponse.Reviewers[i] = dto.UserSummary{
+				ID:    user.UUID,
+				Name:  user.Name,
+				Email: user.Email,
+			}
+		}
+	}
+	
+	// Add links
+	if len(task.Links) > 0 {
+		taskResponse.Links = make([]dto.TaskLinkResponse, len(task.Links))
+		for i, link := range task.Links {
+			taskResponse.Links[i] = dto.TaskLinkResponse{
+				ID:     link.UUID,
+				Type:   link.Type,
+				TaskID: link.TargetTaskID,
+			}
+			if link.TargetTask != nil {
+				taskResponse.Links[i].TaskKey = link.TargetTask.Key
+				taskResponse.Links[i].Title = link.TargetTask.Title
+				taskResponse.Links[i].Completed = link.TargetTask.Completed
+			}
+		}
+	}
+	
+	// Add custom field values
+	if len(task.CustomFields) > 0 {
+		taskResponse.CustomFields = make([]dto.CustomFieldValueResponse, len(task.CustomFields))
+		for i, fieldValue := range task.CustomFields {
+			taskResponse.CustomFields[i] = dto.CustomFieldValueResponse{
+				FieldID: fieldValue.Field.UUID,
+				Name:    fieldValue.Field.Name,
+				Type:    fieldValue.Field.Type,
+				Value:   fieldValue.Value,
+			}
+		}
+	}
+	
 	return taskResponse
 }
 
+// ToApprovalDTOList converts review decisions to DTOs
+func (p *TaskPresenter) ToApprovalDTOList(decisions []*entity.ApprovalDecision) []dto.ApprovalResponse {
+	result := make([]dto.ApprovalResponse, 0, len(decisions))
+	for _, decision := range decisions {
+		result = append(result, dto.ApprovalResponse{
+			ID:         decision.UUID,
+			ReviewerID: decision.ReviewerID,
+			Round:      decision.Round,
+			Decision:   decision.Decision,
+			Comment:    decision.Comment,
+			CreatedAt:  decision.CreatedAt,
+		})
+	}
+	return result
+}
+
 // ToDTOList converts a list of task entities to DTOs
 func (p *TaskPresenter) ToDTOList(tasks []*entity.Task) *dto.TasksResponse {
 	if tasks == nil {
@@ -83,4 +170,32 @@ func (p *TaskPresenter) ToDTOList(tasks []*entity.Task) *dto.TasksResponse {
 	return &dto.TasksResponse{
 		Tasks: taskResponses,
 	}
-}
\ No newline at end of file
+}
+
+// ToRecord converts a task entity to an import/export record
+func (p *TaskPresenter) ToRecord(task *entity.Task) dto.TaskRecord {
+	record := dto.TaskRecord{
+		ExternalID:  task.ExternalID,
+		ID:          &task.UUID,
+		Title:       task.Title,
+		Description: task.Description,
+		Completed:   task.Completed,
+		DueDate:     task.DueDate,
+		CreatedAt:   &task.CreatedAt,
+		UpdatedAt:   &task.UpdatedAt,
+	}
+
+	if task.CreatedBy != nil {
+		record.CreatedBy = task.CreatedBy.Email
+	}
+
+	if task.AssignedTo != nil {
+		record.AssignedTo = task.AssignedTo.Email
+	}
+
+	for _, user := range task.Users {
+		record.Members = append(record.Members, user.Email)
+	}
+
+	return record
+}
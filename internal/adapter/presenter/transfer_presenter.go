@@ -0,0 +1,41 @@
+package presenter
+
+import (
+	"task2/internal/app/dto"
+	"task2/internal/domain/entity"
+)
+
+// TransferPresenter converts between domain entities and DTOs
+type TransferPresenter struct{}
+
+// NewTransferPresenter creates a new ownership transfer presenter
+func NewTransferPresenter() *TransferPresenter {
+	return &TransferPresenter{}
+}
+
+// ToDTO converts a transfer entity to a DTO
+func (p *TransferPresenter) ToDTO(transfer *entity.OwnershipTransfer) *dto.TransferResponse {
+	if transfer == nil {
+		return nil
+	}
+	
+	return &dto.TransferResponse{
+		ID:          transfer.UUID,
+		TaskID:      transfer.TaskID,
+		FromUserID:  transfer.FromUserID,
+		ToUserID:    transfer.ToUserID,
+		RequestedBy: transfer.RequestedByID,
+		Status:      transfer.Status,
+		CreatedAt:   transfer.CreatedAt,
+		RespondedAt: transfer.RespondedAt,
+	}
+}
+
+// ToDTOList converts a list of transfer entities to DTOs
+func (p *TransferPresenter) ToDTOList(transfers []*entity.OwnershipTransfer) []dto.TransferResponse {
+	result := make([]dto.TransferResponse, 0, len(transfers))
+	for _, transfer := range transfers {
+		result = append(result, *p.ToDTO(transfer))
+	}
+	return result
+}
@@ -20,12 +20,15 @@ func (p *UserPresenter) ToDTO(user *entity.User) *dto.UserResponse {
 	}
 	
 	return &dto.UserResponse{
-		ID:        user.UUID,
-		Name:      user.Name,
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-		DeletedAt: user.DeletedAt,
+		ID:            user.UUID,
+		Name:          user.Name,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+		Role:          string(user.Role),
+		SuspendedAt:   user.SuspendedAt,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+		DeletedAt:     user.DeletedAt,
 	}
 }
 
@@ -62,4 +65,4 @@ func (p *UserPresenter) ToDTOList(users []*entity.User) *dto.UsersResponse {
 	return &dto.UsersResponse{
 		Users: userResponses,
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"task2/internal/domain/entity"
+	"task2/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ApprovalRepository implements the domain.ApprovalRepository interface
+type ApprovalRepository struct {
+	db *bun.DB
+}
+
+// NewApprovalRepository creates a new approval repository
+func NewApprovalRepository(db *bun.DB) *ApprovalRepository {
+	return &ApprovalRepository{
+		db: db,
+	}
+}
+
+// conn returns the connection to use for the request, joining any active transaction
+func (r *ApprovalRepository) conn(ctx context.Context) bun.IDB {
+	return conn(ctx, r.db)
+}
+
+// Create records a decision
+func (r *ApprovalRepository) Create(ctx context.Context, decision *entity.ApprovalDecision) error {
+	// Convert domain entity to persistence model
+	dbDecision := &persistence.ApprovalDecision{
+		UUID:       decision.UUID,
+		TaskID:     decision.TaskID,
+		ReviewerID: decision.ReviewerID,
+		Round:      decision.Round,
+		Decision:   decision.Decision,
+		Comment:    decision.Comment,
+		CreatedAt:  decision.CreatedAt,
+	}
+	
+	// Insert decision
+	if _, err := r.conn(ctx).NewInsert().Model(dbDecision).Exec(ctx); err != nil {
+		return err
+	}
+	
+	// Update decision ID
+	decision.ID = dbDecision.ID
+	
+	return nil
+}
+
+// GetByTask gets every decision on a task, oldest first
+func (r *ApprovalRepository) GetByTask(ctx context.Context, taskUUID uuid.UUID) ([]*entity.ApprovalDecision, error) {
+	var dbDecisions []persistence.ApprovalDecision
+	
+	// Get decisions
+	err := r.conn(ctx).NewSelect().
+		Model(&dbDecisions).
+		Where("task_id = ?", taskUUID).
+		Order("id ASC").
+		Scan(ctx)
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	return toApprovalEntities(dbDecisions), nil
+}
+
+// GetByTaskAndRound gets the decisions on a task in one review round
+func (r *ApprovalRepository) GetByTaskAndRound(ctx context.Context, taskUUID uuid.UUID, round int) ([]*entity.ApprovalDecision, error) {
+	var dbDecisions []persistence.ApprovalDecision
+	
+	// Get decisions
+	err := r.conn(ctx).NewSelect().
+		Model(&dbDecisions).
+		Where("task_id = ?", taskUUID).
+		Where("round = ?", round).
+		Order("id ASC").
+		Scan(ctx)
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	return toApprovalEntities(dbDecisions), nil
+}
+
+// toApprovalEntities converts persistence decisions to domain entities
+func toApprovalEntities(dbDecisions []persistence.ApprovalDecision) []*entity.ApprovalDecision {
+	decisions := make([]*entity.ApprovalDecision, len(dbDecisions))
+	for i, dbDecision := range dbDecisions {
+		decisions[i] = &entity.ApprovalDecision{
+			ID:         dbDecision.ID,
+			UUID:       dbDecision.UUID,
+			TaskID:     dbDecision.TaskID,
+			ReviewerID: dbDecision.ReviewerID,
+			Round:      dbDecision.Round,
+			Decision:   dbDecision.Decision,
+			Comment:    dbDecision.Comment,
+			CreatedAt:  dbDecision.CreatedAt,
+		}
+	}
+	return decisions
+}
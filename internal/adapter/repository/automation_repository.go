@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"context"
+	"task2/internal/domain/entity"
+	"task2/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// AutomationRepository implements the domain.AutomationRepository interface
+type AutomationRepository struct {
+	db *bun.DB
+}
+
+// NewAutomationRepository creates a new automation repository
+func NewAutomationRepository(db *bun.DB) *AutomationRepository {
+	return &AutomationRepository{
+		db: db,
+	}
+}
+
+// conn returns the connection to use for the request, joining any active transaction
+func (r *AutomationRepository) conn(ctx context.Context) bun.IDB {
+	return conn(ctx, r.db)
+}
+
+// Create creates a new rule
+func (r *AutomationRepository) Create(ctx context.Context, rule *entity.AutomationRule) error {
+	dbRule := toAutomationRuleModel(rule)
+	
+	// Insert rule
+	if _, err := r.conn(ctx).NewInsert().Model(dbRule).Exec(ctx); err != nil {
+		return err
+	}
+	
+	// Update rule ID
+	rule.ID = dbRule.ID
+	
+	return nil
+}
+
+// GetByUUID gets a rule by UUID
+func (r *AutomationRepository) GetByUUID(ctx context.Context, uuid uuid.UUID) (*entity.AutomationRule, error) {
+	dbRule := new(persistence.AutomationRule)
+	
+	// Get rule
+	err := r.conn(ctx).NewSelect().
+		Model(dbRule).
+		Where("uuid = ?", uuid).
+		Scan(ctx)
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	// Convert to domain entity
+	return toAutomationRuleEntity(dbRule), nil
+}
+
+// GetByOwner gets the rules owned by a user
+func (r *AutomationRepository) GetByOwner(ctx context.Context, ownerUUID uuid.UUID) ([]*entity.AutomationRule, error) {
+	return r.getRules(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("owner_id = ?", ownerUUID)
+	})
+}
+
+// GetEnabledByOwnerAndTrigger gets a user's enabled rules for a trigger
+func (r *AutomationRepository) GetEnabledByOwnerAndTrigger(ctx context.Context, ownerUUID uuid.UUID, trigger string) ([]*entity.AutomationRule, error) {
+	return r.getRules(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("owner_id = ?", ownerUUID).Where("trigger = ?", trigger).Where("enabled")
+	})
+}
+
+// GetEnabledByTrigger gets all enabled rules for a trigger
+func (r *AutomationRepository) GetEnabledByTrigger(ctx context.Context, trigger string) ([]*entity.AutomationRule, error) {
+	return r.getRules(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("trigger = ?", trigger).Where("enabled")
+	})
+}
+
+// getRules gets the rules selected by filter in creation order
+func (r *AutomationRepository) getRules(ctx context.Context, filter func(*bun.SelectQuery) *bun.SelectQuery) ([]*entity.AutomationRule, error) {
+	var dbRules []persistence.AutomationRule
+	
+	// Get rules
+	err := r.conn(ctx).NewSelect().
+		Model(&dbRules).
+		Apply(filter).
+		Order("created_at ASC").
+		Scan(ctx)
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	// Convert to domain entities
+	rules := make([]*entity.AutomationRule, len(dbRules))
+	for i, dbRule := range dbRules {
+		rules[i] = toAutomationRuleEntity(&dbRule)
+	}
+	
+	return rules, nil
+}
+
+// Update updates a rule
+func (r *AutomationRepository) Update(ctx context.Context, rule *entity.AutomationRule) error {
+	_, err := r.conn(ctx).NewUpdate().
+		Model(toAutomationRuleModel(rule)).
+		Column("name", "trigger", "overdue_days", "conditions", "actions", "enabled", "updated_at").
+		WherePK().
+		Exec(ctx)
+	
+	return err
+}
+
+// Delete deletes a rule and its execution log
+func (r *AutomationRepository) Delete(ctx context.Context, uuid uuid.UUID) error {
+	return r.conn(ctx).RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Get rule
+		dbRule := new(persistence.AutomationRule)
+		if err := tx.NewSelect().Model(dbRule).Where("uuid = ?", uuid).Scan(ctx); err != nil {
+			return err
+		}
+		
+		// Delete execution log
+		_, err := tx.NewDelete().
+			Model((*persistence.AutomationExecution)(nil)).
+			Where("rule_id = ?", dbRule.ID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		
+		// Delete rule
+		_, err = tx.NewDelete().Model(dbRule).WherePK().Exec(ctx)
+		return err
+	})
+}
+
+// CreateExecution records the start of an execution, unless one with the same dedupe key exists
+func (r *AutomationRepository) CreateExecution(ctx context.Context, execution *entity.AutomationExecution) (bool, error) {
+	dbExecution := &persistence.AutomationExecution{
+		UUID:      execution.UUID,
+		RuleID:    execution.RuleID,
+		TaskID:    execution.TaskID,
+		Trigger:   execution.Trigger,
+		Status:    execution.Status,
+		Error:     execution.Error,
+		DedupeKey: execution.DedupeKey,
+		CreatedAt: execution.CreatedAt,
+	}
+	
+	// Insert execution; the unique dedupe key makes concurrent schedulers skip claimed work
+	res, err := r.conn(ctx).NewInsert().
+		Model(dbExecution).
+		On("CONFLICT (dedupe_key) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if inserted == 0 {
+		return false, nil
+	}
+	
+	execution.ID = dbExecution.ID
+	return true, nil
+}
+
+// UpdateExecution records the outcome of an execution
+func (r *AutomationRepository) UpdateExecution(ctx context.Context, execution *entity.AutomationExecution) error {
+	_, err := r.conn(ctx).NewUpdate().
+		Model((*persistence.AutomationExecution)(nil)).
+		Set("status = ?", execution.Status).
+		Set("error = ?", execution.Error).
+		Where("id = ?", execution.ID).
+		Exec(ctx)
+	
+	return err
+}
+
+// GetExecutions gets the most recent executions of a rule, newest first
+func (r *AutomationRepository) GetExecutions(ctx context.Context, ruleID int64, limit int) ([]*entity.AutomationExecution, error) {
+	var dbExecutions []persistence.AutomationExecution
+	
+	// Get executions
+	err := r.conn(ctx).NewSelect().
+		Model(&dbExecutions).
+		Where("rule_id = ?", ruleID).
+		Order("created_at DESC", "id DESC").
+		Limit(limit).
+		Scan(ctx)
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	// Convert to domain entities
+	executions := make([]*entity.AutomationExecution, len(dbExecutions))
+	for i, dbExecution := range dbExecutions {
+		executions[i] = &entity.AutomationExecution{
+			ID:        dbExecution.ID,
+			UUID:      dbExecution.UUID,
+			RuleID:    dbExecution.RuleID,
+			TaskID:    dbExecution.TaskID,
+			Trigger:   dbExecution.Trigger,
+			Status:    dbExecution.Status,
+			Error:     dbExecution.Error,
+			DedupeKey: dbExecution.DedupeKey,
+			CreatedAt: dbExecution.CreatedAt,
+		}
+	}
+	
+	return executions, nil
+}
+
+// toAutomationRuleModel converts a domain rule to a persistence model
+func toAutomationRuleModel(rule *entity.AutomationRule) *persistence.AutomationRule {
+	dbRule := &persistence.AutomationRule{
+		ID:          rule.ID,
+		UUID:        rule.UUID,
+		OwnerID:     rule.OwnerID,
+		Name:        rule.Name,
+		Trigger:     rule.Trigger,
+		OverdueDays: rule.OverdueDays,
+		Conditions:  make([]persistence.AutomationCondition, len(rule.Conditions)),
+		Actions:     make([]persistence.AutomationAction, len(rule.Actions)),
+		Enabled:     rule.Enabled,
+		CreatedAt:   rule.CreatedAt,
+		UpdatedAt:   rule.UpdatedAt,
+	}
+	
+	for i, condition := range rule.Conditions {
+		dbRule.Conditions[i] = persistence.AutomationCondition(condition)
+	}
+	for i, action := range rule.Actions {
+		dbRule.Actions[i] = persistence.AutomationAction(action)
+	}
+	
+	return dbRule
+}
+
+// toAutomationRuleEntity converts a persistence rule to a domain entity
+func toAutomationRuleEntity(dbRule *persistence.AutomationRule) *entity.AutomationRule {
+	rule := &entity.AutomationRule{
+		ID:          dbRule.ID,
+		UUID:        dbRule.UUID,
+		OwnerID:     dbRule.OwnerID,
+		Name:        dbRule.Name,
+		Trigger:     dbRule.Trigger,
+		OverdueDays: dbRule.OverdueDays,
+		Conditions:  make([]entity.RuleCondition, len(dbRule.Conditions)),
+		Actions:     make([]entity.RuleAction, len(dbRule.Actions)),
+		Enabled:     dbRule.Enabled,
+		CreatedAt:   dbRule.CreatedAt,
+		UpdatedAt:   dbRule.UpdatedAt,
+	}
+	
+	for i, condition := range dbRule.Conditions {
+		rule.Conditions[i] = entity.RuleCondition(condition)
+	}
+	for i, action := range dbRule.Actions {
+		rule.Actions[i] = entity.RuleAction(action)
+	}
+	
+	return rule
+}
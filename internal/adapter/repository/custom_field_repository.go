@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+	"task2/internal/domain/entity"
+	"task2/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// CustomFieldRepository implements the domain.CustomFieldRepository interface
+type CustomFieldRepository struct {
+	db *bun.DB
+}
+
+// NewCustomFieldRepository creates a new custom field repository
+func NewCustomFieldRepository(db *bun.DB) *CustomFieldRepository {
+	return &CustomFieldRepository{
+		db: db,
+	}
+}
+
+// conn returns the connection to use for the request, joining any active transaction
+func (r *CustomFieldRepository) conn(ctx context.Context) bun.IDB {
+	return conn(ctx, r.db)
+}
+
+// Create creates a new field
+func (r *CustomFieldRepository) Create(ctx context.Context, field *entity.CustomField) error {
+	dbField := toCustomFieldModel(field)
+
+	// Insert field
+	_, err := r.conn(ctx).NewInsert().
+		Model(dbField).
+		Returning("id").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Update field ID
+	field.ID = dbField.ID
+
+	return nil
+}
+
+// GetByUUID gets a field by UUID
+func (r *CustomFieldRepository) GetByUUID(ctx context.Context, uuid uuid.UUID) (*entity.CustomField, error) {
+	dbField := new(persistence.CustomField)
+
+	// Get field
+	err := r.conn(ctx).NewSelect().
+		Model(dbField).
+		Where("uuid = ?", uuid).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to domain entity
+	return toCustomFieldEntity(dbField), nil
+}
+
+// GetByOwner gets the fields owned by a user
+func (r *CustomFieldRepository) GetByOwner(ctx context.Context, ownerUUID uuid.UUID) ([]*entity.CustomField, error) {
+	var dbFields []persistence.CustomField
+
+	// Get fields
+	err := r.conn(ctx).NewSelect().
+		Model(&dbFields).
+		Where("owner_id = ?", ownerUUID).
+		Order("name ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to domain entities
+	fields := make([]*entity.CustomField, len(dbFields))
+	for i, dbField := range dbFields {
+		fields[i] = toCustomFieldEntity(&dbField)
+	}
+
+	return fields, nil
+}
+
+// Update updates a field's name and options
+func (r *CustomFieldRepository) Update(ctx context.Context, field *entity.CustomField) error {
+	_, err := r.conn(ctx).NewUpdate().
+		Model(toCustomFieldModel(field)).
+		Column("name", "options", "updated_at").
+		WherePK().
+		Exec(ctx)
+
+	return err
+}
+
+// Delete deletes a field and its values
+func (r *CustomFieldRepository) Delete(ctx context.Context, uuid uuid.UUID) error {
+	return r.conn(ctx).RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Delete values
+		_, err := tx.NewDelete().
+			Model((*persistence.TaskFieldValue)(nil)).
+			Where("field_id = (SELECT id FROM custom_fields WHERE uuid = ?)", uuid).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		// Delete field
+		_, err = tx.NewDelete().
+			Model((*persistence.CustomField)(nil)).
+			Where("uuid = ?", uuid).
+			Exec(ctx)
+
+		return err
+	})
+}
+
+// SetValue sets the value of a field on a task, replacing any previous value
+func (r *CustomFieldRepository) SetValue(ctx context.Context, task *entity.Task, field *entity.CustomField, value any) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.conn(ctx).NewInsert().
+		Model(&persistence.TaskFieldValue{
+			TaskID:    task.ID,
+			FieldID:   field.ID,
+			Value:     encoded,
+			UpdatedAt: time.Now(),
+		}).
+		On("CONFLICT (task_id, field_id) DO UPDATE").
+		Set("value = EXCLUDED.value").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+
+	return err
+}
+
+// ClearValue removes the value of a field from a task
+func (r *CustomFieldRepository) ClearValue(ctx context.Context, task *entity.Task, field *entity.CustomField) error {
+	_, err := r.conn(ctx).NewDelete().
+		Model((*persistence.TaskFieldValue)(nil)).
+		Where("task_id = ?", task.ID).
+		Where("field_id = ?", field.ID).
+		Exec(ctx)
+
+	return err
+}
+
+// toCustomFieldModel converts a domain field to a persistence model
+func toCustomFieldModel(field *entity.CustomField) *persistence.CustomField {
+	options := field.Options
+	if options == nil {
+		options = []string{}
+	}
+
+	return &persistence.CustomField{
+		ID:        field.ID,
+		UUID:      field.UUID,
+		OwnerID:   field.OwnerID,
+		Name:      field.Name,
+		Type:      field.Type,
+		Options:   options,
+		CreatedAt: field.CreatedAt,
+		UpdatedAt: field.UpdatedAt,
+	}
+}
+
+// toCustomFieldEntity converts a persistence field to a domain entity
+func toCustomFieldEntity(dbField *persistence.CustomField) *entity.CustomField {
+	field := &entity.CustomField{
+		ID:        dbField.ID,
+		UUID:      dbField.UUID,
+		OwnerID:   dbField.OwnerID,
+		Name:      dbField.Name,
+		Type:      dbField.Type,
+		CreatedAt: dbField.CreatedAt,
+		UpdatedAt: dbField.UpdatedAt,
+	}
+	if len(dbField.Options) > 0 {
+		field.Options = dbField.Options
+	}
+
+	return field
+}
+
+// toCustomFieldValueEntity converts a stored field value to a domain value in the form returned by ParseValue
+func toCustomFieldValueEntity(dbValue *persistence.TaskFieldValue) (*entity.CustomFieldValue, error) {
+	field := toCustomFieldEntity(dbValue.Field)
+
+	var value any
+	if field.Type == entity.CustomFieldMultiSelect {
+		var options []string
+		if err := json.Unmarshal(dbValue.Value, &options); err != nil {
+			return nil, err
+		}
+		value = options
+	} else if err := json.Unmarshal(dbValue.Value, &value); err != nil {
+		return nil, err
+	}
+
+	return &entity.CustomFieldValue{Field: field, Value: value}, nil
+}
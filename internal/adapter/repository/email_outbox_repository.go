@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"task2/internal/domain/entity"
+	"task2/internal/infrastructure/persistence"
+
+	"github.com/uptrace/bun"
+)
+
+// EmailOutboxRepository implements the domain.EmailOutboxRepository interface
+type EmailOutboxRepository struct {
+	db *bun.DB
+}
+
+// NewEmailOutboxRepository creates a new email outbox repository
+func NewEmailOutboxRepository(db *bun.DB) *EmailOutboxRepository {
+	return &EmailOutboxRepository{
+		db: db,
+	}
+}
+
+// conn returns the connection to use for the request, joining any active transaction
+func (r *EmailOutboxRepository) conn(ctx context.Context) bun.IDB {
+	return conn(ctx, r.db)
+}
+
+// Create adds an email to the outbox
+func (r *EmailOutboxRepository) Create(ctx context.Context, email *entity.OutboxEmail) error {
+	// Convert domain entity to persistence model
+	dbEmail := &persistence.OutboxEmail{
+		UUID:          email.UUID,
+		To:            email.To,
+		Subject:       email.Subject,
+		Body:          email.Body,
+		NextAttemptAt: email.NextAttemptAt,
+		CreatedAt:     email.CreatedAt,
+	}
+	
+	// Insert email
+	if _, err := r.conn(ctx).NewInsert().Model(dbEmail).Exec(ctx); err != nil {
+		return err
+	}
+	
+	// Update email ID
+	email.ID = dbEmail.ID
+	
+	return nil
+}
+
+// ClaimDue locks unsent emails that are due for an attempt, oldest first, skipping rows locked by other instances
+func (r *EmailOutboxRepository) ClaimDue(ctx context.Context, now time.Time, limit int) ([]*entity.OutboxEmail, error) {
+	var dbEmails []persistence.OutboxEmail
+	
+	err := r.conn(ctx).NewSelect().
+		Model(&dbEmails).
+		Where("sent_at IS NULL").
+		Where("attempts < ?", entity.MaxEmailAttempts).
+		Where("next_attempt_at <= ?", now).
+		Order("id").
+		Limit(limit).
+		For("UPDATE SKIP LOCKED").
+		Scan(ctx)
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	emails := make([]*entity.OutboxEmail, len(dbEmails))
+	for i := range dbEmails {
+		emails[i] = toOutboxEmailEntity(&dbEmails[i])
+	}
+	return emails, nil
+}
+
+// UpdateDelivery saves the outcome of an attempt to send an email
+func (r *EmailOutboxRepository) UpdateDelivery(ctx context.Context, email *entity.OutboxEmail) error {
+	_, err := r.conn(ctx).NewUpdate().
+		Model((*persistence.OutboxEmail)(nil)).
+		Set("attempts = ?", email.Attempts).
+		Set("last_error = ?", email.LastError).
+		Set("next_attempt_at = ?", email.NextAttemptAt).
+		Set("sent_at = ?", email.SentAt).
+		Where("uuid = ?", email.UUID).
+		Exec(ctx)
+	
+	return err
+}
+
+// toOutboxEmailEntity converts a persistence outbox email to a domain entity
+func toOutboxEmailEntity(dbEmail *persistence.OutboxEmail) *entity.OutboxEmail {
+	return &entity.OutboxEmail{
+		ID:            dbEmail.ID,
+		UUID:          dbEmail.UUID,
+		To:            dbEmail.To,
+		Subject:       dbEmail.Subject,
+		Body:          dbEmail.Body,
+		Attempts:      dbEmail.Attempts,
+		LastError:     dbEmail.LastError,
+		NextAttemptAt: dbEmail.NextAttemptAt,
+		SentAt:        dbEmail.SentAt,
+		CreatedAt:     dbEmail.CreatedAt,
+	}
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"task2/internal/domain/entity"
+	"task2/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// NotificationRepository implements the domain.NotificationRepository interface
+type NotificationRepository struct {
+	db *bun.DB
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *bun.DB) *NotificationRepository {
+	return &NotificationRepository{
+		db: db,
+	}
+}
+
+// conn returns the connection to use for the request, joining any active transaction
+func (r *NotificationRepository) conn(ctx context.Context) bun.IDB {
+	return conn(ctx, r.db)
+}
+
+// Create creates a new notification
+func (r *NotificationRepository) Create(ctx context.Context, notification *entity.Notification) error {
+	// Convert domain entity to persistence model
+	dbNotification := &persistence.Notification{
+		UUID:      notification.UUID,
+		UserID:    notification.UserID,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Message:   notification.Message,
+		TaskID:    notification.TaskID,
+		CreatedAt: notification.CreatedAt,
+	}
+	
+	// Insert notification
+	if _, err := r.conn(ctx).NewInsert().Model(dbNotification).Exec(ctx); err != nil {
+		return err
+	}
+	
+	// Update notification ID
+	notification.ID = dbNotification.ID
+	
+	return nil
+}
+
+// GetByUUID gets a notification by UUID
+func (r *NotificationRepository) GetByUUID(ctx context.Context, uuid uuid.UUID) (*entity.Notification, error) {
+	dbNotification := new(persistence.Notification)
+	
+	// Get notification
+	err := r.conn(ctx).NewSelect().
+		Model(dbNotification).
+		Where("uuid = ?", uuid).
+		Scan(ctx)
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	// Convert to domain entity
+	return toNotificationEntity(dbNotification), nil
+}
+
+// GetByUser gets a user's notifications, newest first
+func (r *NotificationRepository) GetByUser(ctx context.Context, userUUID uuid.UUID, unreadOnly bool) ([]*entity.Notification, error) {
+	var dbNotifications []persistence.Notification
+	
+	// Get notifications
+	query := r.conn(ctx).NewSelect().
+		Model(&dbNotifications).
+		Where("user_id = ?", userUUID).
+		Order("created_at DESC")
+	
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+	
+	// Convert to domain entities
+	notifications := make([]*entity.Notification, len(dbNotifications))
+	for i, dbNotification := range dbNotifications {
+		notifications[i] = toNotificationEntity(&dbNotification)
+	}
+	
+	return notifications, nil
+}
+
+// MarkRead marks a notification as read
+func (r *NotificationRepository) MarkRead(ctx context.Context, notification *entity.Notification) error {
+	_, err := r.conn(ctx).NewUpdate().
+		Model((*persistence.Notification)(nil)).
+		Set("read_at = ?", notification.ReadAt).
+		Where("uuid = ?", notification.UUID).
+		Exec(ctx)
+	
+	return err
+}
+
+// MarkAllRead marks all of a user's notifications as read
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userUUID uuid.UUID) error {
+	_, err := r.conn(ctx).NewUpdate().
+		Model((*persistence.Notification)(nil)).
+		Set("read_at = ?", time.Now()).
+		Where("user_id = ?", userUUID).
+		Where("read_at IS NULL").
+		Exec(ctx)
+	
+	return err
+}
+
+// toNotificationEntity converts a persistence notification to a domain entity
+func toNotificationEntity(dbNotification *persistence.Notification) *entity.Notification {
+	return &entity.Notification{
+		ID:        dbNotification.ID,
+		UUID:      dbNotification.UUID,
+		UserID:    dbNotification.UserID,
+		Type:      dbNotification.Type,
+		Title:     dbNotification.Title,
+		Message:   dbNotification.Message,
+		TaskID:    dbNotification.TaskID,
+		ReadAt:    dbNotification.ReadAt,
+		CreatedAt: dbNotification.CreatedAt,
+	}
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"task2/internal/domain/entity"
+	"task2/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// PasswordResetTokenRepository implements the domain.PasswordResetTokenRepository interface
+type PasswordResetTokenRepository struct {
+	db *bun.DB
+}
+
+// NewPasswordResetTokenRepository creates a new password reset token repository
+func NewPasswordResetTokenRepository(db *bun.DB) *PasswordResetTokenRepository {
+	return &PasswordResetTokenRepository{
+		db: db,
+	}
+}
+
+// conn returns the connection to use for the request, joining any active transaction
+func (r *PasswordResetTokenRepository) conn(ctx context.Context) bun.IDB {
+	return conn(ctx, r.db)
+}
+
+// Create creates a new password reset token
+func (r *PasswordResetTokenRepository) Create(ctx context.Context, token *entity.PasswordResetToken) error {
+	// Convert domain entity to persistence model
+	dbToken := &persistence.PasswordResetToken{
+		UserID:    token.UserID,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	}
+	
+	// Insert password reset token
+	_, err := r.conn(ctx).NewInsert().
+		Model(dbToken).
+		Returning("id").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	
+	// Update password reset token ID
+	token.ID = dbToken.ID
+	
+	return nil
+}
+
+// GetByHashForUpdate gets a password reset token by its hash, locking it until the surrounding transaction ends
+func (r *PasswordResetTokenRepository) GetByHashForUpdate(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error) {
+	dbToken := new(persistence.PasswordResetToken)
+	
+	err := r.conn(ctx).NewSelect().
+		Model(dbToken).
+		Where("token_hash = ?", tokenHash).
+		For("UPDATE").
+		Scan(ctx)
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	return &entity.PasswordResetToken{
+		ID:        dbToken.ID,
+		UserID:    dbToken.UserID,
+		TokenHash: dbToken.TokenHash,
+		ExpiresAt: dbToken.ExpiresAt,
+		UsedAt:    dbToken.UsedAt,
+		CreatedAt: dbToken.CreatedAt,
+	}, nil
+}
+
+// MarkAllUsedForUser marks every unused password reset token of a user as used
+func (r *PasswordResetTokenRepository) MarkAllUsedForUser(ctx context.Context, userUUID uuid.UUID, usedAt time.Time) error {
+	_, err := r.conn(ctx).NewUpdate().
+		Model((*persistence.PasswordResetToken)(nil)).
+		Set("used_at = ?", usedAt).
+		Where("user_id = ?", userUUID).
+		Where("used_at IS NULL").
+		Exec(ctx)
+	
+	return err
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"task2/internal/domain/entity"
+	"task2/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// PersonalAccessTokenRepository implements the domain.PersonalAccessTokenRepository interface
+type PersonalAccessTokenRepository struct {
+	db *bun.DB
+}
+
+// NewPersonalAccessTokenRepository creates a new personal access token repository
+func NewPersonalAccessTokenRepository(db *bun.DB) *PersonalAccessTokenRepository {
+	return &PersonalAccessTokenRepository{
+		db: db,
+	}
+}
+
+// conn returns the connection to use for the request, joining any active transaction
+func (r *PersonalAccessTokenRepository) conn(ctx context.Context) bun.IDB {
+	return conn(ctx, r.db)
+}
+
+// Create creates a new personal access token
+func (r *PersonalAccessTokenRepository) Create(ctx context.Context, token *entity.PersonalAccessToken) error {
+	dbToken := toPersonalAccessTokenModel(token)
+
+	// Insert personal access token
+	_, err := r.conn(ctx).NewInsert().
+		Model(dbToken).
+		Returning("id").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Update personal access token ID
+	token.ID = dbToken.ID
+
+	return nil
+}
+
+// GetByUUIDForUpdate gets a personal access token by UUID, locking it until the surrounding transaction ends
+func (r *PersonalAccessTokenRepository) GetByUUIDForUpdate(ctx context.Context, uuid uuid.UUID) (*entity.PersonalAccessToken, error) {
+	dbToken := new(persistence.PersonalAccessToken)
+
+	err := r.conn(ctx).NewSelect().
+		Model(dbToken).
+		Where("uuid = ?", uuid).
+		For("UPDATE").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toPersonalAccessTokenEntity(dbToken), nil
+}
+
+// GetByHash gets a personal access token by its hash
+func (r *PersonalAccessTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*entity.PersonalAccessToken, error) {
+	dbToken := new(persistence.PersonalAccessToken)
+
+	err := r.conn(ctx).NewSelect().
+		Model(dbToken).
+		Where("token_hash = ?", tokenHash).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toPersonalAccessTokenEntity(dbToken), nil
+}
+
+// GetByUser gets a user's personal access tokens that are not revoked, newest first
+func (r *PersonalAccessTokenRepository) GetByUser(ctx context.Context, userUUID uuid.UUID) ([]*entity.PersonalAccessToken, error) {
+	var dbTokens []persistence.PersonalAccessToken
+
+	err := r.conn(ctx).NewSelect().
+		Model(&dbTokens).
+		Where("user_id = ?", userUUID).
+		Where("revoked_at IS NULL").
+		Order("created_at DESC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to domain entities
+	tokens := make([]*entity.PersonalAccessToken, len(dbTokens))
+	for i, dbToken := range dbTokens {
+		tokens[i] = toPersonalAccessTokenEntity(&dbToken)
+	}
+
+	return tokens, nil
+}
+
+// Update updates the secret and revocation of a personal access token
+func (r *PersonalAccessTokenRepository) Update(ctx context.Context, token *entity.PersonalAccessToken) error {
+	_, err := r.conn(ctx).NewUpdate().
+		Model(toPersonalAccessTokenModel(token)).
+		Column("token_hash", "hint", "revoked_at", "updated_at").
+		WherePK().
+		Exec(ctx)
+
+	return err
+}
+
+// RecordUse records when and from which IP a personal access token was last used
+func (r *PersonalAccessTokenRepository) RecordUse(ctx context.Context, id int64, usedAt time.Time, ip string) error {
+	_, err := r.conn(ctx).NewUpdate().
+		Model((*persistence.PersonalAccessToken)(nil)).
+		Set("last_used_at = ?", usedAt).
+		Set("last_used_ip = ?", ip).
+		Where("id = ?", id).
+		Exec(ctx)
+
+	return err
+}
+
+// toPersonalAccessTokenModel converts a domain personal access token to a persistence model
+func toPersonalAccessTokenModel(token *entity.PersonalAccessToken) *persistence.PersonalAccessToken {
+	scopes := make([]string, len(token.Scopes))
+	for i, scope := range token.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	return &persistence.PersonalAccessToken{
+		ID:         token.ID,
+		UUID:       token.UUID,
+		UserID:     token.UserID,
+		Name:       token.Name,
+		Scopes:     scopes,
+		TokenHash:  token.TokenHash,
+		Hint:       token.Hint,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		LastUsedIP: token.LastUsedIP,
+		RevokedAt:  token.RevokedAt,
+		CreatedAt:  token.CreatedAt,
+		UpdatedAt:  token.UpdatedAt,
+	}
+}
+
+// toPersonalAccessTokenEntity converts a persistence personal access token to a domain entity
+func toPersonalAccessTokenEntity(dbToken *persistence.PersonalAccessToken) *entity.PersonalAccessToken {
+	scopes := make([]entity.Permission, len(dbToken.Scopes))
+	for i, scope := range dbToken.Scopes {
+		scopes[i] = entity.Permission(scope)
+	}
+
+	return &entity.PersonalAccessToken{
+		ID:         dbToken.ID,
+		UUID:       dbToken.UUID,
+		UserID:     dbToken.UserID,
+		Name:       dbToken.Name,
+		Scopes:     scopes,
+		TokenHash:  dbToken.TokenHash,
+		Hint:       dbToken.Hint,
+		ExpiresAt:  dbToken.ExpiresAt,
+		LastUsedAt: dbToken.LastUsedAt,
+		LastUsedIP: dbToken.LastUsedIP,
+		RevokedAt:  dbToken.RevokedAt,
+		CreatedAt:  dbToken.CreatedAt,
+		UpdatedAt:  dbToken.UpdatedAt,
+	}
+}
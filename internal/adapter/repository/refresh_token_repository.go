@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"task2/internal/domain/entity"
+	"task2/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// RefreshTokenRepository implements the domain.RefreshTokenRepository interface
+type RefreshTokenRepository struct {
+	db *bun.DB
+}
+
+// NewRefreshTokenRepository creates a new refresh token repository
+func NewRefreshTokenRepository(db *bun.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		db: db,
+	}
+}
+
+// conn returns the connection to use for the request, joining any active transaction
+func (r *RefreshTokenRepository) conn(ctx context.Context) bun.IDB {
+	return conn(ctx, r.db)
+}
+
+// Create creates a new refresh token
+func (r *RefreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) error {
+	// Convert domain entity to persistence model
+	dbToken := &persistence.RefreshToken{
+		UserID:    token.UserID,
+		FamilyID:  token.FamilyID,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	}
+	
+	// Insert refresh token
+	_, err := r.conn(ctx).NewInsert().
+		Model(dbToken).
+		Returning("id").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	
+	// Update refresh token ID
+	token.ID = dbToken.ID
+	
+	return nil
+}
+
+// GetByHashForUpdate gets a refresh token by its hash, locking it until the surrounding transaction ends
+func (r *RefreshTokenRepository) GetByHashForUpdate(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	dbToken := new(persistence.RefreshToken)
+	
+	err := r.conn(ctx).NewSelect().
+		Model(dbToken).
+		Where("token_hash = ?", tokenHash).
+		For("UPDATE").
+		Scan(ctx)
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	return &entity.RefreshToken{
+		ID:        dbToken.ID,
+		UserID:    dbToken.UserID,
+		FamilyID:  dbToken.FamilyID,
+		TokenHash: dbToken.TokenHash,
+		ExpiresAt: dbToken.ExpiresAt,
+		UsedAt:    dbToken.UsedAt,
+		RevokedAt: dbToken.RevokedAt,
+		CreatedAt: dbToken.CreatedAt,
+	}, nil
+}
+
+// MarkUsed records that a refresh token was exchanged
+func (r *RefreshTokenRepository) MarkUsed(ctx context.Context, id int64, usedAt time.Time) error {
+	_, err := r.conn(ctx).NewUpdate().
+		Model((*persistence.RefreshToken)(nil)).
+		Set("used_at = ?", usedAt).
+		Where("id = ?", id).
+		Exec(ctx)
+	
+	return err
+}
+
+// RevokeFamily revokes every refresh token in a family that is not already revoked
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID, revokedAt time.Time) error {
+	_, err := r.conn(ctx).NewUpdate().
+		Model((*persistence.RefreshToken)(nil)).
+		Set("revoked_at = ?", revokedAt).
+		Where("family_id = ?", familyID).
+		Where("revoked_at IS NULL").
+		Exec(ctx)
+	
+	return err
+}
+
+// RevokeAllForUser revokes every refresh token of a user that is not already revoked
+func (r *RefreshTokenRepository) RevokeAllForUser(ctx context.Context, userUUID uuid.UUID, revokedAt time.Time) error {
+	_, err := r.conn(ctx).NewUpdate().
+		Model((*persistence.RefreshToken)(nil)).
+		Set("revoked_at = ?", revokedAt).
+		Where("user_id = ?", userUUID).
+		Where("revoked_at IS NULL").
+		Exec(ctx)
+	
+	return err
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"task2/internal/domain/entity"
+	"task2/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ReminderRepository implements the domain.ReminderRepository interface
+type ReminderRepository struct {
+	db *bun.DB
+}
+
+// NewReminderRepository creates a new reminder repository
+func NewReminderRepository(db *bun.DB) *ReminderRepository {
+	return &ReminderRepository{
+		db: db,
+	}
+}
+
+// conn returns the connection to use for the request, joining any active transaction
+func (r *ReminderRepository) conn(ctx context.Context) bun.IDB {
+	return conn(ctx, r.db)
+}
+
+// Create creates a new reminder
+func (r *ReminderRepository) Create(ctx context.Context, reminder *entity.Reminder) error {
+	// Convert domain entity to persistence model
+	dbReminder := &persistence.Reminder{
+		UUID:             reminder.UUID,
+		TaskID:           reminder.TaskID,
+		CreatedByID:      reminder.CreatedByID,
+		RemindAt:         reminder.RemindAt,
+		MinutesBeforeDue: reminder.MinutesBeforeDue,
+		Recipients:       reminder.Recipients,
+		CreatedAt:        reminder.CreatedAt,
+	}
+	
+	// Insert reminder
+	if _, err := r.conn(ctx).NewInsert().Model(dbReminder).Exec(ctx); err != nil {
+		return err
+	}
+	
+	// Update reminder ID
+	reminder.ID = dbReminder.ID
+	
+	return nil
+}
+
+// GetByUUID gets a reminder by UUID
+func (r *ReminderRepository) GetByUUID(ctx context.Context, uuid uuid.UUID) (*entity.Reminder, error) {
+	dbReminder := new(persistence.Reminder)
+	
+	// Get reminder
+	err := r.conn(ctx).NewSelect().
+		Model(dbReminder).
+		Where("uuid = ?", uuid).
+		Scan(ctx)
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	// Convert to domain entity
+	return toReminderEntity(dbReminder), nil
+}
+
+// GetByTask gets a task's reminders, oldest first
+func (r *ReminderRepository) GetByTask(ctx context.Context, taskUUID uuid.UUID) ([]*entity.Reminder, error) {
+	var dbReminders []persistence.Reminder
+	
+	// Get reminders
+	err := r.conn(ctx).NewSelect().
+		Model(&dbReminders).
+		Where("task_id = ?", taskUUID).
+		Order("created_at ASC").
+		Scan(ctx)
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	return toReminderEntities(dbReminders), nil
+}
+
+// Delete deletes a reminder
+func (r *ReminderRepository) Delete(ctx context.Context, uuid uuid.UUID) error {
+	_, err := r.conn(ctx).NewDelete().
+		Model((*persistence.Reminder)(nil)).
+		Where("uuid = ?", uuid).
+		Exec(ctx)
+	
+	return err
+}
+
+// ClaimDue locks unsent reminders that are due, skipping rows locked by other instances.
+// Relative reminders are resolved against the task's current due date; reminders on
+// deleted or completed tasks are not fired.
+func (r *ReminderRepository) ClaimDue(ctx context.Context, now time.Time, limit int) ([]*entity.Reminder, error) {
+	var dbReminders []persistence.Reminder
+	
+	err := r.conn(ctx).NewSelect().
+		Model(&dbReminders).
+		Join("JOIN tasks AS t ON t.uuid = reminder.task_id").
+		Where("reminder.sent_at IS NULL").
+		Where("t.deleted_at IS NULL").
+		Where("t.completed = FALSE").
+		Where("COALESCE(reminder.remind_at, t.due_date - make_interval(mins => reminder.minutes_before_due)) <= ?", now).
+		Order("reminder.id").
+		Limit(limit).
+		For("UPDATE OF reminder SKIP LOCKED").
+		Scan(ctx)
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	return toReminderEntities(dbReminders), nil
+}
+
+// MarkSent marks a reminder as delivered
+func (r *ReminderRepository) MarkSent(ctx context.Context, reminder *entity.Reminder) error {
+	_, err := r.conn(ctx).NewUpdate().
+		Model((*persistence.Reminder)(nil)).
+		Set("sent_at = ?", reminder.SentAt).
+		Where("uuid = ?", reminder.UUID).
+		Exec(ctx)
+	
+	return err
+}
+
+// toReminderEntities converts persistence reminders to domain entities
+func toReminderEntities(dbReminders []persistence.Reminder) []*entity.Reminder {
+	reminders := make([]*entity.Reminder, len(dbReminders))
+	for i := range dbReminders {
+		reminders[i] = toReminderEntity(&dbReminders[i])
+	}
+	return reminders
+}
+
+// toReminderEntity converts a persistence reminder to a domain entity
+func toReminderEntity(dbReminder *persistence.Reminder) *entity.Reminder {
+	return &entity.Reminder{
+		ID:               dbReminder.ID,
+		UUID:             dbReminder.UUID,
+		TaskID:           dbReminder.TaskID,
+		CreatedByID:      dbReminder.CreatedByID,
+		RemindAt:         dbReminder.RemindAt,
+		MinutesBeforeDue: dbReminder.MinutesBeforeDue,
+		Recipients:       dbReminder.Recipients,
+		SentAt:           dbReminder.SentAt,
+		CreatedAt:        dbReminder.CreatedAt,
+	}
+}
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"task2/internal/domain/entity"
+	"task2/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// SavedViewRepository implements the domain.SavedViewRepository interface
+type SavedViewRepository struct {
+	db *bun.DB
+}
+
+// NewSavedViewRepository creates a new saved view repository
+func NewSavedViewRepository(db *bun.DB) *SavedViewRepository {
+	return &SavedViewRepository{
+		db: db,
+	}
+}
+
+// conn returns the connection to use for the request, joining any active transaction
+func (r *SavedViewRepository) conn(ctx context.Context) bun.IDB {
+	return conn(ctx, r.db)
+}
+
+// Create creates a new view
+func (r *SavedViewRepository) Create(ctx context.Context, view *entity.SavedView) error {
+	dbView := toSavedViewModel(view)
+	
+	// Insert view
+	if _, err := r.conn(ctx).NewInsert().Model(dbView).Exec(ctx); err != nil {
+		return err
+	}
+	
+	// Update view ID
+	view.ID = dbView.ID
+	
+	return nil
+}
+
+// GetByUUID gets a view by UUID
+func (r *SavedViewRepository) GetByUUID(ctx context.Context, uuid uuid.UUID) (*entity.SavedView, error) {
+	dbView := new(persistence.SavedView)
+	
+	// Get view
+	err := r.conn(ctx).NewSelect().
+		Model(dbView).
+		Where("uuid = ?", uuid).
+		Scan(ctx)
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	// Convert to domain entity
+	return toSavedViewEntity(dbView), nil
+}
+
+// GetByOwner gets the views owned by a user
+func (r *SavedViewRepository) GetByOwner(ctx context.Context, ownerUUID uuid.UUID) ([]*entity.SavedView, error) {
+	var dbViews []persistence.SavedView
+	
+	// Get views
+	err := r.conn(ctx).NewSelect().
+		Model(&dbViews).
+		Where("owner_id = ?", ownerUUID).
+		Order("name ASC").
+		Scan(ctx)
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	// Convert to domain entities
+	views := make([]*entity.SavedView, len(dbViews))
+	for i, dbView := range dbViews {
+		views[i] = toSavedViewEntity(&dbView)
+	}
+	
+	return views, nil
+}
+
+// Update updates a view
+func (r *SavedViewRepository) Update(ctx context.Context, view *entity.SavedView) error {
+	_, err := r.conn(ctx).NewUpdate().
+		Model(toSavedViewModel(view)).
+		Column("name", "filter", "sort_by", "sort_desc", "sort_field_id", "columns", "updated_at").
+		WherePK().
+		Exec(ctx)
+	
+	return err
+}
+
+// Delete deletes a view
+func (r *SavedViewRepository) Delete(ctx context.Context, uuid uuid.UUID) error {
+	_, err := r.conn(ctx).NewDelete().
+		Model((*persistence.SavedView)(nil)).
+		Where("uuid = ?", uuid).
+		Exec(ctx)
+	
+	return err
+}
+
+// Pin pins a view as its owner's default, unpinning any other
+func (r *SavedViewRepository) Pin(ctx context.Context, view *entity.SavedView) error {
+	return r.conn(ctx).RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Unpin the current default
+		_, err := tx.NewUpdate().
+			Model((*persistence.SavedView)(nil)).
+			Set("pinned = FALSE").
+			Where("owner_id = ?", view.OwnerID).
+			Where("pinned").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		
+		// Pin the view
+		_, err = tx.NewUpdate().
+			Model((*persistence.SavedView)(nil)).
+			Set("pinned = TRUE").
+			Set("updated_at = ?", time.Now()).
+			Where("id = ?", view.ID).
+			Exec(ctx)
+		return err
+	})
+}
+
+// Unpin unpins a view
+func (r *SavedViewRepository) Unpin(ctx context.Context, view *entity.SavedView) error {
+	_, err := r.conn(ctx).NewUpdate().
+		Model((*persistence.SavedView)(nil)).
+		Set("pinned = FALSE").
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", view.ID).
+		Exec(ctx)
+	
+	return err
+}
+
+// RemoveCustomField removes a deleted custom field from every view's filter,
+// and makes views sorted by it sort by creation time, newest first
+func (r *SavedViewRepository) RemoveCustomField(ctx context.Context, fieldUUID uuid.UUID) error {
+	// Remove the field's filters
+	_, err := r.conn(ctx).NewUpdate().
+		Model((*persistence.SavedView)(nil)).
+		Set("filter = jsonb_set(filter, '{custom_fields}', "+
+			"COALESCE((SELECT jsonb_agg(f) FROM jsonb_array_elements(filter->'custom_fields') AS f WHERE f->>'field_id' <> ?), '[]'))", fieldUUID.String()).
+		Where("filter->'custom_fields' @> ?::jsonb", `[{"field_id":"`+fieldUUID.String()+`"}]`).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Reset the sort of views sorted by the field
+	_, err = r.conn(ctx).NewUpdate().
+		Model((*persistence.SavedView)(nil)).
+		Set("sort_by = ?", entity.SortByCreatedAt).
+		Set("sort_desc = TRUE").
+		Set("sort_field_id = NULL").
+		Where("sort_field_id = ?", fieldUUID).
+		Exec(ctx)
+
+	return err
+}
+
+// toSavedViewModel converts a domain view to a persistence model
+func toSavedViewModel(view *entity.SavedView) *persistence.SavedView {
+	return &persistence.SavedView{
+		ID:          view.ID,
+		UUID:        view.UUID,
+		OwnerID:     view.OwnerID,
+		Name:        view.Name,
+		Filter:      toTaskFilterModel(view.Filter),
+		SortBy:      view.Sort.Field,
+		SortDesc:    view.Sort.Descending,
+		SortFieldID: view.Sort.CustomFieldID,
+		Columns:     view.Columns,
+		Pinned:      view.Pinned,
+		CreatedAt:   view.CreatedAt,
+		UpdatedAt:   view.UpdatedAt,
+	}
+}
+
+// toSavedViewEntity converts a persistence view to a domain entity
+func toSavedViewEntity(dbView *persistence.SavedView) *entity.SavedView {
+	return &entity.SavedView{
+		ID:        dbView.ID,
+		UUID:      dbView.UUID,
+		OwnerID:   dbView.OwnerID,
+		Name:      dbView.Name,
+		Filter:    toTaskFilterEntity(dbView.Filter),
+		Sort:      entity.TaskSort{Field: dbView.SortBy, Descending: dbView.SortDesc, CustomFieldID: dbView.SortFieldID},
+		Columns:   dbView.Columns,
+		Pinned:    dbView.Pinned,
+		CreatedAt: dbView.CreatedAt,
+		UpdatedAt: dbView.UpdatedAt,
+	}
+}
+
+// toTaskFilterModel converts a domain task filter to its stored form
+func toTaskFilterModel(filter entity.TaskFilter) persistence.TaskFilter {
+	dbFilter := persistence.TaskFilter{
+		Completed:  filter.Completed,
+		CreatedBy:  filter.CreatedBy,
+		AssignedTo: filter.AssignedTo,
+		Search:     filter.Search,
+		DueBefore:  filter.DueBefore,
+		DueAfter:   filter.DueAfter,
+		HasDueDate: filter.HasDueDate,
+	}
+	for _, fieldFilter := range filter.CustomFields {
+		dbFilter.CustomFields = append(dbFilter.CustomFields, persistence.CustomFieldFilter(fieldFilter))
+	}
+
+	return dbFilter
+}
+
+// toTaskFilterEntity converts a stored task filter to a domain filter
+func toTaskFilterEntity(dbFilter persistence.TaskFilter) entity.TaskFilter {
+	filter := entity.TaskFilter{
+		Completed:  dbFilter.Completed,
+		CreatedBy:  dbFilter.CreatedBy,
+		AssignedTo: dbFilter.AssignedTo,
+		Search:     dbFilter.Search,
+		DueBefore:  dbFilter.DueBefore,
+		DueAfter:   dbFilter.DueAfter,
+		HasDueDate: dbFilter.HasDueDate,
+	}
+	for _, dbFieldFilter := range dbFilter.CustomFields {
+		filter.CustomFields = append(filter.CustomFields, entity.CustomFieldFilter(dbFieldFilter))
+	}
+
+	return filter
+}
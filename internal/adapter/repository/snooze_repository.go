@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"task2/internal/domain/entity"
+	"task2/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// SnoozeRepository implements the domain.SnoozeRepository interface
+type SnoozeRepository struct {
+	db *bun.DB
+}
+
+// NewSnoozeRepository creates a new snooze repository
+func NewSnoozeRepository(db *bun.DB) *SnoozeRepository {
+	return &SnoozeRepository{
+		db: db,
+	}
+}
+
+// conn returns the connection to use for the request, joining any active transaction
+func (r *SnoozeRepository) conn(ctx context.Context) bun.IDB {
+	return conn(ctx, r.db)
+}
+
+// Save snoozes a task for a user, replacing any earlier snooze of it by the user
+func (r *SnoozeRepository) Save(ctx context.Context, snooze *entity.Snooze) error {
+	// Convert domain entity to persistence model
+	dbSnooze := &persistence.Snooze{
+		TaskID:    snooze.TaskID,
+		UserID:    snooze.UserID,
+		Until:     snooze.Until,
+		CreatedAt: snooze.CreatedAt,
+	}
+	
+	// Insert or replace snooze
+	_, err := r.conn(ctx).NewInsert().
+		Model(dbSnooze).
+		On("CONFLICT (task_id, user_id) DO UPDATE").
+		Set("snoozed_until = EXCLUDED.snoozed_until").
+		Set("created_at = EXCLUDED.created_at").
+		Returning("id").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	
+	// Update snooze ID
+	snooze.ID = dbSnooze.ID
+	
+	return nil
+}
+
+// Delete deletes a user's snooze of a task, reporting whether there was one
+func (r *SnoozeRepository) Delete(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID) (bool, error) {
+	res, err := r.conn(ctx).NewDelete().
+		Model((*persistence.Snooze)(nil)).
+		Where("task_id = ?", taskUUID).
+		Where("user_id = ?", userUUID).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	
+	return deleted > 0, nil
+}
+
+// GetActiveByUser gets a user's snoozes that have not ended by the given time
+func (r *SnoozeRepository) GetActiveByUser(ctx context.Context, userUUID uuid.UUID, now time.Time) ([]*entity.Snooze, error) {
+	var dbSnoozes []persistence.Snooze
+	
+	err := r.conn(ctx).NewSelect().
+		Model(&dbSnoozes).
+		Where("user_id = ?", userUUID).
+		Where("snoozed_until > ?", now).
+		Scan(ctx)
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	return toSnoozeEntities(dbSnoozes), nil
+}
+
+// ClaimDue locks and returns up to limit ended snoozes on tasks that are not deleted.
+// Snoozes locked by another transaction are skipped, so concurrent callers never claim the same snooze.
+func (r *SnoozeRepository) ClaimDue(ctx context.Context, now time.Time, limit int) ([]*entity.Snooze, error) {
+	var dbSnoozes []persistence.Snooze
+	
+	err := r.conn(ctx).NewSelect().
+		Model(&dbSnoozes).
+		Join("JOIN tasks AS t ON t.uuid = snooze.task_id").
+		Where("t.deleted_at IS NULL").
+		Where("snooze.snoozed_until <= ?", now).
+		Order("snooze.snoozed_until").
+		Limit(limit).
+		For("UPDATE OF snooze SKIP LOCKED").
+		Scan(ctx)
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	return toSnoozeEntities(dbSnoozes), nil
+}
+
+// toSnoozeEntities converts persistence snoozes to domain entities
+func toSnoozeEntities(dbSnoozes []persistence.Snooze) []*entity.Snooze {
+	snoozes := make([]*entity.Snooze, len(dbSnoozes))
+	for i, dbSnooze := range dbSnoozes {
+		snoozes[i] = &entity.Snooze{
+			ID:        dbSnooze.ID,
+			TaskID:    dbSnooze.TaskID,
+			UserID:    dbSnooze.UserID,
+			Until:     dbSnooze.Until,
+			CreatedAt: dbSnooze.CreatedAt,
+		}
+	}
+	return snoozes
+}
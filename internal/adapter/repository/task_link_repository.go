@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"task2/internal/domain/entity"
+	"task2/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// TaskLinkRepository implements the domain.TaskLinkRepository interface
+type TaskLinkRepository struct {
+	db *bun.DB
+}
+
+// NewTaskLinkRepository creates a new task link repository
+func NewTaskLinkRepository(db *bun.DB) *TaskLinkRepository {
+	return &TaskLinkRepository{
+		db: db,
+	}
+}
+
+// conn returns the connection to use for the request, joining any active transaction
+func (r *TaskLinkRepository) conn(ctx context.Context) bun.IDB {
+	return conn(ctx, r.db)
+}
+
+// Create creates a new link
+func (r *TaskLinkRepository) Create(ctx context.Context, link *entity.TaskLink) error {
+	// Convert domain entity to persistence model
+	dbLink := &persistence.TaskLink{
+		UUID:         link.UUID,
+		SourceTaskID: link.SourceTaskID,
+		TargetTaskID: link.TargetTaskID,
+		Type:         link.Type,
+		CreatedByID:  link.CreatedByID,
+		CreatedAt:    link.CreatedAt,
+	}
+	
+	// Insert link
+	if _, err := r.conn(ctx).NewInsert().Model(dbLink).Exec(ctx); err != nil {
+		return err
+	}
+	
+	// Update link ID
+	link.ID = dbLink.ID
+	
+	return nil
+}
+
+// Delete deletes a link by UUID
+func (r *TaskLinkRepository) Delete(ctx context.Context, uuid uuid.UUID) error {
+	_, err := r.conn(ctx).NewDelete().
+		Model((*persistence.TaskLink)(nil)).
+		Where("uuid = ?", uuid).
+		Exec(ctx)
+	
+	return err
+}
@@ -1,8 +1,13 @@
 package repository
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"errors"
+	"slices"
+	"strings"
+	"time"
 	"task2/internal/domain/entity"
 	"task2/internal/infrastructure/persistence"
 
@@ -12,16 +17,28 @@ import (
 
 // TaskRepository implements the domain.TaskRepository interface
 type TaskRepository struct {
-	db *bun.DB
+	db        *bun.DB
+	keyPrefix string
 }
 
 // NewTaskRepository creates a new task repository
 func NewTaskRepository(db *bun.DB) *TaskRepository {
 	return &TaskRepository{
-		db: db,
+		db:        db,
+		keyPrefix: entity.DefaultTaskKeyPrefix,
 	}
 }
 
+// SetKeyPrefix sets the prefix of the keys given to new tasks
+func (r *TaskRepository) SetKeyPrefix(prefix string) {
+	r.keyPrefix = prefix
+}
+
+// conn returns the connection to use for the request, joining any active transaction
+func (r *TaskRepository) conn(ctx context.Context) bun.IDB {
+	return conn(ctx, r.db)
+}
+
 // Create creates a new task
 func (r *TaskRepository) Create(ctx context.Context, task *entity.Task) error {
 	// Convert domain entity to persistence model
@@ -30,23 +47,52 @@ func (r *TaskRepository) Create(ctx context.Context, task *entity.Task) error {
 		Title:       task.Title,
 		Description: task.Description,
 		Completed:   task.Completed,
+		ExternalID:  task.ExternalID,
+		DueDate:     task.DueDate,
+		Version:     task.Version,
 		CreatedByID: task.CreatedByID,
 	}
 
 	// Begin transaction
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := r.conn(ctx).BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
+	// Take the next number under the key prefix; the sequence row stays locked until commit,
+	// so concurrent creates get consecutive numbers
+	var number int64
+	err = tx.NewRaw(`
+		INSERT INTO task_key_sequences (prefix, last_number) VALUES (?, 1)
+		ON CONFLICT (prefix) DO UPDATE SET last_number = task_key_sequences.last_number + 1
+		RETURNING last_number
+	`, r.keyPrefix).Scan(ctx, &number)
+	if err != nil {
+		return err
+	}
+	dbTask.ShortKey = entity.FormatTaskKey(r.keyPrefix, number)
+
 	// Insert task
 	if _, err := tx.NewInsert().Model(dbTask).Exec(ctx); err != nil {
 		return err
 	}
 
-	// Update task ID
+	// Update task ID and key
 	task.ID = dbTask.ID
+	task.Key = dbTask.ShortKey
+
+	// Record mentioned users
+	for _, user := range task.Mentions {
+		taskMention := &persistence.TaskMention{
+			TaskID: dbTask.ID,
+			UserID: user.ID,
+		}
+
+		if _, err := tx.NewInsert().Model(taskMention).Exec(ctx); err != nil {
+			return err
+		}
+	}
 
 	// Add users if provided
 	if task.Users != nil && len(task.Users) > 0 {
@@ -87,9 +133,14 @@ func (r *TaskRepository) GetByUUID(ctx context.Context, uuid uuid.UUID) (*entity
 	dbTask := new(persistence.Task)
 
 	// Get task with relationships
-	err := r.db.NewSelect().
+	err := r.conn(ctx).NewSelect().
 		Model(dbTask).
 		Relation("Users").
+		Relation("Mentions").
+		Relation("Reviewers").
+		Relation("OutgoingLinks.TargetTask").
+		Relation("IncomingLinks.SourceTask").
+		Relation("FieldValues.Field").
 		Relation("CreatedBy").
 		Relation("AssignedTo").
 		Where("task.uuid = ?", uuid).
@@ -100,51 +151,21 @@ func (r *TaskRepository) GetByUUID(ctx context.Context, uuid uuid.UUID) (*entity
 	}
 
 	// Convert to domain entity
-	task := &entity.Task{
-		ID:           dbTask.ID,
-		UUID:         dbTask.UUID,
-		Title:        dbTask.Title,
-		Description:  dbTask.Description,
-		Completed:    dbTask.Completed,
-		CreatedAt:    dbTask.CreatedAt,
-		UpdatedAt:    dbTask.UpdatedAt,
-		DeletedAt:    dbTask.DeletedAt,
-		CreatedByID:  dbTask.CreatedByID,
-		AssignedToID: dbTask.AssignedToID,
-	}
+	return toTaskEntity(dbTask), nil
+}
 
-	// Convert relationships
-	if dbTask.CreatedBy != nil {
-		task.CreatedBy = &entity.User{
-			ID:    dbTask.CreatedBy.ID,
-			UUID:  dbTask.CreatedBy.UUID,
-			Name:  dbTask.CreatedBy.Name,
-			Email: dbTask.CreatedBy.Email,
-		}
-	}
+// GetUUIDByKey gets the UUID of the task with a short key, including deleted tasks
+func (r *TaskRepository) GetUUIDByKey(ctx context.Context, key string) (uuid.UUID, error) {
+	var taskUUID uuid.UUID
 
-	if dbTask.AssignedTo != nil {
-		task.AssignedTo = &entity.User{
-			ID:    dbTask.AssignedTo.ID,
-			UUID:  dbTask.AssignedTo.UUID,
-			Name:  dbTask.AssignedTo.Name,
-			Email: dbTask.AssignedTo.Email,
-		}
-	}
+	err := r.conn(ctx).NewSelect().
+		Model((*persistence.Task)(nil)).
+		Column("uuid").
+		WhereAllWithDeleted().
+		Where("short_key = ?", key).
+		Scan(ctx, &taskUUID)
 
-	if dbTask.Users != nil {
-		task.Users = make([]*entity.User, len(dbTask.Users))
-		for i, user := range dbTask.Users {
-			task.Users[i] = &entity.User{
-				ID:    user.ID,
-				UUID:  user.UUID,
-				Name:  user.Name,
-				Email: user.Email,
-			}
-		}
-	}
-
-	return task, nil
+	return taskUUID, err
 }
 
 // GetAll gets all tasks
@@ -152,9 +173,14 @@ func (r *TaskRepository) GetAll(ctx context.Context) ([]*entity.Task, error) {
 	var dbTasks []persistence.Task
 
 	// Get all tasks with relationships
-	err := r.db.NewSelect().
+	err := r.conn(ctx).NewSelect().
 		Model(&dbTasks).
 		Relation("Users").
+		Relation("Mentions").
+		Relation("Reviewers").
+		Relation("OutgoingLinks.TargetTask").
+		Relation("IncomingLinks.SourceTask").
+		Relation("FieldValues.Field").
 		Relation("CreatedBy").
 		Relation("AssignedTo").
 		Scan(ctx)
@@ -166,49 +192,7 @@ func (r *TaskRepository) GetAll(ctx context.Context) ([]*entity.Task, error) {
 	// Convert to domain entities
 	tasks := make([]*entity.Task, len(dbTasks))
 	for i, dbTask := range dbTasks {
-		tasks[i] = &entity.Task{
-			ID:           dbTask.ID,
-			UUID:         dbTask.UUID,
-			Title:        dbTask.Title,
-			Description:  dbTask.Description,
-			Completed:    dbTask.Completed,
-			CreatedAt:    dbTask.CreatedAt,
-			UpdatedAt:    dbTask.UpdatedAt,
-			DeletedAt:    dbTask.DeletedAt,
-			CreatedByID:  dbTask.CreatedByID,
-			AssignedToID: dbTask.AssignedToID,
-		}
-
-		// Convert relationships
-		if dbTask.CreatedBy != nil {
-			tasks[i].CreatedBy = &entity.User{
-				ID:    dbTask.CreatedBy.ID,
-				UUID:  dbTask.CreatedBy.UUID,
-				Name:  dbTask.CreatedBy.Name,
-				Email: dbTask.CreatedBy.Email,
-			}
-		}
-
-		if dbTask.AssignedTo != nil {
-			tasks[i].AssignedTo = &entity.User{
-				ID:    dbTask.AssignedTo.ID,
-				UUID:  dbTask.AssignedTo.UUID,
-				Name:  dbTask.AssignedTo.Name,
-				Email: dbTask.AssignedTo.Email,
-			}
-		}
-
-		if dbTask.Users != nil {
-			tasks[i].Users = make([]*entity.User, len(dbTask.Users))
-			for j, user := range dbTask.Users {
-				tasks[i].Users[j] = &entity.User{
-					ID:    user.ID,
-					UUID:  user.UUID,
-					Name:  user.Name,
-					Email: user.Email,
-				}
-			}
-		}
+		tasks[i] = toTaskEntity(&dbTask)
 	}
 
 	return tasks, nil
@@ -223,40 +207,286 @@ func (r *TaskRepository) Update(ctx context.Context, task *entity.Task) error {
 		Title:        task.Title,
 		Description:  task.Description,
 		Completed:    task.Completed,
+		ExternalID:   task.ExternalID,
+		DueDate:      task.DueDate,
+		Version:      task.Version,
 		UpdatedAt:    task.UpdatedAt,
+		CreatedByID:  task.CreatedByID,
 		AssignedToID: task.AssignedToID,
+
+		RequiredApprovals: task.RequiredApprovals,
+		AwaitingApproval:  task.AwaitingApproval,
+		ApprovalRound:     task.ApprovalRound,
 	}
 
-	// Update task
-	_, err := r.db.NewUpdate().
-		Model(dbTask).
-		Column("title", "description", "completed", "updated_at", "assigned_to_id").
-		WherePK().
+	// Update task, unless it changed since it was read
+	err := updateTaskColumns(ctx, r.conn(ctx), dbTask, "title", "description", "completed", "external_id", "due_date", "updated_at", "created_by_id", "assigned_to_id",
+		"required_approvals", "awaiting_approval", "approval_round")
+	if err != nil {
+		return err
+	}
+
+	task.Version = dbTask.Version
+	return nil
+}
+
+// Delete soft-deletes a task and increments its version.
+// It fails with entity.ErrVersionConflict if the task changed or was deleted since it was read.
+func (r *TaskRepository) Delete(ctx context.Context, task *entity.Task) error {
+	deletedAt := time.Now()
+
+	res, err := r.conn(ctx).NewUpdate().
+		Model((*persistence.Task)(nil)).
+		Set("deleted_at = ?", deletedAt).
+		Set("version = version + 1").
+		Where("uuid = ?", task.UUID).
+		Where("version = ?", task.Version).
 		Exec(ctx)
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return entity.ErrVersionConflict
+	}
+
+	task.Version++
+	task.DeletedAt = &deletedAt
+	return nil
 }
 
-// Delete deletes a task
-func (r *TaskRepository) Delete(ctx context.Context, uuid uuid.UUID) error {
-	// Get task
+// SetReviewers replaces a task's reviewers and saves its review settings
+func (r *TaskRepository) SetReviewers(ctx context.Context, task *entity.Task) error {
+	// Begin transaction
+	tx, err := r.conn(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Replace reviewers
+	if _, err := tx.NewDelete().Model((*persistence.TaskReviewer)(nil)).Where("task_id = ?", task.ID).Exec(ctx); err != nil {
+		return err
+	}
+
+	for _, user := range task.Reviewers {
+		taskReviewer := &persistence.TaskReviewer{
+			TaskID: task.ID,
+			UserID: user.ID,
+		}
+
+		if _, err := tx.NewInsert().Model(taskReviewer).Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	// Save review settings
+	if err := r.Update(context.WithValue(ctx, txKey{}, tx), task); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit()
+}
+
+// GetTasksAwaitingReviewBy gets tasks awaiting approval that a user reviews
+func (r *TaskRepository) GetTasksAwaitingReviewBy(ctx context.Context, userUUID uuid.UUID) ([]*entity.Task, error) {
+	var dbTasks []persistence.Task
+
+	// Get tasks awaiting review by user
+	err := r.conn(ctx).NewSelect().
+		Model(&dbTasks).
+		Where("task.awaiting_approval = TRUE").
+		Where("task.id IN (SELECT tr.task_id FROM task_reviewers AS tr JOIN users AS u ON u.id = tr.user_id WHERE u.uuid = ?)", userUUID).
+		Relation("Users").
+		Relation("Mentions").
+		Relation("Reviewers").
+		Relation("OutgoingLinks.TargetTask").
+		Relation("IncomingLinks.SourceTask").
+		Relation("FieldValues.Field").
+		Relation("CreatedBy").
+		Relation("AssignedTo").
+		Order("updated_at DESC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to domain entities
+	tasks := make([]*entity.Task, len(dbTasks))
+	for i, dbTask := range dbTasks {
+		tasks[i] = toTaskEntity(&dbTask)
+	}
+
+	return tasks, nil
+}
+
+// GetDeletedTasksCreatedByUser gets soft-deleted tasks created by a user, most recently deleted first
+func (r *TaskRepository) GetDeletedTasksCreatedByUser(ctx context.Context, userUUID uuid.UUID) ([]*entity.Task, error) {
+	var dbTasks []persistence.Task
+
+	// Get deleted tasks created by user
+	err := r.conn(ctx).NewSelect().
+		Model(&dbTasks).
+		WhereDeleted().
+		Where("created_by_id = ?", userUUID).
+		Relation("Users").
+		Relation("Mentions").
+		Relation("Reviewers").
+		Relation("OutgoingLinks.TargetTask").
+		Relation("IncomingLinks.SourceTask").
+		Relation("FieldValues.Field").
+		Relation("CreatedBy").
+		Relation("AssignedTo").
+		Order("deleted_at DESC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to domain entities
+	tasks := make([]*entity.Task, len(dbTasks))
+	for i, dbTask := range dbTasks {
+		tasks[i] = toTaskEntity(&dbTask)
+	}
+
+	return tasks, nil
+}
+
+// GetDeletedByUUID gets a soft-deleted task by UUID
+func (r *TaskRepository) GetDeletedByUUID(ctx context.Context, uuid uuid.UUID) (*entity.Task, error) {
 	dbTask := new(persistence.Task)
-	err := r.db.NewSelect().
+
+	// Get deleted task with relationships
+	err := r.conn(ctx).NewSelect().
 		Model(dbTask).
-		Where("uuid = ?", uuid).
+		WhereDeleted().
+		Relation("Users").
+		Relation("Mentions").
+		Relation("Reviewers").
+		Relation("OutgoingLinks.TargetTask").
+		Relation("IncomingLinks.SourceTask").
+		Relation("FieldValues.Field").
+		Relation("CreatedBy").
+		Relation("AssignedTo").
+		Where("task.uuid = ?", uuid).
 		Scan(ctx)
 
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to domain entity
+	return toTaskEntity(dbTask), nil
+}
+
+// Restore restores a soft-deleted task
+func (r *TaskRepository) Restore(ctx context.Context, task *entity.Task) error {
+	res, err := r.conn(ctx).NewUpdate().
+		Model((*persistence.Task)(nil)).
+		WhereDeleted().
+		Set("deleted_at = NULL").
+		Set("updated_at = ?", task.UpdatedAt).
+		Set("version = version + 1").
+		Where("uuid = ?", task.UUID).
+		Where("version = ?", task.Version).
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return entity.ErrVersionConflict
+	}
+
+	task.Version++
+	task.DeletedAt = nil
+	return nil
+}
+
+// Purge permanently deletes a soft-deleted task and the rows that belong to it
+func (r *TaskRepository) Purge(ctx context.Context, task *entity.Task) error {
+	// Begin transaction
+	tx, err := r.conn(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Delete rows keyed by the task ID
+	for _, model := range []interface{}{(*persistence.UserTask)(nil), (*persistence.TaskMention)(nil), (*persistence.TaskReviewer)(nil), (*persistence.TaskFieldValue)(nil)} {
+		if _, err := tx.NewDelete().Model(model).Where("task_id = ?", task.ID).Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	// Delete rows keyed by the task UUID
+	for _, model := range []interface{}{(*persistence.Reminder)(nil), (*persistence.OwnershipTransfer)(nil), (*persistence.ApprovalDecision)(nil), (*persistence.Snooze)(nil)} {
+		if _, err := tx.NewDelete().Model(model).Where("task_id = ?", task.UUID).Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	// Delete links from and to the task
+	_, err = tx.NewDelete().
+		Model((*persistence.TaskLink)(nil)).
+		Where("source_task_id = ? OR target_task_id = ?", task.UUID, task.UUID).
+		Exec(ctx)
 	if err != nil {
 		return err
 	}
 
 	// Delete task
-	_, err = r.db.NewDelete().
-		Model(dbTask).
-		WherePK().
+	_, err = tx.NewDelete().
+		Model((*persistence.Task)(nil)).
+		WhereDeleted().
+		Where("id = ?", task.ID).
+		ForceDelete().
 		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit()
+}
+
+// GetTasksDeletedBefore gets up to limit tasks that were soft-deleted before the given time
+func (r *TaskRepository) GetTasksDeletedBefore(ctx context.Context, deletedBefore time.Time, limit int) ([]*entity.Task, error) {
+	var dbTasks []persistence.Task
+
+	// Get expired deleted tasks
+	err := r.conn(ctx).NewSelect().
+		Model(&dbTasks).
+		WhereDeleted().
+		Where("deleted_at < ?", deletedBefore).
+		Order("deleted_at ASC").
+		Limit(limit).
+		Scan(ctx)
 
-	return err
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to domain entities
+	tasks := make([]*entity.Task, len(dbTasks))
+	for i, dbTask := range dbTasks {
+		tasks[i] = toTaskEntity(&dbTask)
+	}
+
+	return tasks, nil
 }
 
 // GetTasksCreatedByUser gets tasks created by a user
@@ -264,10 +494,15 @@ func (r *TaskRepository) GetTasksCreatedByUser(ctx context.Context, userUUID uui
 	var dbTasks []persistence.Task
 
 	// Get tasks created by user
-	err := r.db.NewSelect().
+	err := r.conn(ctx).NewSelect().
 		Model(&dbTasks).
 		Where("created_by_id = ?", userUUID).
 		Relation("Users").
+		Relation("Mentions").
+		Relation("Reviewers").
+		Relation("OutgoingLinks.TargetTask").
+		Relation("IncomingLinks.SourceTask").
+		Relation("FieldValues.Field").
 		Relation("CreatedBy").
 		Relation("AssignedTo").
 		Order("created_at DESC").
@@ -280,48 +515,7 @@ func (r *TaskRepository) GetTasksCreatedByUser(ctx context.Context, userUUID uui
 	// Convert to domain entities
 	tasks := make([]*entity.Task, len(dbTasks))
 	for i, dbTask := range dbTasks {
-		tasks[i] = &entity.Task{
-			ID:           dbTask.ID,
-			UUID:         dbTask.UUID,
-			Title:        dbTask.Title,
-			Description:  dbTask.Description,
-			Completed:    dbTask.Completed,
-			CreatedAt:    dbTask.CreatedAt,
-			UpdatedAt:    dbTask.UpdatedAt,
-			DeletedAt:    dbTask.DeletedAt,
-			CreatedByID:  dbTask.CreatedByID,
-			AssignedToID: dbTask.AssignedToID,
-		}
-
-		if dbTask.CreatedBy != nil {
-			tasks[i].CreatedBy = &entity.User{
-				ID:    dbTask.CreatedBy.ID,
-				UUID:  dbTask.CreatedBy.UUID,
-				Name:  dbTask.CreatedBy.Name,
-				Email: dbTask.CreatedBy.Email,
-			}
-		}
-
-		if dbTask.AssignedTo != nil {
-			tasks[i].AssignedTo = &entity.User{
-				ID:    dbTask.AssignedTo.ID,
-				UUID:  dbTask.AssignedTo.UUID,
-				Name:  dbTask.AssignedTo.Name,
-				Email: dbTask.AssignedTo.Email,
-			}
-		}
-
-		if dbTask.Users != nil {
-			tasks[i].Users = make([]*entity.User, len(dbTask.Users))
-			for j, user := range dbTask.Users {
-				tasks[i].Users[j] = &entity.User{
-					ID:    user.ID,
-					UUID:  user.UUID,
-					Name:  user.Name,
-					Email: user.Email,
-				}
-			}
-		}
+		tasks[i] = toTaskEntity(&dbTask)
 	}
 
 	return tasks, nil
@@ -332,10 +526,15 @@ func (r *TaskRepository) GetTasksAssignedToUser(ctx context.Context, userUUID uu
 	var dbTasks []persistence.Task
 
 	// Get tasks assigned to user
-	err := r.db.NewSelect().
+	err := r.conn(ctx).NewSelect().
 		Model(&dbTasks).
 		Where("assigned_to_id = ?", userUUID).
 		Relation("Users").
+		Relation("Mentions").
+		Relation("Reviewers").
+		Relation("OutgoingLinks.TargetTask").
+		Relation("IncomingLinks.SourceTask").
+		Relation("FieldValues.Field").
 		Relation("CreatedBy").
 		Relation("AssignedTo").
 		Order("created_at DESC").
@@ -348,69 +547,18 @@ func (r *TaskRepository) GetTasksAssignedToUser(ctx context.Context, userUUID uu
 	// Convert to domain entities
 	tasks := make([]*entity.Task, len(dbTasks))
 	for i, dbTask := range dbTasks {
-		tasks[i] = &entity.Task{
-			ID:           dbTask.ID,
-			UUID:         dbTask.UUID,
-			Title:        dbTask.Title,
-			Description:  dbTask.Description,
-			Completed:    dbTask.Completed,
-			CreatedAt:    dbTask.CreatedAt,
-			UpdatedAt:    dbTask.UpdatedAt,
-			DeletedAt:    dbTask.DeletedAt,
-			CreatedByID:  dbTask.CreatedByID,
-			AssignedToID: dbTask.AssignedToID,
-		}
-
-		if dbTask.CreatedBy != nil {
-			tasks[i].CreatedBy = &entity.User{
-				ID:    dbTask.CreatedBy.ID,
-				UUID:  dbTask.CreatedBy.UUID,
-				Name:  dbTask.CreatedBy.Name,
-				Email: dbTask.CreatedBy.Email,
-			}
-		}
-
-		if dbTask.AssignedTo != nil {
-			tasks[i].AssignedTo = &entity.User{
-				ID:    dbTask.AssignedTo.ID,
-				UUID:  dbTask.AssignedTo.UUID,
-				Name:  dbTask.AssignedTo.Name,
-				Email: dbTask.AssignedTo.Email,
-			}
-		}
-
-		if dbTask.Users != nil {
-			tasks[i].Users = make([]*entity.User, len(dbTask.Users))
-			for j, user := range dbTask.Users {
-				tasks[i].Users[j] = &entity.User{
-					ID:    user.ID,
-					UUID:  user.UUID,
-					Name:  user.Name,
-					Email: user.Email,
-				}
-			}
-		}
+		tasks[i] = toTaskEntity(&dbTask)
 	}
 
 	return tasks, nil
 }
 
-// AssignTaskToUser assigns a task to a user
-func (r *TaskRepository) AssignTaskToUser(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID) error {
-	// Get task
-	dbTask := new(persistence.Task)
-	err := r.db.NewSelect().
-		Model(dbTask).
-		Where("uuid = ?", taskUUID).
-		Scan(ctx)
-
-	if err != nil {
-		return err
-	}
-
+// AssignTaskToUser assigns a task to a user, adding them as a member if they are not one.
+// It fails with entity.ErrVersionConflict if the task changed since it was read.
+func (r *TaskRepository) AssignTaskToUser(ctx context.Context, task *entity.Task, userUUID uuid.UUID) error {
 	// Get user
 	dbUser := new(persistence.User)
-	err = r.db.NewSelect().
+	err := r.conn(ctx).NewSelect().
 		Model(dbUser).
 		Where("uuid = ?", userUUID).
 		Scan(ctx)
@@ -420,7 +568,7 @@ func (r *TaskRepository) AssignTaskToUser(ctx context.Context, taskUUID uuid.UUI
 	}
 
 	// Begin transaction
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := r.conn(ctx).BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -429,7 +577,7 @@ func (r *TaskRepository) AssignTaskToUser(ctx context.Context, taskUUID uuid.UUI
 	// Check if user is already assigned
 	exists, err := tx.NewSelect().
 		Model((*persistence.UserTask)(nil)).
-		Where("task_id = ? AND user_id = ?", dbTask.ID, dbUser.ID).
+		Where("task_id = ? AND user_id = ?", task.ID, dbUser.ID).
 		Exists(ctx)
 
 	if err != nil {
@@ -439,7 +587,7 @@ func (r *TaskRepository) AssignTaskToUser(ctx context.Context, taskUUID uuid.UUI
 	// Add user to task if not already assigned
 	if !exists {
 		userTask := &persistence.UserTask{
-			TaskID: dbTask.ID,
+			TaskID: task.ID,
 			UserID: dbUser.ID,
 		}
 
@@ -448,21 +596,33 @@ func (r *TaskRepository) AssignTaskToUser(ctx context.Context, taskUUID uuid.UUI
 		}
 	}
 
-	// Update assigned user
-	dbTask.AssignedToID = &userUUID
-	if _, err := tx.NewUpdate().Model(dbTask).Column("assigned_to_id").WherePK().Exec(ctx); err != nil {
+	// Update assigned user against the version the caller checked
+	dbTask := &persistence.Task{
+		ID:           task.ID,
+		AssignedToID: &userUUID,
+		UpdatedAt:    time.Now(),
+		Version:      task.Version,
+	}
+	if err := updateTaskColumns(ctx, tx, dbTask, "assigned_to_id", "updated_at"); err != nil {
 		return err
 	}
 
 	// Commit transaction
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	task.AssignedToID = &userUUID
+	task.UpdatedAt = dbTask.UpdatedAt
+	task.Version = dbTask.Version
+	return nil
 }
 
 // CompleteTask completes a task
 func (r *TaskRepository) CompleteTask(ctx context.Context, taskUUID uuid.UUID) error {
 	// Get task
 	dbTask := new(persistence.Task)
-	err := r.db.NewSelect().
+	err := r.conn(ctx).NewSelect().
 		Model(dbTask).
 		Where("uuid = ?", taskUUID).
 		Scan(ctx)
@@ -478,20 +638,96 @@ func (r *TaskRepository) CompleteTask(ctx context.Context, taskUUID uuid.UUID) e
 
 	// Update task
 	dbTask.Completed = true
-	_, err = r.db.NewUpdate().
-		Model(dbTask).
-		Column("completed").
-		WherePK().
-		Exec(ctx)
+	dbTask.UpdatedAt = time.Now()
+	return updateTaskColumns(ctx, r.conn(ctx), dbTask, "completed", "updated_at")
+}
+
+// AddUserToTask adds a user to a task.
+// It fails with entity.ErrVersionConflict if the task changed since it was read.
+func (r *TaskRepository) AddUserToTask(ctx context.Context, task *entity.Task, userUUID uuid.UUID) error {
+	// Get user
+	dbUser := new(persistence.User)
+	err := r.conn(ctx).NewSelect().
+		Model(dbUser).
+		Where("uuid = ?", userUUID).
+		Scan(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	// Check if user is already assigned
+	exists, err := r.conn(ctx).NewSelect().
+		Model((*persistence.UserTask)(nil)).
+		Where("task_id = ? AND user_id = ?", task.ID, dbUser.ID).
+		Exists(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return errors.New("user is already assigned to this task")
+	}
+
+	// Begin transaction
+	tx, err := r.conn(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Add user to task
+	userTask := &persistence.UserTask{
+		TaskID: task.ID,
+		UserID: dbUser.ID,
+	}
+
+	if _, err := tx.NewInsert().Model(userTask).Exec(ctx); err != nil {
+		return err
+	}
+
+	// Membership is part of the task, so it counts as a write against the version the caller checked
+	dbTask := &persistence.Task{
+		ID:        task.ID,
+		UpdatedAt: time.Now(),
+		Version:   task.Version,
+	}
+	if err := updateTaskColumns(ctx, tx, dbTask, "updated_at"); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return err
+	}
 
-	return err
+	task.UpdatedAt = dbTask.UpdatedAt
+	task.Version = dbTask.Version
+	return nil
 }
 
-// AddUserToTask adds a user to a task
-func (r *TaskRepository) AddUserToTask(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID) error {
+// AddMentions records users as mentioned in a task, ignoring those already recorded
+func (r *TaskRepository) AddMentions(ctx context.Context, task *entity.Task, users []*entity.User) error {
+	for _, user := range users {
+		taskMention := &persistence.TaskMention{
+			TaskID: task.ID,
+			UserID: user.ID,
+		}
+
+		if _, err := r.conn(ctx).NewInsert().Model(taskMention).On("CONFLICT DO NOTHING").Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveUserFromTask removes a user from a task's members
+func (r *TaskRepository) RemoveUserFromTask(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID) error {
 	// Get task
 	dbTask := new(persistence.Task)
-	err := r.db.NewSelect().
+	err := r.conn(ctx).NewSelect().
 		Model(dbTask).
 		Where("uuid = ?", taskUUID).
 		Scan(ctx)
@@ -502,7 +738,7 @@ func (r *TaskRepository) AddUserToTask(ctx context.Context, taskUUID uuid.UUID,
 
 	// Get user
 	dbUser := new(persistence.User)
-	err = r.db.NewSelect().
+	err = r.conn(ctx).NewSelect().
 		Model(dbUser).
 		Where("uuid = ?", userUUID).
 		Scan(ctx)
@@ -511,26 +747,366 @@ func (r *TaskRepository) AddUserToTask(ctx context.Context, taskUUID uuid.UUID,
 		return err
 	}
 
-	// Check if user is already assigned
-	exists, err := r.db.NewSelect().
+	// Begin transaction
+	tx, err := r.conn(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Remove user from task
+	_, err = tx.NewDelete().
 		Model((*persistence.UserTask)(nil)).
 		Where("task_id = ? AND user_id = ?", dbTask.ID, dbUser.ID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Membership is part of the task, so it counts as a write
+	dbTask.UpdatedAt = time.Now()
+	if err := updateTaskColumns(ctx, tx, dbTask, "updated_at"); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit()
+}
+
+// GetTasksVisibleToUserAfter gets up to limit tasks a user created, is assigned to or is a member of,
+// in ID order starting after afterID, so callers can page through every visible task
+func (r *TaskRepository) GetTasksVisibleToUserAfter(ctx context.Context, userUUID uuid.UUID, afterID int64, limit int) ([]*entity.Task, error) {
+	var dbTasks []persistence.Task
+
+	// Get the next page of visible tasks
+	err := r.conn(ctx).NewSelect().
+		Model(&dbTasks).
+		Relation("Users").
+		Relation("CreatedBy").
+		Relation("AssignedTo").
+		WhereGroup(" AND ", r.visibleToUser(ctx, userUUID)).
+		Where("task.id > ?", afterID).
+		OrderExpr("task.id ASC").
+		Limit(limit).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to domain entities
+	tasks := make([]*entity.Task, len(dbTasks))
+	for i, dbTask := range dbTasks {
+		tasks[i] = toTaskEntity(&dbTask)
+	}
+
+	return tasks, nil
+}
+
+// visibleToUser matches tasks a user created, is assigned to or is a member of
+func (r *TaskRepository) visibleToUser(ctx context.Context, userUUID uuid.UUID) func(q *bun.SelectQuery) *bun.SelectQuery {
+	// Tasks the user is a member of
+	memberTaskIDs := r.conn(ctx).NewSelect().
+		Model((*persistence.UserTask)(nil)).
+		Column("ut.task_id").
+		Join("JOIN users AS u ON u.id = ut.user_id").
+		Where("u.uuid = ?", userUUID)
+
+	return func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			Where("task.created_by_id = ?", userUUID).
+			WhereOr("task.assigned_to_id = ?", userUUID).
+			WhereOr("task.id IN (?)", memberTaskIDs)
+	}
+}
+
+// FindTasksVisibleToUser gets tasks visible to a user that match a filter, in the given order
+func (r *TaskRepository) FindTasksVisibleToUser(ctx context.Context, userUUID uuid.UUID, filter entity.TaskFilter, sort entity.TaskSort) ([]*entity.Task, error) {
+	var dbTasks []persistence.Task
+
+	// Get visible tasks
+	query := r.conn(ctx).NewSelect().
+		Model(&dbTasks).
+		Relation("Users").
+		Relation("Mentions").
+		Relation("Reviewers").
+		Relation("OutgoingLinks.TargetTask").
+		Relation("IncomingLinks.SourceTask").
+		Relation("FieldValues.Field").
+		Relation("CreatedBy").
+		Relation("AssignedTo").
+		WhereGroup(" AND ", r.visibleToUser(ctx, userUUID))
+
+	// Apply filter
+	if filter.Completed != nil {
+		query = query.Where("task.completed = ?", *filter.Completed)
+	}
+	if filter.CreatedBy != nil {
+		query = query.Where("task.created_by_id = ?", *filter.CreatedBy)
+	}
+	if filter.AssignedTo != nil {
+		query = query.Where("task.assigned_to_id = ?", *filter.AssignedTo)
+	}
+	if filter.Search != "" {
+		pattern := "%" + escapeLike(filter.Search) + "%"
+		query = query.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("task.title ILIKE ?", pattern).
+				WhereOr("task.description ILIKE ?", pattern)
+		})
+	}
+	if filter.DueBefore != nil {
+		query = query.Where("task.due_date < ?", *filter.DueBefore)
+	}
+	if filter.DueAfter != nil {
+		query = query.Where("task.due_date >= ?", *filter.DueAfter)
+	}
+	if filter.HasDueDate != nil {
+		if *filter.HasDueDate {
+			query = query.Where("task.due_date IS NOT NULL")
+		} else {
+			query = query.Where("task.due_date IS NULL")
+		}
+	}
+
+	for _, fieldFilter := range filter.CustomFields {
+		value, err := json.Marshal(fieldFilter.Value)
+		if err != nil {
+			return nil, err
+		}
+		// Containment is equality for single values and membership for multi_select lists
+		query = query.Where("EXISTS (SELECT 1 FROM task_field_values AS tfv JOIN custom_fields AS cf ON cf.id = tfv.field_id "+
+			"WHERE tfv.task_id = task.id AND cf.uuid = ? AND tfv.value @> ?::jsonb)", fieldFilter.FieldID, string(value))
+	}
+
+	// Apply sort; the sort field is validated by the entity, and tasks without a due date or field value sort last
+	direction := "ASC"
+	if sort.Descending {
+		direction = "DESC"
+	}
+	if sort.Field == entity.SortByCustomField {
+		// Number values sort numerically; other types sort by their text, which for dates is YYYY-MM-DD
+		query = query.
+			Join("LEFT JOIN task_field_values AS sort_value ON sort_value.task_id = task.id "+
+				"AND sort_value.field_id = (SELECT id FROM custom_fields WHERE uuid = ?)", *sort.CustomFieldID).
+			OrderExpr("CASE WHEN jsonb_typeof(sort_value.value) = 'number' THEN (sort_value.value #>> '{}')::numeric END " + direction + " NULLS LAST").
+			OrderExpr("sort_value.value #>> '{}' " + direction + " NULLS LAST")
+	} else {
+		query = query.OrderExpr("task.? "+direction+" NULLS LAST", bun.Ident(sort.Field))
+	}
+	query = query.OrderExpr("task.id " + direction)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// Convert to domain entities
+	tasks := make([]*entity.Task, len(dbTasks))
+	for i, dbTask := range dbTasks {
+		tasks[i] = toTaskEntity(&dbTask)
+	}
+
+	return tasks, nil
+}
+
+// ExternalIDExists checks if a user already created a task with the given external ID
+func (r *TaskRepository) ExternalIDExists(ctx context.Context, creatorUUID uuid.UUID, externalID string) (bool, error) {
+	return r.conn(ctx).NewSelect().
+		Model((*persistence.Task)(nil)).
+		Where("created_by_id = ?", creatorUUID).
+		Where("external_id = ?", externalID).
+		WhereAllWithDeleted().
 		Exists(ctx)
+}
+
+// GetOverdueTasksCreatedByUser gets incomplete tasks created by a user that were due before the given time
+func (r *TaskRepository) GetOverdueTasksCreatedByUser(ctx context.Context, userUUID uuid.UUID, dueBefore time.Time) ([]*entity.Task, error) {
+	var dbTasks []persistence.Task
+
+	// Get overdue tasks
+	err := r.conn(ctx).NewSelect().
+		Model(&dbTasks).
+		Relation("Users").
+		Relation("Mentions").
+		Relation("Reviewers").
+		Relation("OutgoingLinks.TargetTask").
+		Relation("IncomingLinks.SourceTask").
+		Relation("FieldValues.Field").
+		Relation("CreatedBy").
+		Relation("AssignedTo").
+		Where("task.created_by_id = ?", userUUID).
+		Where("task.completed = FALSE").
+		Where("task.due_date < ?", dueBefore).
+		Order("task.due_date ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to domain entities
+	tasks := make([]*entity.Task, len(dbTasks))
+	for i, dbTask := range dbTasks {
+		tasks[i] = toTaskEntity(&dbTask)
+	}
+
+	return tasks, nil
+}
+
+// escapeLike escapes the wildcard characters of a LIKE pattern
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+// updateTaskColumns updates the given columns of a task and increments its version.
+// It fails with entity.ErrVersionConflict if the task's version changed since dbTask was read.
+func updateTaskColumns(ctx context.Context, db bun.IDB, dbTask *persistence.Task, columns ...string) error {
+	res, err := db.NewUpdate().
+		Model(dbTask).
+		Column(append(columns, "version")...).
+		Value("version", "version + 1").
+		WherePK().
+		Where("version = ?", dbTask.Version).
+		Exec(ctx)
 
 	if err != nil {
 		return err
 	}
 
-	if exists {
-		return errors.New("user is already assigned to this task")
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return entity.ErrVersionConflict
 	}
 
-	// Add user to task
-	userTask := &persistence.UserTask{
-		TaskID: dbTask.ID,
-		UserID: dbUser.ID,
+	dbTask.Version++
+	return nil
+}
+
+// toTaskEntity converts a persistence task and its loaded relationships to a domain entity
+func toTaskEntity(dbTask *persistence.Task) *entity.Task {
+	task := &entity.Task{
+		ID:          dbTask.ID,
+		UUID:        dbTask.UUID,
+		Key:         dbTask.ShortKey,
+		Title:       dbTask.Title,
+		Description: dbTask.Description,
+		Completed:   dbTask.Completed,
+		ExternalID:  dbTask.ExternalID,
+		DueDate:     dbTask.DueDate,
+		Version:     dbTask.Version,
+		CreatedAt:   dbTask.CreatedAt,
+
+		RequiredApprovals: dbTask.RequiredApprovals,
+		AwaitingApproval:  dbTask.AwaitingApproval,
+		ApprovalRound:     dbTask.ApprovalRound,
+
+		UpdatedAt:    dbTask.UpdatedAt,
+		DeletedAt:    dbTask.DeletedAt,
+		CreatedByID:  dbTask.CreatedByID,
+		AssignedToID: dbTask.AssignedToID,
+	}
+
+	// Convert relationships
+	if dbTask.CreatedBy != nil {
+		task.CreatedBy = toUserSummaryEntity(dbTask.CreatedBy)
+	}
+
+	if dbTask.AssignedTo != nil {
+		task.AssignedTo = toUserSummaryEntity(dbTask.AssignedTo)
+	}
+
+	if dbTask.Users != nil {
+		task.Users = make([]*entity.User, len(dbTask.Users))
+		for i, user := range dbTask.Users {
+			task.Users[i] = toUserSummaryEntity(user)
+		}
 	}
 
-	_, err = r.db.NewInsert().Model(userTask).Exec(ctx)
-	return err
+	if dbTask.Mentions != nil {
+		task.Mentions = make([]*entity.User, len(dbTask.Mentions))
+		for i, user := range dbTask.Mentions {
+			task.Mentions[i] = toUserSummaryEntity(user)
+		}
+	}
+
+	if dbTask.Reviewers != nil {
+		task.Reviewers = make([]*entity.User, len(dbTask.Reviewers))
+		for i, user := range dbTask.Reviewers {
+			task.Reviewers[i] = toUserSummaryEntity(user)
+		}
+	}
+
+	// Convert links, reading incoming links from this task's side and skipping deleted tasks
+	for _, link := range dbTask.OutgoingLinks {
+		if link.TargetTask != nil {
+			task.Links = append(task.Links, toTaskLinkEntity(link, link.TargetTask))
+		}
+	}
+
+	for _, link := range dbTask.IncomingLinks {
+		if link.SourceTask != nil {
+			task.Links = append(task.Links, toTaskLinkEntity(link, nil).Inverse(toLinkedTaskEntity(link.SourceTask)))
+		}
+	}
+
+	// Convert custom field values, ordered by field name
+	for _, dbValue := range dbTask.FieldValues {
+		if dbValue.Field == nil {
+			continue
+		}
+		value, err := toCustomFieldValueEntity(dbValue)
+		if err != nil {
+			continue
+		}
+		task.CustomFields = append(task.CustomFields, value)
+	}
+	slices.SortFunc(task.CustomFields, func(a, b *entity.CustomFieldValue) int {
+		return cmp.Or(strings.Compare(a.Field.Name, b.Field.Name), cmp.Compare(a.Field.ID, b.Field.ID))
+	})
+
+	return task
+}
+
+// toTaskLinkEntity converts a persistence task link to a domain entity
+func toTaskLinkEntity(dbLink *persistence.TaskLink, dbTarget *persistence.Task) *entity.TaskLink {
+	link := &entity.TaskLink{
+		ID:           dbLink.ID,
+		UUID:         dbLink.UUID,
+		SourceTaskID: dbLink.SourceTaskID,
+		TargetTaskID: dbLink.TargetTaskID,
+		Type:         dbLink.Type,
+		CreatedByID:  dbLink.CreatedByID,
+		CreatedAt:    dbLink.CreatedAt,
+	}
+
+	if dbTarget != nil {
+		link.TargetTask = toLinkedTaskEntity(dbTarget)
+	}
+
+	return link
+}
+
+// toLinkedTaskEntity converts a linked persistence task to a domain entity without its relations
+func toLinkedTaskEntity(dbTask *persistence.Task) *entity.Task {
+	return &entity.Task{
+		ID:          dbTask.ID,
+		UUID:        dbTask.UUID,
+		Key:         dbTask.ShortKey,
+		Title:       dbTask.Title,
+		Completed:   dbTask.Completed,
+		CreatedByID: dbTask.CreatedByID,
+	}
+}
+
+// toUserSummaryEntity converts a related persistence user to a domain entity without credentials
+func toUserSummaryEntity(dbUser *persistence.User) *entity.User {
+	return &entity.User{
+		ID:    dbUser.ID,
+		UUID:  dbUser.UUID,
+		Name:  dbUser.Name,
+		Email: dbUser.Email,
+	}
 }
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+	"task2/internal/domain/entity"
+	"task2/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// TokenRevocationRepository implements the domain.TokenRevocationRepository interface
+type TokenRevocationRepository struct {
+	db *bun.DB
+}
+
+// NewTokenRevocationRepository creates a new token revocation repository
+func NewTokenRevocationRepository(db *bun.DB) *TokenRevocationRepository {
+	return &TokenRevocationRepository{
+		db: db,
+	}
+}
+
+// conn returns the connection to use for the request, joining any active transaction
+func (r *TokenRevocationRepository) conn(ctx context.Context) bun.IDB {
+	return conn(ctx, r.db)
+}
+
+// RevokeToken revokes an access token; revoking it again does nothing
+func (r *TokenRevocationRepository) RevokeToken(ctx context.Context, token *entity.RevokedToken) error {
+	// Convert domain entity to persistence model
+	dbToken := &persistence.RevokedToken{
+		TokenID:   token.TokenID,
+		UserID:    token.UserID,
+		ExpiresAt: token.ExpiresAt,
+		RevokedAt: token.RevokedAt,
+	}
+	
+	// Insert revoked token
+	_, err := r.conn(ctx).NewInsert().
+		Model(dbToken).
+		On("CONFLICT (jti) DO NOTHING").
+		Exec(ctx)
+	
+	return err
+}
+
+// IsTokenRevoked checks if an access token is revoked
+func (r *TokenRevocationRepository) IsTokenRevoked(ctx context.Context, tokenID uuid.UUID) (bool, error) {
+	return r.conn(ctx).NewSelect().
+		Model((*persistence.RevokedToken)(nil)).
+		Where("jti = ?", tokenID).
+		Exists(ctx)
+}
+
+// RevokeUserTokens revokes every access token issued to a user up to the given time
+func (r *TokenRevocationRepository) RevokeUserTokens(ctx context.Context, userUUID uuid.UUID, before time.Time) error {
+	dbRevocation := &persistence.UserTokenRevocation{
+		UserID:        userUUID,
+		RevokedBefore: before,
+	}
+	
+	// Insert or move the user's revocation time forward
+	_, err := r.conn(ctx).NewInsert().
+		Model(dbRevocation).
+		On("CONFLICT (user_id) DO UPDATE").
+		Set("revoked_before = GREATEST(utr.revoked_before, EXCLUDED.revoked_before)").
+		Exec(ctx)
+	
+	return err
+}
+
+// GetUserTokensRevokedBefore gets the time up to which a user's access tokens are revoked, or nil if they never were
+func (r *TokenRevocationRepository) GetUserTokensRevokedBefore(ctx context.Context, userUUID uuid.UUID) (*time.Time, error) {
+	dbRevocation := new(persistence.UserTokenRevocation)
+	
+	err := r.conn(ctx).NewSelect().
+		Model(dbRevocation).
+		Where("user_id = ?", userUUID).
+		Scan(ctx)
+	
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	
+	return &dbRevocation.RevokedBefore, nil
+}
+
+// DeleteExpired deletes revoked tokens that expired by the given time, returning how many were deleted
+func (r *TokenRevocationRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	res, err := r.conn(ctx).NewDelete().
+		Model((*persistence.RevokedToken)(nil)).
+		Where("expires_at <= ?", now).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	
+	return int(deleted), nil
+}
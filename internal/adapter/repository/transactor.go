@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+// txKey is the context key for the active transaction
+type txKey struct{}
+
+// Transactor implements the domain.Transactor interface
+type Transactor struct {
+	db *bun.DB
+}
+
+// NewTransactor creates a new transactor
+func NewTransactor(db *bun.DB) *Transactor {
+	return &Transactor{
+		db: db,
+	}
+}
+
+// WithinTransaction runs fn within a transaction.
+// Repositories called with the context passed to fn use the same transaction;
+// nested calls run in a savepoint of the outer transaction.
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return conn(ctx, t.db).RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn returns the transaction stored in the context, or the database if there is none
+func conn(ctx context.Context, db *bun.DB) bun.IDB {
+	if tx, ok := ctx.Value(txKey{}).(bun.Tx); ok {
+		return tx
+	}
+	return db
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+	"task2/internal/domain/entity"
+	"task2/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// TransferRepository implements the domain.TransferRepository interface
+type TransferRepository struct {
+	db *bun.DB
+}
+
+// NewTransferRepository creates a new ownership transfer repository
+func NewTransferRepository(db *bun.DB) *TransferRepository {
+	return &TransferRepository{
+		db: db,
+	}
+}
+
+// conn returns the connection to use for the request, joining any active transaction
+func (r *TransferRepository) conn(ctx context.Context) bun.IDB {
+	return conn(ctx, r.db)
+}
+
+// Create creates a new transfer
+func (r *TransferRepository) Create(ctx context.Context, transfer *entity.OwnershipTransfer) error {
+	// Convert domain entity to persistence model
+	dbTransfer := &persistence.OwnershipTransfer{
+		UUID:          transfer.UUID,
+		TaskID:        transfer.TaskID,
+		FromUserID:    transfer.FromUserID,
+		ToUserID:      transfer.ToUserID,
+		RequestedByID: transfer.RequestedByID,
+		Status:        transfer.Status,
+		CreatedAt:     transfer.CreatedAt,
+		RespondedAt:   transfer.RespondedAt,
+	}
+	
+	// Insert transfer
+	if _, err := r.conn(ctx).NewInsert().Model(dbTransfer).Exec(ctx); err != nil {
+		return err
+	}
+	
+	// Update transfer ID
+	transfer.ID = dbTransfer.ID
+	
+	return nil
+}
+
+// GetByUUID gets a transfer by UUID
+func (r *TransferRepository) GetByUUID(ctx context.Context, uuid uuid.UUID) (*entity.OwnershipTransfer, error) {
+	dbTransfer := new(persistence.OwnershipTransfer)
+	
+	// Get transfer
+	err := r.conn(ctx).NewSelect().
+		Model(dbTransfer).
+		Where("uuid = ?", uuid).
+		Scan(ctx)
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	// Convert to domain entity
+	return toTransferEntity(dbTransfer), nil
+}
+
+// GetPendingByTask gets the pending transfer of a task, or nil if there is none
+func (r *TransferRepository) GetPendingByTask(ctx context.Context, taskUUID uuid.UUID) (*entity.OwnershipTransfer, error) {
+	dbTransfer := new(persistence.OwnershipTransfer)
+	
+	// Get transfer
+	err := r.conn(ctx).NewSelect().
+		Model(dbTransfer).
+		Where("task_id = ?", taskUUID).
+		Where("status = ?", entity.TransferStatusPending).
+		Scan(ctx)
+	
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	
+	// Convert to domain entity
+	return toTransferEntity(dbTransfer), nil
+}
+
+// GetPendingForUser gets pending transfers a user requested or has to answer, newest first
+func (r *TransferRepository) GetPendingForUser(ctx context.Context, userUUID uuid.UUID) ([]*entity.OwnershipTransfer, error) {
+	var dbTransfers []persistence.OwnershipTransfer
+	
+	// Get transfers
+	err := r.conn(ctx).NewSelect().
+		Model(&dbTransfers).
+		Where("status = ?", entity.TransferStatusPending).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("to_user_id = ?", userUUID).WhereOr("requested_by_id = ?", userUUID)
+		}).
+		Order("created_at DESC").
+		Scan(ctx)
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	// Convert to domain entities
+	transfers := make([]*entity.OwnershipTransfer, len(dbTransfers))
+	for i := range dbTransfers {
+		transfers[i] = toTransferEntity(&dbTransfers[i])
+	}
+	
+	return transfers, nil
+}
+
+// Update saves a transfer's status
+func (r *TransferRepository) Update(ctx context.Context, transfer *entity.OwnershipTransfer) error {
+	_, err := r.conn(ctx).NewUpdate().
+		Model((*persistence.OwnershipTransfer)(nil)).
+		Set("status = ?", transfer.Status).
+		Set("responded_at = ?", transfer.RespondedAt).
+		Where("uuid = ?", transfer.UUID).
+		Exec(ctx)
+	
+	return err
+}
+
+// CancelPendingForTask cancels any pending transfer of a task
+func (r *TransferRepository) CancelPendingForTask(ctx context.Context, taskUUID uuid.UUID) error {
+	_, err := r.conn(ctx).NewUpdate().
+		Model((*persistence.OwnershipTransfer)(nil)).
+		Set("status = ?", entity.TransferStatusCancelled).
+		Set("responded_at = ?", time.Now()).
+		Where("task_id = ?", taskUUID).
+		Where("status = ?", entity.TransferStatusPending).
+		Exec(ctx)
+	
+	return err
+}
+
+// toTransferEntity converts a persistence transfer to a domain entity
+func toTransferEntity(dbTransfer *persistence.OwnershipTransfer) *entity.OwnershipTransfer {
+	return &entity.OwnershipTransfer{
+		ID:            dbTransfer.ID,
+		UUID:          dbTransfer.UUID,
+		TaskID:        dbTransfer.TaskID,
+		FromUserID:    dbTransfer.FromUserID,
+		ToUserID:      dbTransfer.ToUserID,
+		RequestedByID: dbTransfer.RequestedByID,
+		Status:        dbTransfer.Status,
+		CreatedAt:     dbTransfer.CreatedAt,
+		RespondedAt:   dbTransfer.RespondedAt,
+	}
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 	"task2/internal/domain/entity"
 	"task2/internal/infrastructure/persistence"
 
@@ -21,6 +22,11 @@ func NewUserRepository(db *bun.DB) *UserRepository {
 	}
 }
 
+// conn returns the connection to use for the request, joining any active transaction
+func (r *UserRepository) conn(ctx context.Context) bun.IDB {
+	return conn(ctx, r.db)
+}
+
 // Create creates a new user
 func (r *UserRepository) Create(ctx context.Context, user *entity.User) error {
 	// Convert domain entity to persistence model
@@ -29,13 +35,14 @@ func (r *UserRepository) Create(ctx context.Context, user *entity.User) error {
 		Name:     user.Name,
 		Email:    user.Email,
 		Password: user.Password,
+		Role:     string(user.Role),
 		// Tasks field is explicitly excluded with bun:"-" tag
 	}
 	
 	// Insert user - explicitly specify columns to avoid tasks field
-	_, err := r.db.NewInsert().
+	_, err := r.conn(ctx).NewInsert().
 		Model(dbUser).
-		Column("uuid", "name", "email", "password").
+		Column("uuid", "name", "email", "password", "role").
 		Returning("id").
 		Exec(ctx)
 	
@@ -54,7 +61,7 @@ func (r *UserRepository) GetByUUID(ctx context.Context, uuid uuid.UUID) (*entity
 	dbUser := new(persistence.User)
 	
 	// Get user
-	err := r.db.NewSelect().
+	err := r.conn(ctx).NewSelect().
 		Model(dbUser).
 		Where("uuid = ?", uuid).
 		Scan(ctx)
@@ -64,18 +71,7 @@ func (r *UserRepository) GetByUUID(ctx context.Context, uuid uuid.UUID) (*entity
 	}
 	
 	// Convert to domain entity
-	user := &entity.User{
-		ID:        dbUser.ID,
-		UUID:      dbUser.UUID,
-		Name:      dbUser.Name,
-		Email:     dbUser.Email,
-		Password:  dbUser.Password,
-		CreatedAt: dbUser.CreatedAt,
-		UpdatedAt: dbUser.UpdatedAt,
-		DeletedAt: dbUser.DeletedAt,
-	}
-	
-	return user, nil
+	return toUserEntity(dbUser), nil
 }
 
 // GetByEmail gets a user by email
@@ -83,7 +79,7 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*entity.
 	dbUser := new(persistence.User)
 	
 	// Get user
-	err := r.db.NewSelect().
+	err := r.conn(ctx).NewSelect().
 		Model(dbUser).
 		Where("email = ?", email).
 		Scan(ctx)
@@ -93,18 +89,7 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*entity.
 	}
 	
 	// Convert to domain entity
-	user := &entity.User{
-		ID:        dbUser.ID,
-		UUID:      dbUser.UUID,
-		Name:      dbUser.Name,
-		Email:     dbUser.Email,
-		Password:  dbUser.Password,
-		CreatedAt: dbUser.CreatedAt,
-		UpdatedAt: dbUser.UpdatedAt,
-		DeletedAt: dbUser.DeletedAt,
-	}
-	
-	return user, nil
+	return toUserEntity(dbUser), nil
 }
 
 // GetAll gets all users
@@ -112,7 +97,7 @@ func (r *UserRepository) GetAll(ctx context.Context) ([]*entity.User, error) {
 	var dbUsers []persistence.User
 	
 	// Get all users
-	err := r.db.NewSelect().
+	err := r.conn(ctx).NewSelect().
 		Model(&dbUsers).
 		Scan(ctx)
 	
@@ -123,16 +108,7 @@ func (r *UserRepository) GetAll(ctx context.Context) ([]*entity.User, error) {
 	// Convert to domain entities
 	users := make([]*entity.User, len(dbUsers))
 	for i, dbUser := range dbUsers {
-		users[i] = &entity.User{
-			ID:        dbUser.ID,
-			UUID:      dbUser.UUID,
-			Name:      dbUser.Name,
-			Email:     dbUser.Email,
-			Password:  dbUser.Password,
-			CreatedAt: dbUser.CreatedAt,
-			UpdatedAt: dbUser.UpdatedAt,
-			DeletedAt: dbUser.DeletedAt,
-		}
+		users[i] = toUserEntity(&dbUser)
 	}
 	
 	return users, nil
@@ -142,18 +118,22 @@ func (r *UserRepository) GetAll(ctx context.Context) ([]*entity.User, error) {
 func (r *UserRepository) Update(ctx context.Context, user *entity.User) error {
 	// Convert domain entity to persistence model
 	dbUser := &persistence.User{
-		ID:       user.ID,
-		UUID:     user.UUID,
-		Name:     user.Name,
-		Email:    user.Email,
-		Password: user.Password,
-		UpdatedAt: user.UpdatedAt,
+		ID:                 user.ID,
+		UUID:               user.UUID,
+		Name:               user.Name,
+		Email:              user.Email,
+		Password:           user.Password,
+		EmailVerified:      user.EmailVerified,
+		VerificationSentAt: user.VerificationSentAt,
+		Role:               string(user.Role),
+		SuspendedAt:        user.SuspendedAt,
+		UpdatedAt:          user.UpdatedAt,
 	}
 	
 	// Update user
-	_, err := r.db.NewUpdate().
+	_, err := r.conn(ctx).NewUpdate().
 		Model(dbUser).
-		Column("name", "email", "password", "updated_at").
+		Column("name", "email", "password", "email_verified", "verification_sent_at", "role", "suspended_at", "updated_at").
 		WherePK().
 		Exec(ctx)
 	
@@ -164,7 +144,7 @@ func (r *UserRepository) Update(ctx context.Context, user *entity.User) error {
 func (r *UserRepository) Delete(ctx context.Context, uuid uuid.UUID) error {
 	// Get user
 	dbUser := new(persistence.User)
-	err := r.db.NewSelect().
+	err := r.conn(ctx).NewSelect().
 		Model(dbUser).
 		Where("uuid = ?", uuid).
 		Scan(ctx)
@@ -174,7 +154,7 @@ func (r *UserRepository) Delete(ctx context.Context, uuid uuid.UUID) error {
 	}
 	
 	// Delete user
-	_, err = r.db.NewDelete().
+	_, err = r.conn(ctx).NewDelete().
 		Model(dbUser).
 		WherePK().
 		Exec(ctx)
@@ -184,8 +164,147 @@ func (r *UserRepository) Delete(ctx context.Context, uuid uuid.UUID) error {
 
 // EmailExists checks if an email exists
 func (r *UserRepository) EmailExists(ctx context.Context, email string) (bool, error) {
-	return r.db.NewSelect().
+	return r.conn(ctx).NewSelect().
 		Model((*persistence.User)(nil)).
 		Where("email = ?", email).
 		Exists(ctx)
-}
\ No newline at end of file
+}
+
+// GetByCalendarTokenHash gets a user by the hash of their calendar feed token
+func (r *UserRepository) GetByCalendarTokenHash(ctx context.Context, tokenHash string) (*entity.User, error) {
+	dbUser := new(persistence.User)
+	
+	// Get user
+	err := r.conn(ctx).NewSelect().
+		Model(dbUser).
+		Where("calendar_token_hash = ?", tokenHash).
+		Scan(ctx)
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	// Convert to domain entity
+	return toUserEntity(dbUser), nil
+}
+
+// UpdateCalendarTokenHash replaces the hash of a user's calendar feed token
+func (r *UserRepository) UpdateCalendarTokenHash(ctx context.Context, uuid uuid.UUID, tokenHash string) error {
+	_, err := r.conn(ctx).NewUpdate().
+		Model((*persistence.User)(nil)).
+		Set("calendar_token_hash = ?", tokenHash).
+		Set("updated_at = ?", time.Now()).
+		Where("uuid = ?", uuid).
+		Exec(ctx)
+	
+	return err
+}
+
+// MarkEmailVerified marks a user's email as verified if it is still the given address, reporting whether it is
+func (r *UserRepository) MarkEmailVerified(ctx context.Context, uuid uuid.UUID, email string) (bool, error) {
+	res, err := r.conn(ctx).NewUpdate().
+		Model((*persistence.User)(nil)).
+		Set("email_verified = TRUE").
+		Set("updated_at = ?", time.Now()).
+		Where("uuid = ?", uuid).
+		Where("email = ?", email).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	
+	return updated > 0, nil
+}
+
+// ClaimVerificationEmail records that a verification email is being sent to an unverified user,
+// unless one was already sent after the given time. It reports whether the email should be sent.
+func (r *UserRepository) ClaimVerificationEmail(ctx context.Context, uuid uuid.UUID, notSentAfter time.Time) (bool, error) {
+	res, err := r.conn(ctx).NewUpdate().
+		Model((*persistence.User)(nil)).
+		Set("verification_sent_at = ?", time.Now()).
+		Where("uuid = ?", uuid).
+		Where("email_verified = FALSE").
+		Where("verification_sent_at IS NULL OR verification_sent_at <= ?", notSentAfter).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	
+	claimed, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	
+	return claimed > 0, nil
+}
+
+// GetByHandle gets the users whose email starts with the given handle followed by @
+func (r *UserRepository) GetByHandle(ctx context.Context, handle string) ([]*entity.User, error) {
+	var dbUsers []persistence.User
+	
+	// Get users
+	err := r.conn(ctx).NewSelect().
+		Model(&dbUsers).
+		Where("lower(split_part(email, '@', 1)) = lower(?)", handle).
+		Scan(ctx)
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	// Convert to domain entities
+	users := make([]*entity.User, len(dbUsers))
+	for i, dbUser := range dbUsers {
+		users[i] = toUserEntity(&dbUser)
+	}
+	
+	return users, nil
+}
+
+// Count counts every user ever registered, including deleted ones
+func (r *UserRepository) Count(ctx context.Context) (int, error) {
+	return r.conn(ctx).NewSelect().
+		Model((*persistence.User)(nil)).
+		WhereAllWithDeleted().
+		Count(ctx)
+}
+
+// CountActiveByRole counts the users with the given role who are not suspended
+func (r *UserRepository) CountActiveByRole(ctx context.Context, role entity.Role) (int, error) {
+	return r.conn(ctx).NewSelect().
+		Model((*persistence.User)(nil)).
+		Where("role = ?", string(role)).
+		Where("suspended_at IS NULL").
+		Count(ctx)
+}
+
+// LockRoles takes a transaction-level advisory lock that serializes role changes,
+// so checks such as "is this the last admin" cannot race
+func (r *UserRepository) LockRoles(ctx context.Context) error {
+	_, err := r.conn(ctx).ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext('users.role'))")
+	return err
+}
+
+// toUserEntity converts a persistence user to a domain entity
+func toUserEntity(dbUser *persistence.User) *entity.User {
+	return &entity.User{
+		ID:                 dbUser.ID,
+		UUID:               dbUser.UUID,
+		Name:               dbUser.Name,
+		Email:              dbUser.Email,
+		Password:           dbUser.Password,
+		CalendarTokenHash:  dbUser.CalendarTokenHash,
+		EmailVerified:      dbUser.EmailVerified,
+		VerificationSentAt: dbUser.VerificationSentAt,
+		Role:               entity.Role(dbUser.Role),
+		SuspendedAt:        dbUser.SuspendedAt,
+		CreatedAt:          dbUser.CreatedAt,
+		UpdatedAt:          dbUser.UpdatedAt,
+		DeletedAt:          dbUser.DeletedAt,
+	}
+}
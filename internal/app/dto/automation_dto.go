@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AutomationCondition represents a condition a task must meet for a rule to run
+type AutomationCondition struct {
+	Field    string `json:"field" validate:"required,oneof=title description assigned_to completed due_date"`
+	Operator string `json:"operator" validate:"required,oneof=equals not_equals contains is_empty is_not_empty"`
+	Value    string `json:"value,omitempty"`
+}
+
+// AutomationAction represents an action a rule performs
+type AutomationAction struct {
+	Type    string     `json:"type" validate:"required,oneof=notify assign add_member complete"`
+	Target  string     `json:"target,omitempty" validate:"required_if=Type notify"`
+	UserID  *uuid.UUID `json:"user_id,omitempty" validate:"required_if=Type assign,required_if=Type add_member"`
+	Message string     `json:"message,omitempty" validate:"max=500"`
+}
+
+// AutomationRuleRequest represents the request to create or replace an automation rule
+type AutomationRuleRequest struct {
+	Name        string                `json:"name" validate:"required,max=100"`
+	Trigger     string                `json:"trigger" validate:"required,oneof=task.created task.completed task.assigned task.overdue"`
+	OverdueDays int                   `json:"overdue_days" validate:"min=0,max=365"`
+	Conditions  []AutomationCondition `json:"conditions" validate:"max=20,dive"`
+	Actions     []AutomationAction    `json:"actions" validate:"required,min=1,max=10,dive"`
+	Enabled     *bool                 `json:"enabled,omitempty"`
+}
+
+// AutomationRuleResponse represents the response for an automation rule
+type AutomationRuleResponse struct {
+	ID          uuid.UUID             `json:"id"`
+	Name        string                `json:"name"`
+	Trigger     string                `json:"trigger"`
+	OverdueDays int                   `json:"overdue_days"`
+	Conditions  []AutomationCondition `json:"conditions"`
+	Actions     []AutomationAction    `json:"actions"`
+	Enabled     bool                  `json:"enabled"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+}
+
+// AutomationRulesResponse represents the response for multiple automation rules
+type AutomationRulesResponse struct {
+	Rules []AutomationRuleResponse `json:"rules"`
+}
+
+// AutomationExecutionResponse represents one entry in a rule's execution log
+type AutomationExecutionResponse struct {
+	ID        uuid.UUID `json:"id"`
+	TaskID    uuid.UUID `json:"task_id"`
+	Trigger   string    `json:"trigger"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
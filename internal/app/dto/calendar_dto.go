@@ -0,0 +1,7 @@
+package dto
+
+// CalendarTokenResponse represents the response for a regenerated calendar feed token
+type CalendarTokenResponse struct {
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
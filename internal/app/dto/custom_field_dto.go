@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CustomFieldRequest represents the request to create a custom field
+type CustomFieldRequest struct {
+	Name    string   `json:"name" validate:"required,max=100"`
+	Type    string   `json:"type" validate:"required,oneof=text number date select multi_select user"`
+	Options []string `json:"options,omitempty" validate:"max=50,dive,max=100"`
+}
+
+// UpdateCustomFieldRequest represents the request to rename a custom field or change its options.
+// A field's type cannot change, and options already in use cannot be removed.
+type UpdateCustomFieldRequest struct {
+	Name    string   `json:"name" validate:"required,max=100"`
+	Options []string `json:"options,omitempty" validate:"max=50,dive,max=100"`
+}
+
+// CustomFieldResponse represents the response for a custom field
+type CustomFieldResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Options   []string  `json:"options,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CustomFieldsResponse represents the response for multiple custom fields
+type CustomFieldsResponse struct {
+	Fields []CustomFieldResponse `json:"fields"`
+}
+
+// SetCustomFieldValueRequest represents the request to set a custom field on a task.
+// Value is a string for text, date (YYYY-MM-DD), select and user (user ID) fields,
+// a number for number fields and a list of options for multi_select fields.
+type SetCustomFieldValueRequest struct {
+	Value any `json:"value"`
+}
+
+// CustomFieldValueResponse represents the value of a custom field on a task
+type CustomFieldValueResponse struct {
+	FieldID uuid.UUID `json:"field_id"`
+	Name    string    `json:"name"`
+	Type    string    `json:"type"`
+	Value   any       `json:"value"`
+}
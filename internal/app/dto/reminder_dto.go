@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReminderRequest represents the request to create a reminder.
+// Set either RemindAt or MinutesBeforeDue; recipients default to the current user.
+type ReminderRequest struct {
+	RemindAt         *time.Time  `json:"remind_at,omitempty"`
+	MinutesBeforeDue *int        `json:"minutes_before_due,omitempty" validate:"omitempty,min=0,max=525600"`
+	Recipients       []uuid.UUID `json:"recipients,omitempty" validate:"max=50"`
+}
+
+// ReminderResponse represents the response for a reminder
+type ReminderResponse struct {
+	ID               uuid.UUID   `json:"id"`
+	TaskID           uuid.UUID   `json:"task_id"`
+	CreatedBy        uuid.UUID   `json:"created_by"`
+	RemindAt         *time.Time  `json:"remind_at,omitempty"`
+	MinutesBeforeDue *int        `json:"minutes_before_due,omitempty"`
+	FireAt           *time.Time  `json:"fire_at,omitempty"`
+	Recipients       []uuid.UUID `json:"recipients"`
+	Sent             bool        `json:"sent"`
+	SentAt           *time.Time  `json:"sent_at,omitempty"`
+	CreatedAt        time.Time   `json:"created_at"`
+}
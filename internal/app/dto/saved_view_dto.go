@@ -0,0 +1,73 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskFilter represents the tasks a view selects; omitted fields match every task
+type TaskFilter struct {
+	Completed  *bool      `json:"completed,omitempty"`
+	CreatedBy  *uuid.UUID `json:"created_by,omitempty"`
+	AssignedTo *uuid.UUID `json:"assigned_to,omitempty"`
+	Search     string     `json:"search,omitempty" validate:"max=200"`
+	DueBefore  *time.Time `json:"due_before,omitempty"`
+	DueAfter   *time.Time `json:"due_after,omitempty"`
+	HasDueDate *bool      `json:"has_due_date,omitempty"`
+	// CustomFields selects tasks whose custom fields have the values; a multi_select field matches tasks with the option
+	CustomFields []CustomFieldFilter `json:"custom_fields,omitempty" validate:"max=20,dive"`
+}
+
+// CustomFieldFilter represents a custom field value a view's tasks must have
+type CustomFieldFilter struct {
+	FieldID uuid.UUID `json:"field_id" validate:"required"`
+	Value   any       `json:"value"`
+}
+
+// TaskSort represents the order of a view's tasks
+type TaskSort struct {
+	Field         string     `json:"field" validate:"omitempty,oneof=created_at updated_at due_date title custom_field"`
+	Order         string     `json:"order" validate:"omitempty,oneof=asc desc"`
+	CustomFieldID *uuid.UUID `json:"custom_field_id,omitempty"`
+}
+
+// TaskListQuery represents the filter and sort given in a task list's query string.
+// Custom field values are the text of the query parameters.
+type TaskListQuery struct {
+	Filter TaskFilter
+	Sort   TaskSort
+}
+
+// SavedViewRequest represents the request to create or replace a saved view
+type SavedViewRequest struct {
+	Name    string     `json:"name" validate:"required,max=100"`
+	Filter  TaskFilter `json:"filter"`
+	Sort    TaskSort   `json:"sort"`
+	Columns []string   `json:"columns,omitempty" validate:"max=20"`
+}
+
+// SavedViewResponse represents the response for a saved view
+type SavedViewResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	Filter    TaskFilter `json:"filter"`
+	Sort      TaskSort   `json:"sort"`
+	Columns   []string   `json:"columns"`
+	Pinned    bool       `json:"pinned"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// SavedViewsResponse represents the response for multiple saved views
+type SavedViewsResponse struct {
+	Views []SavedViewResponse `json:"views"`
+}
+
+// ViewTasksResponse represents the tasks selected by a view and the columns to show.
+// View is nil when a user without a pinned view asks for their default task list.
+type ViewTasksResponse struct {
+	View    *SavedViewResponse `json:"view"`
+	Columns []string           `json:"columns"`
+	Tasks   []TaskResponse     `json:"tasks"`
+}
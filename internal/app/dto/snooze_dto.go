@@ -0,0 +1,18 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SnoozeRequest represents the request to snooze a task until a given time
+type SnoozeRequest struct {
+	Until time.Time `json:"until" validate:"required"`
+}
+
+// SnoozeResponse represents the response for a snoozed task
+type SnoozeResponse struct {
+	TaskID uuid.UUID `json:"task_id"`
+	Until  time.Time `json:"until"`
+}
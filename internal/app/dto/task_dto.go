@@ -10,9 +10,85 @@ import (
 type CreateTaskRequest struct {
 	Title       string       `json:"title" validate:"required"`
 	Description string       `json:"description"`
+	DueDate     *time.Time   `json:"due_date,omitempty"`
 	Users       []UserAssign `json:"users,omitempty"`
 }
 
+// QuickAddRequest represents a one-line task such as "Send invoice to ACME tomorrow 5pm #finance !high @sara".
+// Timezone is the IANA name relative dates and times are read in, UTC by default.
+type QuickAddRequest struct {
+	Text     string `json:"text" validate:"required,max=1000"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// QuickAddResponse represents how a quick-add line was read, and the task created from it unless it was a dry run
+type QuickAddResponse struct {
+	Interpretation QuickAddInterpretation `json:"interpretation"`
+	Task           *TaskResponse          `json:"task,omitempty"`
+}
+
+// QuickAddInterpretation represents the parts found in a quick-add line and the request built from them
+type QuickAddInterpretation struct {
+	Title    string            `json:"title"`
+	DueDate  *time.Time        `json:"due_date,omitempty"`
+	Timezone string            `json:"timezone"`
+	Labels   []string          `json:"labels"`
+	Priority string            `json:"priority,omitempty"`
+	Mentions []QuickAddMention `json:"mentions"`
+	Request  CreateTaskRequest `json:"request"`
+}
+
+// QuickAddMention represents an @mention in a quick-add line and the user it refers to, if any
+type QuickAddMention struct {
+	Mention string    `json:"mention"`
+	User    *UserInfo `json:"user"`
+}
+
+// DuplicateTaskRequest represents the options for duplicating a task
+type DuplicateTaskRequest struct {
+	IncludeMembers bool `json:"include_members"`
+}
+
+// SetReviewersRequest represents the request to set who must approve a task before it completes.
+// RequiredApprovals defaults to every reviewer; an empty Reviewers list turns review off.
+type SetReviewersRequest struct {
+	Reviewers         []uuid.UUID `json:"reviewers" validate:"max=20"`
+	RequiredApprovals int         `json:"required_approvals" validate:"min=0"`
+}
+
+// ReviewTaskRequest represents a reviewer's comment when approving or rejecting a task
+type ReviewTaskRequest struct {
+	Comment string `json:"comment" validate:"max=2000"`
+}
+
+// LinkTaskRequest represents the request to link a task to another task.
+// CloseDuplicate only applies to duplicate links: it completes the duplicate and moves its members onto the original.
+type LinkTaskRequest struct {
+	TaskID         uuid.UUID `json:"task_id" validate:"required"`
+	Type           string    `json:"type" validate:"required,oneof=relates_to duplicates is_duplicated_by clones is_cloned_by caused_by causes"`
+	CloseDuplicate bool      `json:"close_duplicate"`
+}
+
+// TaskLinkResponse represents a link to another task, as seen from the task it appears on
+type TaskLinkResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Type      string    `json:"type"`
+	TaskID    uuid.UUID `json:"task_id"`
+	TaskKey   string    `json:"task_key"`
+	Title     string    `json:"title"`
+	Completed bool      `json:"completed"`
+}
+
+// ApprovalResponse represents a reviewer's decision on a task
+type ApprovalResponse struct {
+	ID         uuid.UUID `json:"id"`
+	ReviewerID uuid.UUID `json:"reviewer_id"`
+	Round      int       `json:"round"`
+	Decision   string    `json:"decision"`
+	Comment    string    `json:"comment,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
 // UserAssign represents a user to be assigned to a task
 type UserAssign struct {
 	ID string `json:"id" validate:"required"`
@@ -20,16 +96,32 @@ type UserAssign struct {
 
 // TaskResponse represents the response for a task
 type TaskResponse struct {
-	ID          uuid.UUID   `json:"id"`
-	Title       string      `json:"title"`
-	Description string      `json:"description"`
-	Completed   bool        `json:"completed"`
-	CreatedAt   time.Time   `json:"created_at"`
-	UpdatedAt   time.Time   `json:"updated_at"`
-	DeletedAt   *time.Time  `json:"deleted_at,omitempty"`
-	CreatedBy   UserSummary `json:"created_by"`
-	AssignedTo  *UserSummary `json:"assigned_to,omitempty"`
-	Users       []UserSummary `json:"users,omitempty"`
+	ID              uuid.UUID  `json:"id"`
+	Key             string     `json:"key"`
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	DescriptionHTML string     `json:"description_html"`
+	Completed       bool       `json:"completed"`
+	DueDate         *time.Time `json:"due_date,omitempty"`
+	SnoozedUntil    *time.Time `json:"snoozed_until,omitempty"` // set in the current user's assigned tasks
+	
+	// Review settings; see SetReviewersRequest
+	AwaitingApproval  bool          `json:"awaiting_approval"`
+	RequiredApprovals int           `json:"required_approvals,omitempty"`
+	Reviewers         []UserSummary `json:"reviewers,omitempty"`
+	
+	Links []TaskLinkResponse `json:"links,omitempty"`
+	
+	CustomFields []CustomFieldValueResponse `json:"custom_fields,omitempty"`
+	
+	Version    int64         `json:"version"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+	DeletedAt  *time.Time    `json:"deleted_at,omitempty"`
+	CreatedBy  UserSummary   `json:"created_by"`
+	AssignedTo *UserSummary  `json:"assigned_to,omitempty"`
+	Users      []UserSummary `json:"users,omitempty"`
+	Mentions   []UserInfo    `json:"mentions,omitempty"`
 }
 
 // TasksResponse represents the response for multiple tasks
@@ -45,4 +137,86 @@ type AssignTaskRequest struct {
 // CompleteTaskRequest represents the request to complete a task
 type CompleteTaskRequest struct {
 	// Empty as it's just a status change
-}
\ No newline at end of file
+}
+
+// Bulk task operations
+const (
+	BulkOperationComplete = "complete"
+	BulkOperationDelete   = "delete"
+	BulkOperationAssign   = "assign"
+)
+
+// BulkTaskRequest represents the request to apply one operation to many tasks
+type BulkTaskRequest struct {
+	TaskIDs   []uuid.UUID `json:"task_ids" validate:"required,min=1,max=100"`
+	Operation string      `json:"operation" validate:"required,oneof=complete delete assign"`
+	UserID    *uuid.UUID  `json:"user_id,omitempty" validate:"required_if=Operation assign"`
+	Atomic    bool        `json:"atomic"`
+}
+
+// BulkTaskResult represents the outcome of a bulk operation for a single task
+type BulkTaskResult struct {
+	ID      uuid.UUID `json:"id"`
+	Success bool      `json:"success"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// BulkTaskResponse represents the response for a bulk task operation
+type BulkTaskResponse struct {
+	Operation  string           `json:"operation"`
+	Atomic     bool             `json:"atomic"`
+	RolledBack bool             `json:"rolled_back"`
+	Succeeded  int              `json:"succeeded"`
+	Failed     int              `json:"failed"`
+	Results    []BulkTaskResult `json:"results"`
+}
+
+// TaskRecord represents a task in an import or export file
+type TaskRecord struct {
+	ExternalID  string     `json:"external_id,omitempty"`
+	ID          *uuid.UUID `json:"id,omitempty"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	Completed   bool       `json:"completed"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	CreatedBy   string     `json:"created_by,omitempty"`
+	AssignedTo  string     `json:"assigned_to,omitempty"`
+	Members     []string   `json:"members,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// TaskImportRow represents one decoded row of an import file
+type TaskImportRow struct {
+	Line   int
+	Record TaskRecord
+	Errors []string // problems found while decoding the row
+}
+
+// Import row statuses
+const (
+	ImportStatusCreated = "created"
+	ImportStatusValid   = "valid"
+	ImportStatusSkipped = "skipped"
+	ImportStatusFailed  = "failed"
+)
+
+// TaskImportResult represents the outcome of importing a single row
+type TaskImportResult struct {
+	Line       int        `json:"line"`
+	ExternalID string     `json:"external_id,omitempty"`
+	Status     string     `json:"status"`
+	TaskID     *uuid.UUID `json:"task_id,omitempty"`
+	Errors     []string   `json:"errors,omitempty"`
+}
+
+// ImportTasksResponse represents the response for a task import
+type ImportTasksResponse struct {
+	DryRun  bool               `json:"dry_run"`
+	Total   int                `json:"total"`
+	Created int                `json:"created"`
+	Valid   int                `json:"valid"`
+	Skipped int                `json:"skipped"`
+	Failed  int                `json:"failed"`
+	Rows    []TaskImportResult `json:"rows"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransferRequest represents the request to transfer a task to a new owner
+type TransferRequest struct {
+	ToUserID uuid.UUID `json:"to_user_id" validate:"required"`
+	Force    bool      `json:"force"` // admins and managers only: transfer without the new owner's consent
+}
+
+// BulkTransferRequest represents the request to transfer all of a user's tasks
+type BulkTransferRequest struct {
+	ToUserID uuid.UUID `json:"to_user_id" validate:"required"`
+}
+
+// TransferResponse represents the response for an ownership transfer
+type TransferResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	TaskID      uuid.UUID  `json:"task_id"`
+	FromUserID  uuid.UUID  `json:"from_user_id"`
+	ToUserID    uuid.UUID  `json:"to_user_id"`
+	RequestedBy uuid.UUID  `json:"requested_by"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+}
+
+// BulkTransferResponse represents the result of transferring all of a user's tasks
+type BulkTransferResponse struct {
+	Transferred int         `json:"transferred"`
+	TaskIDs     []uuid.UUID `json:"task_ids"`
+}
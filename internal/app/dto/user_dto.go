@@ -19,14 +19,50 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
+// ResendVerificationRequest represents the request to send another email verification link
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ForgotPasswordRequest represents the request to email a password reset link
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ResetPasswordRequest represents the request to set a new password with a password reset token
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=6"`
+}
+
+// UpdateProfileRequest represents the request to update the current user's profile; omitted fields are left unchanged
+type UpdateProfileRequest struct {
+	Name  *string `json:"name" validate:"omitempty,min=1,max=255"`
+	Email *string `json:"email" validate:"omitempty,email"`
+}
+
+// ChangePasswordRequest represents the request to change the current user's password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=6"`
+}
+
+// ChangeRoleRequest represents the request to change a user's role
+type ChangeRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=admin manager member guest"`
+}
+
 // UserResponse represents the response for a user
 type UserResponse struct {
-	ID        uuid.UUID  `json:"id"`
-	Name      string     `json:"name"`
-	Email     string     `json:"email"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	ID            uuid.UUID  `json:"id"`
+	Name          string     `json:"name"`
+	Email         string     `json:"email"`
+	EmailVerified bool       `json:"email_verified"`
+	Role          string     `json:"role"`
+	SuspendedAt   *time.Time `json:"suspended_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
 }
 
 // UserSummary represents a simplified user response
@@ -36,13 +72,33 @@ type UserSummary struct {
 	Email string    `json:"email"`
 }
 
+// UserInfo identifies a user without their email, for responses that name users
+// the caller could otherwise use to look up addresses, such as mentions
+type UserInfo struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+// RefreshTokenRequest represents the request to exchange a refresh token for new tokens
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// TokenResponse represents a short-lived access token and the refresh token that renews it
+type TokenResponse struct {
+	Token            string    `json:"token"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
 // LoginResponse represents the response for a login
 type LoginResponse struct {
-	User  UserResponse `json:"user"`
-	Token string       `json:"token"`
+	User UserResponse `json:"user"`
+	TokenResponse
 }
 
 // UsersResponse represents the response for multiple users
 type UsersResponse struct {
 	Users []UserResponse `json:"users"`
-}
\ No newline at end of file
+}
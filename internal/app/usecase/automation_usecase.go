@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	"task2/internal/adapter/presenter"
+	"task2/internal/app/dto"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/service"
+	"task2/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// automationRunKey marks contexts in which automation actions are running.
+// Events raised by those actions do not trigger further rules, so rules cannot loop.
+type automationRunKey struct{}
+
+// AutomationUseCase handles application logic for automation rules
+type AutomationUseCase struct {
+	automationService   *service.AutomationService
+	taskService         *service.TaskService
+	notificationUseCase *NotificationUseCase
+	automationPresenter *presenter.AutomationPresenter
+}
+
+// NewAutomationUseCase creates a new automation use case
+func NewAutomationUseCase(automationService *service.AutomationService, taskService *service.TaskService, notificationUseCase *NotificationUseCase) *AutomationUseCase {
+	return &AutomationUseCase{
+		automationService:   automationService,
+		taskService:         taskService,
+		notificationUseCase: notificationUseCase,
+		automationPresenter: presenter.NewAutomationPresenter(),
+	}
+}
+
+// CreateRule creates a new rule for the tasks the owner creates
+func (uc *AutomationUseCase) CreateRule(ctx context.Context, req *dto.AutomationRuleRequest, ownerUUID uuid.UUID) (*dto.AutomationRuleResponse, error) {
+	// Create rule entity
+	conditions, actions := uc.automationPresenter.ToEntity(req)
+	rule, err := entity.NewAutomationRule(ownerUUID, req.Name, req.Trigger, req.OverdueDays, conditions, actions)
+	if err != nil {
+		return nil, err
+	}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	
+	// Create rule
+	if err := uc.automationService.CreateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	
+	return uc.automationPresenter.ToDTO(rule), nil
+}
+
+// GetRules gets the current user's rules
+func (uc *AutomationUseCase) GetRules(ctx context.Context, ownerUUID uuid.UUID) (*dto.AutomationRulesResponse, error) {
+	rules, err := uc.automationService.GetRulesForUser(ctx, ownerUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.automationPresenter.ToDTOList(rules), nil
+}
+
+// GetRule gets one of the current user's rules
+func (uc *AutomationUseCase) GetRule(ctx context.Context, ruleUUID uuid.UUID, ownerUUID uuid.UUID) (*dto.AutomationRuleResponse, error) {
+	rule, err := uc.automationService.GetRule(ctx, ruleUUID, ownerUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.automationPresenter.ToDTO(rule), nil
+}
+
+// UpdateRule replaces the definition of one of the current user's rules
+func (uc *AutomationUseCase) UpdateRule(ctx context.Context, ruleUUID uuid.UUID, req *dto.AutomationRuleRequest, ownerUUID uuid.UUID) (*dto.AutomationRuleResponse, error) {
+	// Get the rule
+	rule, err := uc.automationService.GetRule(ctx, ruleUUID, ownerUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Replace its definition
+	rule.Name = req.Name
+	rule.Trigger = req.Trigger
+	rule.OverdueDays = req.OverdueDays
+	rule.Conditions, rule.Actions = uc.automationPresenter.ToEntity(req)
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	
+	if err := uc.automationService.UpdateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	
+	return uc.automationPresenter.ToDTO(rule), nil
+}
+
+// DeleteRule deletes one of the current user's rules
+func (uc *AutomationUseCase) DeleteRule(ctx context.Context, ruleUUID uuid.UUID, ownerUUID uuid.UUID) error {
+	return uc.automationService.DeleteRule(ctx, ruleUUID, ownerUUID)
+}
+
+// GetExecutions gets the execution log of one of the current user's rules
+func (uc *AutomationUseCase) GetExecutions(ctx context.Context, ruleUUID uuid.UUID, ownerUUID uuid.UUID) ([]dto.AutomationExecutionResponse, error) {
+	executions, err := uc.automationService.GetExecutions(ctx, ruleUUID, ownerUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.automationPresenter.ToExecutionDTOList(executions), nil
+}
+
+// HandleTaskEvent runs the rules matching a task event. It is subscribed to the task service.
+func (uc *AutomationUseCase) HandleTaskEvent(ctx context.Context, event entity.TaskEvent) {
+	// Ignore events raised by automation actions
+	if ctx.Value(automationRunKey{}) != nil {
+		return
+	}
+	
+	task, err := uc.taskService.GetTaskByUUID(ctx, event.TaskUUID)
+	if err != nil {
+		log.Printf("Automation: failed to get task %s for %s: %v", event.TaskUUID, event.Type, err)
+		return
+	}
+	
+	rules, err := uc.automationService.GetMatchingRules(ctx, event.Type, task)
+	if err != nil {
+		log.Printf("Automation: failed to get rules for %s: %v", event.Type, err)
+		return
+	}
+	
+	for _, rule := range rules {
+		uc.runRule(ctx, rule, task, "")
+	}
+}
+
+// RunOverdueRules runs task.overdue rules against tasks that have been overdue for at least the rule's overdue_days.
+// Each rule runs once per task and due date, even with several API instances running the scheduler.
+func (uc *AutomationUseCase) RunOverdueRules(ctx context.Context) error {
+	rules, err := uc.automationService.GetEnabledRulesForTrigger(ctx, entity.TaskEventOverdue)
+	if err != nil {
+		return err
+	}
+	
+	now := time.Now()
+	for _, rule := range rules {
+		dueBefore := now.AddDate(0, 0, -rule.OverdueDays)
+		tasks, err := uc.taskService.GetOverdueTasksCreatedByUser(ctx, rule.OwnerID, dueBefore)
+		if err != nil {
+			return err
+		}
+		
+		for _, task := range tasks {
+			if !rule.Matches(task) {
+				continue
+			}
+			
+			dedupeKey := fmt.Sprintf("%s:%s:%d", rule.UUID, task.UUID, task.DueDate.Unix())
+			uc.runRule(ctx, rule, task, dedupeKey)
+		}
+	}
+	
+	return nil
+}
+
+// runRule runs a rule's actions against a task in one transaction and records the outcome in the execution log
+func (uc *AutomationUseCase) runRule(ctx context.Context, rule *entity.AutomationRule, task *entity.Task, dedupeKey string) {
+	execution, started, err := uc.automationService.StartExecution(ctx, rule, task.UUID, dedupeKey)
+	if err != nil {
+		log.Printf("Automation: failed to record execution of rule %s: %v", rule.UUID, err)
+		return
+	}
+	if !started {
+		return
+	}
+	
+	// Actions act as the rule owner, are not bound by the triggering request's If-Match
+	// and do not trigger further rules
+	actionCtx := context.WithValue(utils.WithExpectedVersions(ctx, nil), automationRunKey{}, true)
+	runErr := uc.taskService.WithinTransaction(actionCtx, func(ctx context.Context) error {
+		for _, action := range rule.Actions {
+			if err := uc.runAction(ctx, rule, task, action); err != nil {
+				return fmt.Errorf("%s action failed: %w", action.Type, err)
+			}
+		}
+		return nil
+	})
+	
+	if err := uc.automationService.FinishExecution(ctx, execution, runErr); err != nil {
+		log.Printf("Automation: failed to record outcome of rule %s: %v", rule.UUID, err)
+	}
+}
+
+// runAction performs a single rule action against a task
+func (uc *AutomationUseCase) runAction(ctx context.Context, rule *entity.AutomationRule, task *entity.Task, action entity.RuleAction) error {
+	switch action.Type {
+	case entity.ActionNotify:
+		uc.notify(ctx, rule, task, action)
+		return nil
+	case entity.ActionAssign:
+		return uc.taskService.AssignTask(ctx, task.UUID, *action.UserID, rule.OwnerID)
+	case entity.ActionAddMember:
+		for _, user := range task.Users {
+			if user.UUID == *action.UserID {
+				return nil
+			}
+		}
+		return uc.taskService.AddUserToTask(ctx, task.UUID, *action.UserID, rule.OwnerID)
+	case entity.ActionComplete:
+		if task.Completed {
+			return nil
+		}
+		return uc.taskService.CompleteTask(ctx, task.UUID, rule.OwnerID)
+	}
+	
+	return errors.New("unknown action type: " + action.Type)
+}
+
+// notify sends a rule notification to the action's target users
+func (uc *AutomationUseCase) notify(ctx context.Context, rule *entity.AutomationRule, task *entity.Task, action entity.RuleAction) {
+	var recipients []uuid.UUID
+	switch action.Target {
+	case entity.NotifyCreator:
+		recipients = append(recipients, task.CreatedByID)
+	case entity.NotifyAssignee:
+		if task.AssignedToID != nil {
+			recipients = append(recipients, *task.AssignedToID)
+		}
+	case entity.NotifyMembers:
+		for _, user := range task.Users {
+			recipients = append(recipients, user.UUID)
+		}
+	}
+	
+	message := action.Message
+	if message == "" {
+		message = fmt.Sprintf("The rule %q ran on the task %q (%s).", rule.Name, task.Title, rule.Trigger)
+	}
+	
+	for _, userUUID := range recipients {
+		uc.notificationUseCase.Notify(ctx, entity.NewNotification(
+			userUUID,
+			entity.NotificationTypeAutomation,
+			rule.Name,
+			message,
+			&task.UUID,
+		))
+	}
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"task2/internal/adapter/presenter"
+	"task2/internal/app/dto"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/service"
+	"task2/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// calendarTokenBytes is the number of random bytes in a calendar feed token
+const calendarTokenBytes = 32
+
+// CalendarUseCase handles application logic for calendar feeds
+type CalendarUseCase struct {
+	taskService       *service.TaskService
+	userService       *service.UserService
+	calendarPresenter *presenter.CalendarPresenter
+	
+	// Scheme and host of the API that feed URLs point at
+	appBaseURL string
+}
+
+// NewCalendarUseCase creates a new calendar use case
+func NewCalendarUseCase(taskService *service.TaskService, userService *service.UserService) *CalendarUseCase {
+	return &CalendarUseCase{
+		taskService:       taskService,
+		userService:       userService,
+		calendarPresenter: presenter.NewCalendarPresenter(),
+	}
+}
+
+// SetAppBaseURL sets the scheme and host of the API that feed URLs point at.
+// URLs are never built from request headers, which a client controls; without a base URL no feed token is issued.
+func (uc *CalendarUseCase) SetAppBaseURL(appBaseURL string) {
+	uc.appBaseURL = appBaseURL
+}
+
+// GetFeed renders the tasks with due dates assigned to the owner of a feed token
+func (uc *CalendarUseCase) GetFeed(ctx context.Context, token string, component string) (string, error) {
+	// Get the feed owner; feeds of suspended users stop working
+	user, err := uc.userService.GetUserByCalendarTokenHash(ctx, utils.HashToken(token))
+	if err != nil || user.IsSuspended() {
+		return "", errors.New("calendar feed not found")
+	}
+	
+	// Get tasks assigned to the owner
+	tasks, err := uc.taskService.GetTasksAssignedToUser(ctx, user.UUID)
+	if err != nil {
+		return "", err
+	}
+	
+	// Keep tasks with due dates
+	dueTasks := make([]*entity.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task.DueDate != nil {
+			dueTasks = append(dueTasks, task)
+		}
+	}
+	
+	return uc.calendarPresenter.ToICS("Tasks - "+user.Name, dueTasks, component), nil
+}
+
+// RegenerateToken issues a new secret feed token for a user, invalidating the previous one.
+// Only a hash of the token is stored, so the token is returned once.
+func (uc *CalendarUseCase) RegenerateToken(ctx context.Context, userUUID uuid.UUID) (*dto.CalendarTokenResponse, error) {
+	if uc.appBaseURL == "" {
+		return nil, errors.New("calendar feeds are not configured")
+	}
+	
+	// Generate token
+	token, err := utils.GenerateRandomToken(calendarTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Store its hash
+	if err := uc.userService.SetCalendarTokenHash(ctx, userUUID, utils.HashToken(token)); err != nil {
+		return nil, err
+	}
+	
+	return &dto.CalendarTokenResponse{
+		Token: token,
+		URL:   uc.appBaseURL + "/api/v1/calendar/" + token + ".ics",
+	}, nil
+}
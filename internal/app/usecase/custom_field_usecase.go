@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"task2/internal/adapter/presenter"
+	"task2/internal/app/dto"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/service"
+	"task2/pkg/markdown"
+
+	"github.com/google/uuid"
+)
+
+// CustomFieldUseCase handles application logic for custom fields
+type CustomFieldUseCase struct {
+	customFieldService   *service.CustomFieldService
+	customFieldPresenter *presenter.CustomFieldPresenter
+	taskPresenter        *presenter.TaskPresenter
+}
+
+// NewCustomFieldUseCase creates a new custom field use case
+func NewCustomFieldUseCase(customFieldService *service.CustomFieldService) *CustomFieldUseCase {
+	return &CustomFieldUseCase{
+		customFieldService:   customFieldService,
+		customFieldPresenter: presenter.NewCustomFieldPresenter(),
+		taskPresenter:        presenter.NewTaskPresenter(),
+	}
+}
+
+// SetMarkdownRenderer sets the renderer used for task descriptions
+func (uc *CustomFieldUseCase) SetMarkdownRenderer(renderer *markdown.Renderer) {
+	uc.taskPresenter.SetMarkdownRenderer(renderer)
+}
+
+// CreateField creates a new field for the tasks the owner creates
+func (uc *CustomFieldUseCase) CreateField(ctx context.Context, req *dto.CustomFieldRequest, ownerUUID uuid.UUID) (*dto.CustomFieldResponse, error) {
+	field, err := entity.NewCustomField(ownerUUID, req.Name, req.Type, req.Options)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := uc.customFieldService.CreateField(ctx, field); err != nil {
+		return nil, err
+	}
+	
+	return uc.customFieldPresenter.ToDTO(field), nil
+}
+
+// GetFields gets the current user's fields
+func (uc *CustomFieldUseCase) GetFields(ctx context.Context, ownerUUID uuid.UUID) (*dto.CustomFieldsResponse, error) {
+	fields, err := uc.customFieldService.GetFieldsForUser(ctx, ownerUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.customFieldPresenter.ToDTOList(fields), nil
+}
+
+// GetField gets one of the current user's fields
+func (uc *CustomFieldUseCase) GetField(ctx context.Context, fieldUUID uuid.UUID, ownerUUID uuid.UUID) (*dto.CustomFieldResponse, error) {
+	field, err := uc.customFieldService.GetField(ctx, fieldUUID, ownerUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.customFieldPresenter.ToDTO(field), nil
+}
+
+// UpdateField renames one of the current user's fields and replaces its options
+func (uc *CustomFieldUseCase) UpdateField(ctx context.Context, fieldUUID uuid.UUID, req *dto.UpdateCustomFieldRequest, ownerUUID uuid.UUID) (*dto.CustomFieldResponse, error) {
+	field, err := uc.customFieldService.UpdateField(ctx, fieldUUID, ownerUUID, req.Name, req.Options)
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.customFieldPresenter.ToDTO(field), nil
+}
+
+// DeleteField deletes one of the current user's fields and its values
+func (uc *CustomFieldUseCase) DeleteField(ctx context.Context, fieldUUID uuid.UUID, ownerUUID uuid.UUID) error {
+	return uc.customFieldService.DeleteField(ctx, fieldUUID, ownerUUID)
+}
+
+// SetValue sets the value of a field on a task; a null value clears it
+func (uc *CustomFieldUseCase) SetValue(ctx context.Context, taskUUID uuid.UUID, fieldUUID uuid.UUID, value any, userUUID uuid.UUID) (*dto.TaskResponse, error) {
+	task, err := uc.customFieldService.SetValue(ctx, taskUUID, fieldUUID, value, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.taskPresenter.ToDTO(task), nil
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"html"
+	"log"
+	"time"
+	"task2/internal/adapter/presenter"
+	"task2/internal/app/dto"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/service"
+	"task2/pkg/email"
+
+	"github.com/google/uuid"
+)
+
+// emailBatchSize is the number of outbox emails claimed per transaction
+const emailBatchSize = 20
+
+// NotificationUseCase handles application logic for notifications
+type NotificationUseCase struct {
+	notificationService   *service.NotificationService
+	userService           *service.UserService
+	emailOutboxService    *service.EmailOutboxService
+	emailService          *email.EmailService
+	notificationPresenter *presenter.NotificationPresenter
+}
+
+// NewNotificationUseCase creates a new notification use case
+func NewNotificationUseCase(notificationService *service.NotificationService, userService *service.UserService, emailOutboxService *service.EmailOutboxService) *NotificationUseCase {
+	return &NotificationUseCase{
+		notificationService:   notificationService,
+		userService:           userService,
+		emailOutboxService:    emailOutboxService,
+		notificationPresenter: presenter.NewNotificationPresenter(),
+	}
+}
+
+// SetEmailService sets the email service used to deliver notifications by email
+func (uc *NotificationUseCase) SetEmailService(emailService *email.EmailService) {
+	uc.emailService = emailService
+}
+
+// Notify stores an in-app notification and queues it for email if the email service is available.
+// Failures are logged rather than returned so they never fail the triggering request.
+func (uc *NotificationUseCase) Notify(ctx context.Context, notification *entity.Notification) {
+	if err := uc.Store(ctx, notification); err != nil {
+		log.Printf("Failed to create notification for user %s: %v", notification.UserID, err)
+		return
+	}
+	
+	if err := uc.QueueEmail(ctx, notification); err != nil {
+		log.Printf("Failed to queue notification email for user %s: %v", notification.UserID, err)
+	}
+}
+
+// Store stores an in-app notification without emailing it
+func (uc *NotificationUseCase) Store(ctx context.Context, notification *entity.Notification) error {
+	return uc.notificationService.CreateNotification(ctx, notification)
+}
+
+// QueueEmail adds a notification email to the outbox if the email service is available.
+// Called within a transaction, the email is only sent if the transaction commits.
+// A recipient who cannot be found, such as a deleted user, is skipped.
+func (uc *NotificationUseCase) QueueEmail(ctx context.Context, notification *entity.Notification) error {
+	if uc.emailService == nil {
+		return nil
+	}
+	
+	user, err := uc.userService.GetUserByUUID(ctx, notification.UserID)
+	if err != nil {
+		log.Printf("Failed to get user %s for notification email: %v", notification.UserID, err)
+		return nil
+	}
+	
+	body := "<p>" + html.EscapeString(notification.Message) + "</p>"
+	return uc.emailOutboxService.Enqueue(ctx, entity.NewOutboxEmail(user.Email, notification.Title, body))
+}
+
+// SendQueuedEmails sends the outbox emails that are due. Failed sends stay in the outbox
+// and are retried by a later run.
+func (uc *NotificationUseCase) SendQueuedEmails(ctx context.Context) error {
+	if uc.emailService == nil {
+		return nil
+	}
+	
+	for {
+		failed := 0
+		claimed, err := uc.emailOutboxService.SendDue(ctx, time.Now(), emailBatchSize, func(email *entity.OutboxEmail) error {
+			if err := uc.emailService.SendEmail(email.To, email.Subject, email.Body); err != nil {
+				log.Printf("Failed to send email to %s (attempt %d): %v", email.To, email.Attempts+1, err)
+				failed++
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		
+		if claimed > 0 {
+			log.Printf("Sent %d queued emails, %d failed", claimed-failed, failed)
+		}
+		
+		if claimed < emailBatchSize {
+			return nil
+		}
+	}
+}
+
+// GetNotifications gets the current user's notifications
+func (uc *NotificationUseCase) GetNotifications(ctx context.Context, userUUID uuid.UUID, unreadOnly bool) (*dto.NotificationsResponse, error) {
+	// Get notifications
+	notifications, err := uc.notificationService.GetNotificationsForUser(ctx, userUUID, unreadOnly)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Convert to DTOs
+	return uc.notificationPresenter.ToDTOList(notifications), nil
+}
+
+// MarkRead marks one of the current user's notifications as read
+func (uc *NotificationUseCase) MarkRead(ctx context.Context, notificationUUID uuid.UUID, userUUID uuid.UUID) (*dto.NotificationResponse, error) {
+	notification, err := uc.notificationService.MarkRead(ctx, notificationUUID, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.notificationPresenter.ToDTO(notification), nil
+}
+
+// MarkAllRead marks all of the current user's notifications as read
+func (uc *NotificationUseCase) MarkAllRead(ctx context.Context, userUUID uuid.UUID) error {
+	return uc.notificationService.MarkAllRead(ctx, userUUID)
+}
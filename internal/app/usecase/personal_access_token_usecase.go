@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	"task2/internal/adapter/presenter"
+	"task2/internal/app/dto"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/service"
+	"task2/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// personalAccessTokenBytes is the number of random bytes in a personal access token
+const personalAccessTokenBytes = 32
+
+// personalAccessTokenHintLength is how much of a token, including its prefix, is kept to tell tokens apart
+const personalAccessTokenHintLength = 12
+
+// defaultPersonalAccessTokenTTL is how long a personal access token is valid for when no expiry is given
+const defaultPersonalAccessTokenTTL = 30 * 24 * time.Hour
+
+// PersonalAccessTokenUseCase handles application logic for personal access tokens
+type PersonalAccessTokenUseCase struct {
+	tokenService   *service.PersonalAccessTokenService
+	tokenPresenter *presenter.PersonalAccessTokenPresenter
+}
+
+// NewPersonalAccessTokenUseCase creates a new personal access token use case
+func NewPersonalAccessTokenUseCase(tokenService *service.PersonalAccessTokenService) *PersonalAccessTokenUseCase {
+	return &PersonalAccessTokenUseCase{
+		tokenService:   tokenService,
+		tokenPresenter: presenter.NewPersonalAccessTokenPresenter(),
+	}
+}
+
+// CreateToken creates a personal access token for the user.
+// Only a hash of the token is stored, so the token is returned once.
+func (uc *PersonalAccessTokenUseCase) CreateToken(ctx context.Context, userUUID uuid.UUID, req *dto.CreatePersonalAccessTokenRequest) (*dto.PersonalAccessTokenResponse, error) {
+	secret, err := generatePersonalAccessToken()
+	if err != nil {
+		return nil, err
+	}
+	
+	scopes := make([]entity.Permission, len(req.Scopes))
+	for i, scope := range req.Scopes {
+		scopes[i] = entity.Permission(scope)
+	}
+	
+	ttl := defaultPersonalAccessTokenTTL
+	if req.ExpiresInDays > 0 {
+		ttl = time.Duration(req.ExpiresInDays) * 24 * time.Hour
+	}
+	
+	token, err := entity.NewPersonalAccessToken(userUUID, req.Name, scopes, utils.HashToken(secret), secret[:personalAccessTokenHintLength], ttl)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := uc.tokenService.CreateToken(ctx, token); err != nil {
+		return nil, err
+	}
+	
+	resp := uc.tokenPresenter.ToDTO(token)
+	resp.Token = secret
+	return resp, nil
+}
+
+// GetTokens gets the user's personal access tokens that are not revoked
+func (uc *PersonalAccessTokenUseCase) GetTokens(ctx context.Context, userUUID uuid.UUID) (*dto.PersonalAccessTokensResponse, error) {
+	tokens, err := uc.tokenService.GetTokens(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.tokenPresenter.ToDTOList(tokens), nil
+}
+
+// RevokeToken revokes one of the user's personal access tokens
+func (uc *PersonalAccessTokenUseCase) RevokeToken(ctx context.Context, tokenUUID uuid.UUID, userUUID uuid.UUID) error {
+	return uc.tokenService.RevokeToken(ctx, tokenUUID, userUUID)
+}
+
+// RotateToken gives one of the user's personal access tokens a new secret, which is returned once
+func (uc *PersonalAccessTokenUseCase) RotateToken(ctx context.Context, tokenUUID uuid.UUID, userUUID uuid.UUID) (*dto.PersonalAccessTokenResponse, error) {
+	secret, err := generatePersonalAccessToken()
+	if err != nil {
+		return nil, err
+	}
+	
+	token, err := uc.tokenService.RotateToken(ctx, tokenUUID, userUUID, utils.HashToken(secret), secret[:personalAccessTokenHintLength])
+	if err != nil {
+		return nil, err
+	}
+	
+	resp := uc.tokenPresenter.ToDTO(token)
+	resp.Token = secret
+	return resp, nil
+}
+
+// Authenticate gets the user and scopes of a personal access token, recording its use from the IP
+func (uc *PersonalAccessTokenUseCase) Authenticate(ctx context.Context, secret string, ip string) (uuid.UUID, []string, error) {
+	token, err := uc.tokenService.Authenticate(ctx, utils.HashToken(secret), ip)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+	
+	scopes := make([]string, len(token.Scopes))
+	for i, scope := range token.Scopes {
+		scopes[i] = string(scope)
+	}
+	
+	return token.UserID, scopes, nil
+}
+
+// generatePersonalAccessToken generates a new secret personal access token
+func generatePersonalAccessToken() (string, error) {
+	secret, err := utils.GenerateRandomToken(personalAccessTokenBytes)
+	if err != nil {
+		return "", err
+	}
+	
+	return entity.PersonalAccessTokenPrefix + secret, nil
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+	"task2/internal/adapter/presenter"
+	"task2/internal/app/dto"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+// reminderBatchSize is the number of reminders claimed per transaction
+const reminderBatchSize = 100
+
+// ReminderUseCase handles application logic for task reminders
+type ReminderUseCase struct {
+	reminderService     *service.ReminderService
+	notificationUseCase *NotificationUseCase
+	reminderPresenter   *presenter.ReminderPresenter
+}
+
+// NewReminderUseCase creates a new reminder use case
+func NewReminderUseCase(reminderService *service.ReminderService, notificationUseCase *NotificationUseCase) *ReminderUseCase {
+	return &ReminderUseCase{
+		reminderService:     reminderService,
+		notificationUseCase: notificationUseCase,
+		reminderPresenter:   presenter.NewReminderPresenter(),
+	}
+}
+
+// CreateReminder creates a reminder on a task
+func (uc *ReminderUseCase) CreateReminder(ctx context.Context, taskUUID uuid.UUID, req *dto.ReminderRequest, userUUID uuid.UUID) (*dto.ReminderResponse, error) {
+	reminder, task, err := uc.reminderService.CreateReminder(ctx, taskUUID, userUUID, req.RemindAt, req.MinutesBeforeDue, req.Recipients)
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.reminderPresenter.ToDTO(reminder, task), nil
+}
+
+// GetReminders gets the reminders on a task
+func (uc *ReminderUseCase) GetReminders(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID) ([]dto.ReminderResponse, error) {
+	reminders, task, err := uc.reminderService.GetReminders(ctx, taskUUID, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.reminderPresenter.ToDTOList(reminders, task), nil
+}
+
+// DeleteReminder deletes a reminder from a task
+func (uc *ReminderUseCase) DeleteReminder(ctx context.Context, taskUUID uuid.UUID, reminderUUID uuid.UUID, userUUID uuid.UUID) error {
+	return uc.reminderService.DeleteReminder(ctx, taskUUID, reminderUUID, userUUID)
+}
+
+// DeliverDueReminders fires every due reminder. In-app notifications and their emails are queued
+// in the same transaction that marks the reminder sent, so running this on several instances never
+// delivers a reminder twice, and an email that fails to send is retried from the outbox.
+func (uc *ReminderUseCase) DeliverDueReminders(ctx context.Context) error {
+	for {
+		delivered, err := uc.reminderService.DeliverDueReminders(ctx, time.Now(), reminderBatchSize,
+			func(ctx context.Context, reminder *entity.Reminder, task *entity.Task) error {
+				for _, recipient := range reminder.Recipients {
+					notification := entity.NewNotification(recipient, entity.NotificationTypeReminder,
+						"Reminder: "+task.Title, reminderMessage(task), &task.UUID)
+					if err := uc.notificationUseCase.Store(ctx, notification); err != nil {
+						return err
+					}
+					if err := uc.notificationUseCase.QueueEmail(ctx, notification); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		if err != nil {
+			return err
+		}
+		
+		if delivered > 0 {
+			log.Printf("Delivered %d reminders", delivered)
+		}
+		
+		if delivered < reminderBatchSize {
+			return nil
+		}
+	}
+}
+
+// reminderMessage describes the task a reminder is about
+func reminderMessage(task *entity.Task) string {
+	if task.DueDate == nil {
+		return fmt.Sprintf("This is a reminder about the task %q.", task.Title)
+	}
+	return fmt.Sprintf("The task %q is due %s.", task.Title, task.DueDate.Format("Mon, 02 Jan 2006 15:04 MST"))
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"task2/internal/adapter/presenter"
+	"task2/internal/app/dto"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/service"
+	"task2/pkg/markdown"
+
+	"github.com/google/uuid"
+)
+
+// SavedViewUseCase handles application logic for saved views
+type SavedViewUseCase struct {
+	savedViewService   *service.SavedViewService
+	savedViewPresenter *presenter.SavedViewPresenter
+	taskPresenter      *presenter.TaskPresenter
+}
+
+// NewSavedViewUseCase creates a new saved view use case
+func NewSavedViewUseCase(savedViewService *service.SavedViewService) *SavedViewUseCase {
+	return &SavedViewUseCase{
+		savedViewService:   savedViewService,
+		savedViewPresenter: presenter.NewSavedViewPresenter(),
+		taskPresenter:      presenter.NewTaskPresenter(),
+	}
+}
+
+// SetMarkdownRenderer sets the renderer used for task descriptions
+func (uc *SavedViewUseCase) SetMarkdownRenderer(renderer *markdown.Renderer) {
+	uc.taskPresenter.SetMarkdownRenderer(renderer)
+}
+
+// CreateView creates a new view owned by the current user
+func (uc *SavedViewUseCase) CreateView(ctx context.Context, req *dto.SavedViewRequest, ownerUUID uuid.UUID) (*dto.SavedViewResponse, error) {
+	// Create view entity
+	filter, sort := uc.savedViewPresenter.ToEntity(req)
+	view, err := entity.NewSavedView(ownerUUID, req.Name, filter, sort, req.Columns)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Create view
+	if err := uc.savedViewService.CreateView(ctx, view); err != nil {
+		return nil, err
+	}
+	
+	return uc.savedViewPresenter.ToDTO(view), nil
+}
+
+// GetViews gets the current user's views
+func (uc *SavedViewUseCase) GetViews(ctx context.Context, ownerUUID uuid.UUID) (*dto.SavedViewsResponse, error) {
+	views, err := uc.savedViewService.GetViewsForUser(ctx, ownerUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.savedViewPresenter.ToDTOList(views), nil
+}
+
+// GetView gets one of the current user's views
+func (uc *SavedViewUseCase) GetView(ctx context.Context, viewUUID uuid.UUID, ownerUUID uuid.UUID) (*dto.SavedViewResponse, error) {
+	view, err := uc.savedViewService.GetView(ctx, viewUUID, ownerUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.savedViewPresenter.ToDTO(view), nil
+}
+
+// UpdateView replaces the definition of one of the current user's views
+func (uc *SavedViewUseCase) UpdateView(ctx context.Context, viewUUID uuid.UUID, req *dto.SavedViewRequest, ownerUUID uuid.UUID) (*dto.SavedViewResponse, error) {
+	// Get the view
+	view, err := uc.savedViewService.GetView(ctx, viewUUID, ownerUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Replace its definition
+	view.Name = req.Name
+	view.Filter, view.Sort = uc.savedViewPresenter.ToEntity(req)
+	view.Columns = req.Columns
+	
+	if err := uc.savedViewService.UpdateView(ctx, view); err != nil {
+		return nil, err
+	}
+	
+	return uc.savedViewPresenter.ToDTO(view), nil
+}
+
+// DeleteView deletes one of the current user's views
+func (uc *SavedViewUseCase) DeleteView(ctx context.Context, viewUUID uuid.UUID, ownerUUID uuid.UUID) error {
+	return uc.savedViewService.DeleteView(ctx, viewUUID, ownerUUID)
+}
+
+// PinView makes one of the current user's views their default task list
+func (uc *SavedViewUseCase) PinView(ctx context.Context, viewUUID uuid.UUID, ownerUUID uuid.UUID) (*dto.SavedViewResponse, error) {
+	view, err := uc.savedViewService.PinView(ctx, viewUUID, ownerUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.savedViewPresenter.ToDTO(view), nil
+}
+
+// UnpinView stops one of the current user's views being their default task list
+func (uc *SavedViewUseCase) UnpinView(ctx context.Context, viewUUID uuid.UUID, ownerUUID uuid.UUID) (*dto.SavedViewResponse, error) {
+	view, err := uc.savedViewService.UnpinView(ctx, viewUUID, ownerUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.savedViewPresenter.ToDTO(view), nil
+}
+
+// GetViewTasks runs one of the current user's views
+func (uc *SavedViewUseCase) GetViewTasks(ctx context.Context, viewUUID uuid.UUID, ownerUUID uuid.UUID) (*dto.ViewTasksResponse, error) {
+	view, err := uc.savedViewService.GetView(ctx, viewUUID, ownerUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.executeView(ctx, view, uc.savedViewPresenter.ToDTO(view))
+}
+
+// GetDefaultTasks runs the current user's pinned view, or lists all their visible tasks if none is pinned
+func (uc *SavedViewUseCase) GetDefaultTasks(ctx context.Context, ownerUUID uuid.UUID) (*dto.ViewTasksResponse, error) {
+	view, err := uc.savedViewService.GetPinnedView(ctx, ownerUUID)
+	if err != nil {
+		return nil, err
+	}
+	if view != nil {
+		return uc.executeView(ctx, view, uc.savedViewPresenter.ToDTO(view))
+	}
+	
+	// Fall back to every visible task, newest first
+	view = &entity.SavedView{OwnerID: ownerUUID, Name: "All tasks"}
+	if err := view.Validate(); err != nil {
+		return nil, err
+	}
+	
+	return uc.executeView(ctx, view, nil)
+}
+
+// executeView gets a view's tasks and converts them to DTOs
+func (uc *SavedViewUseCase) executeView(ctx context.Context, view *entity.SavedView, viewResponse *dto.SavedViewResponse) (*dto.ViewTasksResponse, error) {
+	tasks, err := uc.savedViewService.ExecuteView(ctx, view)
+	if err != nil {
+		return nil, err
+	}
+	
+	return &dto.ViewTasksResponse{
+		View:    viewResponse,
+		Columns: view.Columns,
+		Tasks:   uc.taskPresenter.ToDTOList(tasks).Tasks,
+	}, nil
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+	"task2/internal/adapter/presenter"
+	"task2/internal/app/dto"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+// snoozeBatchSize is the number of snoozes woken per transaction
+const snoozeBatchSize = 100
+
+// SnoozeUseCase handles application logic for snoozing tasks
+type SnoozeUseCase struct {
+	snoozeService       *service.SnoozeService
+	notificationUseCase *NotificationUseCase
+	snoozePresenter     *presenter.SnoozePresenter
+}
+
+// NewSnoozeUseCase creates a new snooze use case
+func NewSnoozeUseCase(snoozeService *service.SnoozeService, notificationUseCase *NotificationUseCase) *SnoozeUseCase {
+	return &SnoozeUseCase{
+		snoozeService:       snoozeService,
+		notificationUseCase: notificationUseCase,
+		snoozePresenter:     presenter.NewSnoozePresenter(),
+	}
+}
+
+// SnoozeTask snoozes a task for the user
+func (uc *SnoozeUseCase) SnoozeTask(ctx context.Context, taskUUID uuid.UUID, req *dto.SnoozeRequest, userUUID uuid.UUID) (*dto.SnoozeResponse, error) {
+	snooze, err := uc.snoozeService.SnoozeTask(ctx, taskUUID, userUUID, req.Until)
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.snoozePresenter.ToDTO(snooze), nil
+}
+
+// UnsnoozeTask ends the user's snooze of a task
+func (uc *SnoozeUseCase) UnsnoozeTask(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID) error {
+	return uc.snoozeService.UnsnoozeTask(ctx, taskUUID, userUUID)
+}
+
+// WakeDueSnoozes resurfaces every task whose snooze has ended, notifying the user who snoozed it.
+// In-app notifications and their emails are queued in the transaction that ends the snooze,
+// so running this on several instances notifies each user once.
+func (uc *SnoozeUseCase) WakeDueSnoozes(ctx context.Context) error {
+	for {
+		woken, err := uc.snoozeService.WakeDueSnoozes(ctx, time.Now(), snoozeBatchSize,
+			func(ctx context.Context, snooze *entity.Snooze, task *entity.Task) error {
+				notification := entity.NewNotification(snooze.UserID, entity.NotificationTypeSnooze,
+					"Back from snooze: "+task.Title, fmt.Sprintf("The task %q you snoozed is back in your list.", task.Title), &task.UUID)
+				if err := uc.notificationUseCase.Store(ctx, notification); err != nil {
+					return err
+				}
+				return uc.notificationUseCase.QueueEmail(ctx, notification)
+			})
+		if err != nil {
+			return err
+		}
+		
+		if woken > 0 {
+			log.Printf("Woke %d snoozed tasks", woken)
+		}
+		
+		if woken < snoozeBatchSize {
+			return nil
+		}
+	}
+}
@@ -5,31 +5,72 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"strings"
+	"time"
 	"task2/internal/adapter/presenter"
 	"task2/internal/app/dto"
 	"task2/internal/domain/entity"
 	"task2/internal/domain/service"
+	"task2/pkg/markdown"
+	"task2/pkg/quickadd"
 
 	"github.com/google/uuid"
 )
 
 // TaskUseCase handles application logic for tasks
 type TaskUseCase struct {
-	taskService   *service.TaskService
-	userService   *service.UserService
-	taskPresenter *presenter.TaskPresenter
+	taskService         *service.TaskService
+	userService         *service.UserService
+	notificationUseCase *NotificationUseCase
+	snoozeService       *service.SnoozeService
+	savedViewService    *service.SavedViewService
+	taskPresenter       *presenter.TaskPresenter
+	savedViewPresenter  *presenter.SavedViewPresenter
+	trashRetention      time.Duration
 }
 
+// trashPurgeBatchSize is the number of expired tasks purged per query
+const trashPurgeBatchSize = 100
+
+// exportBatchSize is the number of tasks loaded per query when exporting
+const exportBatchSize = 500
+
 // NewTaskUseCase creates a new task use case
 func NewTaskUseCase(taskService *service.TaskService, userService *service.UserService) *TaskUseCase {
 	return &TaskUseCase{
-		taskService:   taskService,
-		userService:   userService,
-		taskPresenter: presenter.NewTaskPresenter(),
+		taskService:        taskService,
+		userService:        userService,
+		taskPresenter:      presenter.NewTaskPresenter(),
+		savedViewPresenter: presenter.NewSavedViewPresenter(),
 	}
 }
 
+// SetNotificationUseCase sets the use case used to notify users about their tasks
+func (uc *TaskUseCase) SetNotificationUseCase(notificationUseCase *NotificationUseCase) {
+	uc.notificationUseCase = notificationUseCase
+}
+
+// SetSnoozeService sets the service used to hide snoozed tasks from assigned tasks
+func (uc *TaskUseCase) SetSnoozeService(snoozeService *service.SnoozeService) {
+	uc.snoozeService = snoozeService
+}
+
+// SetSavedViewService sets the service used to filter and sort task lists
+func (uc *TaskUseCase) SetSavedViewService(savedViewService *service.SavedViewService) {
+	uc.savedViewService = savedViewService
+}
+
+// SetMarkdownRenderer sets the renderer used for task descriptions
+func (uc *TaskUseCase) SetMarkdownRenderer(renderer *markdown.Renderer) {
+	uc.taskPresenter.SetMarkdownRenderer(renderer)
+}
+
+// SetTrashRetention sets how long deleted tasks stay in the trash; zero keeps them forever
+func (uc *TaskUseCase) SetTrashRetention(retention time.Duration) {
+	uc.trashRetention = retention
+}
+
 // CreateTask creates a new task
 func (uc *TaskUseCase) CreateTask(ctx context.Context, req *dto.CreateTaskRequest, creatorUUID uuid.UUID) (*dto.TaskResponse, error) {
 	// Create task entity
@@ -37,6 +78,7 @@ func (uc *TaskUseCase) CreateTask(ctx context.Context, req *dto.CreateTaskReques
 	if err != nil {
 		return nil, err
 	}
+	task.DueDate = req.DueDate
 	
 	// Create task
 	if err := uc.taskService.CreateTask(ctx, task); err != nil {
@@ -87,10 +129,84 @@ func (uc *TaskUseCase) CreateTask(ctx context.Context, req *dto.CreateTaskReques
 		return nil, err
 	}
 	
+	// Mentions count once the assignees are known
+	if err := uc.taskService.RecordMentions(ctx, createdTask); err != nil {
+		return nil, err
+	}
+	uc.notifyMentions(ctx, createdTask, creatorUUID)
+	
 	// Convert to DTO
 	return uc.taskPresenter.ToDTO(createdTask), nil
 }
 
+// QuickAddTask creates a task from a one-line description such as "Send invoice to ACME tomorrow 5pm #finance !high @sara",
+// returning how the line was read. With dryRun the line is only read, so clients can confirm it first.
+// Mentioned users are added to the task; labels and priority are returned but not stored, as tasks have neither yet.
+func (uc *TaskUseCase) QuickAddTask(ctx context.Context, req *dto.QuickAddRequest, dryRun bool, creatorUUID uuid.UUID) (*dto.QuickAddResponse, error) {
+	// Read the line in the user's timezone
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q", timezone)
+	}
+	
+	parsed := quickadd.Parse(req.Text, time.Now(), loc)
+	
+	interpretation := dto.QuickAddInterpretation{
+		Title:    parsed.Title,
+		DueDate:  parsed.DueDate,
+		Timezone: loc.String(),
+		Labels:   append([]string{}, parsed.Labels...),
+		Priority: parsed.Priority,
+		Mentions: []dto.QuickAddMention{},
+		Request: dto.CreateTaskRequest{
+			Title:   parsed.Title,
+			DueDate: parsed.DueDate,
+		},
+	}
+	
+	// Resolve mentions to the users the task is for
+	var unknown []string
+	for _, mention := range parsed.Mentions {
+		quickAddMention := dto.QuickAddMention{Mention: mention}
+		
+		if user := uc.taskService.ResolveMention(ctx, mention); user != nil {
+			quickAddMention.User = &dto.UserInfo{
+				ID:   user.UUID,
+				Name: user.Name,
+			}
+			interpretation.Request.Users = append(interpretation.Request.Users, dto.UserAssign{ID: user.UUID.String()})
+		} else {
+			unknown = append(unknown, "@"+mention)
+		}
+		
+		interpretation.Mentions = append(interpretation.Mentions, quickAddMention)
+	}
+	
+	resp := &dto.QuickAddResponse{Interpretation: interpretation}
+	if dryRun {
+		return resp, nil
+	}
+	
+	// Create the task
+	if len(unknown) > 0 {
+		return nil, errors.New("unknown or ambiguous users: " + strings.Join(unknown, ", "))
+	}
+	if parsed.Title == "" {
+		return nil, errors.New("task title is required")
+	}
+	
+	resp.Task, err = uc.CreateTask(ctx, &interpretation.Request, creatorUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return resp, nil
+}
+
 // GetTaskByUUID gets a task by UUID
 func (uc *TaskUseCase) GetTaskByUUID(ctx context.Context, taskUUID uuid.UUID) (*dto.TaskResponse, error) {
 	// Get task
@@ -103,10 +219,12 @@ func (uc *TaskUseCase) GetTaskByUUID(ctx context.Context, taskUUID uuid.UUID) (*
 	return uc.taskPresenter.ToDTO(task), nil
 }
 
-// GetAllTasks gets all tasks
-func (uc *TaskUseCase) GetAllTasks(ctx context.Context) (*dto.TasksResponse, error) {
+// GetAllTasks gets all tasks. With a query, it gets the tasks visible to the user that match it instead.
+func (uc *TaskUseCase) GetAllTasks(ctx context.Context, userUUID uuid.UUID, query *dto.TaskListQuery) (*dto.TasksResponse, error) {
 	// Get all tasks
-	tasks, err := uc.taskService.GetAllTasks(ctx)
+	tasks, err := uc.listTasks(ctx, userUUID, query, func() ([]*entity.Task, error) {
+		return uc.taskService.GetAllTasks(ctx)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -115,10 +233,16 @@ func (uc *TaskUseCase) GetAllTasks(ctx context.Context) (*dto.TasksResponse, err
 	return uc.taskPresenter.ToDTOList(tasks), nil
 }
 
-// GetTasksCreatedByUser gets tasks created by a user
-func (uc *TaskUseCase) GetTasksCreatedByUser(ctx context.Context, userUUID uuid.UUID) (*dto.TasksResponse, error) {
+// GetTasksCreatedByUser gets tasks created by a user that match the query, if there is one
+func (uc *TaskUseCase) GetTasksCreatedByUser(ctx context.Context, userUUID uuid.UUID, query *dto.TaskListQuery) (*dto.TasksResponse, error) {
+	if query != nil {
+		query.Filter.CreatedBy = &userUUID
+	}
+	
 	// Get tasks created by user
-	tasks, err := uc.taskService.GetTasksCreatedByUser(ctx, userUUID)
+	tasks, err := uc.listTasks(ctx, userUUID, query, func() ([]*entity.Task, error) {
+		return uc.taskService.GetTasksCreatedByUser(ctx, userUUID)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -127,10 +251,108 @@ func (uc *TaskUseCase) GetTasksCreatedByUser(ctx context.Context, userUUID uuid.
 	return uc.taskPresenter.ToDTOList(tasks), nil
 }
 
-// GetTasksAssignedToUser gets tasks assigned to a user
-func (uc *TaskUseCase) GetTasksAssignedToUser(ctx context.Context, userUUID uuid.UUID) (*dto.TasksResponse, error) {
+// GetTasksAssignedToUser gets tasks assigned to a user that match the query, if there is one,
+// leaving out tasks the user has snoozed unless includeSnoozed is set
+func (uc *TaskUseCase) GetTasksAssignedToUser(ctx context.Context, userUUID uuid.UUID, includeSnoozed bool, query *dto.TaskListQuery) (*dto.TasksResponse, error) {
+	if query != nil {
+		query.Filter.AssignedTo = &userUUID
+	}
+	
 	// Get tasks assigned to user
-	tasks, err := uc.taskService.GetTasksAssignedToUser(ctx, userUUID)
+	tasks, err := uc.listTasks(ctx, userUUID, query, func() ([]*entity.Task, error) {
+		return uc.taskService.GetTasksAssignedToUser(ctx, userUUID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	if uc.snoozeService == nil {
+		return uc.taskPresenter.ToDTOList(tasks), nil
+	}
+	
+	// Get the user's snoozes
+	snoozedUntil, err := uc.snoozeService.GetActiveSnoozes(ctx, userUUID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	
+	if !includeSnoozed {
+		tasks = slices.DeleteFunc(tasks, func(task *entity.Task) bool {
+			_, snoozed := snoozedUntil[task.UUID]
+			return snoozed
+		})
+	}
+	
+	// Convert to DTOs
+	tasksResp := uc.taskPresenter.ToDTOList(tasks)
+	for i := range tasksResp.Tasks {
+		if until, ok := snoozedUntil[tasksResp.Tasks[i].ID]; ok {
+			tasksResp.Tasks[i].SnoozedUntil = &until
+		}
+	}
+	
+	return tasksResp, nil
+}
+
+// listTasks gets a task list with list, or the tasks visible to the user that match the query if there is one
+func (uc *TaskUseCase) listTasks(ctx context.Context, userUUID uuid.UUID, query *dto.TaskListQuery, list func() ([]*entity.Task, error)) ([]*entity.Task, error) {
+	if query == nil {
+		return list()
+	}
+	if uc.savedViewService == nil {
+		return nil, errors.New("task filters are not available")
+	}
+	
+	filter, sort := uc.savedViewPresenter.ToTaskQuery(query.Filter, query.Sort)
+	return uc.savedViewService.FindTasks(ctx, userUUID, filter, sort)
+}
+
+// SetReviewers sets who must approve a task before it completes
+func (uc *TaskUseCase) SetReviewers(ctx context.Context, taskUUID uuid.UUID, req *dto.SetReviewersRequest, requestorUUID uuid.UUID) (*dto.TaskResponse, error) {
+	task, err := uc.taskService.SetReviewers(ctx, taskUUID, req.Reviewers, req.RequiredApprovals, requestorUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Convert to DTO
+	return uc.taskPresenter.ToDTO(task), nil
+}
+
+// ReviewTask approves or rejects a task awaiting the current user's approval
+func (uc *TaskUseCase) ReviewTask(ctx context.Context, taskUUID uuid.UUID, decision string, req *dto.ReviewTaskRequest, reviewerUUID uuid.UUID) (*dto.TaskResponse, error) {
+	task, err := uc.taskService.ReviewTask(ctx, taskUUID, reviewerUUID, decision, req.Comment)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Tell the creator and assignee about rejections and completions
+	if decision == entity.ApprovalDecisionRejected || task.Completed {
+		title := "Task approved"
+		message := fmt.Sprintf("The task %q has been approved and is completed.", task.Title)
+		if decision == entity.ApprovalDecisionRejected {
+			title = "Task rejected"
+			message = fmt.Sprintf("The task %q was rejected: %s", task.Title, req.Comment)
+		}
+		uc.notifyTaskOwners(ctx, task, reviewerUUID, title, message)
+	}
+	
+	// Convert to DTO
+	return uc.taskPresenter.ToDTO(task), nil
+}
+
+// GetApprovals gets every review decision on a task
+func (uc *TaskUseCase) GetApprovals(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID) ([]dto.ApprovalResponse, error) {
+	decisions, err := uc.taskService.GetApprovals(ctx, taskUUID, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.taskPresenter.ToApprovalDTOList(decisions), nil
+}
+
+// GetTasksAwaitingReview gets tasks awaiting the current user's approval
+func (uc *TaskUseCase) GetTasksAwaitingReview(ctx context.Context, userUUID uuid.UUID) (*dto.TasksResponse, error) {
+	tasks, err := uc.taskService.GetTasksAwaitingReviewBy(ctx, userUUID)
 	if err != nil {
 		return nil, err
 	}
@@ -139,6 +361,52 @@ func (uc *TaskUseCase) GetTasksAssignedToUser(ctx context.Context, userUUID uuid
 	return uc.taskPresenter.ToDTOList(tasks), nil
 }
 
+// GetTrash gets the deleted tasks a user created
+func (uc *TaskUseCase) GetTrash(ctx context.Context, userUUID uuid.UUID) (*dto.TasksResponse, error) {
+	// Get deleted tasks
+	tasks, err := uc.taskService.GetTrash(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Convert to DTOs
+	return uc.taskPresenter.ToDTOList(tasks), nil
+}
+
+// RestoreTask restores a deleted task from the trash
+func (uc *TaskUseCase) RestoreTask(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID) (*dto.TaskResponse, error) {
+	task, err := uc.taskService.RestoreTask(ctx, taskUUID, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Convert to DTO
+	return uc.taskPresenter.ToDTO(task), nil
+}
+
+// PurgeTask permanently deletes a task from the trash
+func (uc *TaskUseCase) PurgeTask(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID) error {
+	return uc.taskService.PurgeTask(ctx, taskUUID, userUUID)
+}
+
+// PurgeExpiredTasks permanently deletes tasks that have been in the trash longer than the retention period
+func (uc *TaskUseCase) PurgeExpiredTasks(ctx context.Context) error {
+	if uc.trashRetention <= 0 {
+		return nil
+	}
+	
+	deletedBefore := time.Now().Add(-uc.trashRetention)
+	for {
+		purged, err := uc.taskService.PurgeExpiredTasks(ctx, deletedBefore, trashPurgeBatchSize)
+		if purged > 0 {
+			log.Printf("Purged %d tasks from the trash", purged)
+		}
+		if err != nil || purged < trashPurgeBatchSize {
+			return err
+		}
+	}
+}
+
 // CompleteTask completes a task
 func (uc *TaskUseCase) CompleteTask(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID) (*dto.TaskResponse, error) {
 	// Complete the task
@@ -152,6 +420,10 @@ func (uc *TaskUseCase) CompleteTask(ctx context.Context, taskUUID uuid.UUID, use
 		return nil, err
 	}
 	
+	if task.AwaitingApproval {
+		uc.notifyReviewers(ctx, task, userUUID)
+	}
+	
 	// Convert to DTO
 	return uc.taskPresenter.ToDTO(task), nil
 }
@@ -161,6 +433,39 @@ func (uc *TaskUseCase) DeleteTask(ctx context.Context, taskUUID uuid.UUID, userU
 	return uc.taskService.DeleteTask(ctx, taskUUID, userUUID)
 }
 
+// DuplicateTask copies a task into a new task created by the current user
+func (uc *TaskUseCase) DuplicateTask(ctx context.Context, taskUUID uuid.UUID, req *dto.DuplicateTaskRequest, requestorUUID uuid.UUID) (*dto.TaskResponse, error) {
+	task, err := uc.taskService.DuplicateTask(ctx, taskUUID, requestorUUID, req.IncludeMembers)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Convert to DTO
+	return uc.taskPresenter.ToDTO(task), nil
+}
+
+// LinkTask links a task to another task
+func (uc *TaskUseCase) LinkTask(ctx context.Context, taskUUID uuid.UUID, req *dto.LinkTaskRequest, requestorUUID uuid.UUID) (*dto.TaskResponse, error) {
+	task, err := uc.taskService.LinkTasks(ctx, taskUUID, req.TaskID, req.Type, requestorUUID, req.CloseDuplicate)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Convert to DTO
+	return uc.taskPresenter.ToDTO(task), nil
+}
+
+// UnlinkTask deletes a link from a task
+func (uc *TaskUseCase) UnlinkTask(ctx context.Context, taskUUID uuid.UUID, linkUUID uuid.UUID, requestorUUID uuid.UUID) (*dto.TaskResponse, error) {
+	task, err := uc.taskService.UnlinkTasks(ctx, taskUUID, linkUUID, requestorUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Convert to DTO
+	return uc.taskPresenter.ToDTO(task), nil
+}
+
 // AssignTask assigns a task to a user
 func (uc *TaskUseCase) AssignTask(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID, requestorUUID uuid.UUID) (*dto.TaskResponse, error) {
 	// Assign the task
@@ -176,4 +481,342 @@ func (uc *TaskUseCase) AssignTask(ctx context.Context, taskUUID uuid.UUID, userU
 	
 	// Convert to DTO
 	return uc.taskPresenter.ToDTO(task), nil
-}
\ No newline at end of file
+}
+
+// BulkTaskOperation applies one operation to many tasks, authorizing each task individually
+func (uc *TaskUseCase) BulkTaskOperation(ctx context.Context, req *dto.BulkTaskRequest, requestorUUID uuid.UUID) (*dto.BulkTaskResponse, error) {
+	// Resolve the operation to apply to each task
+	var apply func(ctx context.Context, taskUUID uuid.UUID) error
+	switch req.Operation {
+	case dto.BulkOperationComplete:
+		apply = func(ctx context.Context, taskUUID uuid.UUID) error {
+			return uc.taskService.CompleteTask(ctx, taskUUID, requestorUUID)
+		}
+	case dto.BulkOperationDelete:
+		apply = func(ctx context.Context, taskUUID uuid.UUID) error {
+			return uc.taskService.DeleteTask(ctx, taskUUID, requestorUUID)
+		}
+	case dto.BulkOperationAssign:
+		if req.UserID == nil {
+			return nil, errors.New("user_id is required for the assign operation")
+		}
+		apply = func(ctx context.Context, taskUUID uuid.UUID) error {
+			return uc.taskService.AssignTask(ctx, taskUUID, *req.UserID, requestorUUID)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported bulk operation: %s", req.Operation)
+	}
+
+	resp := &dto.BulkTaskResponse{
+		Operation: req.Operation,
+		Atomic:    req.Atomic,
+		Results:   make([]dto.BulkTaskResult, len(req.TaskIDs)),
+	}
+	for i, taskUUID := range req.TaskIDs {
+		resp.Results[i].ID = taskUUID
+	}
+
+	if req.Atomic {
+		// Stop at the first failure and roll back everything applied so far
+		failed := -1
+		err := uc.taskService.WithinTransaction(ctx, func(ctx context.Context) error {
+			for i, taskUUID := range req.TaskIDs {
+				if err := apply(ctx, taskUUID); err != nil {
+					failed = i
+					return err
+				}
+				resp.Results[i].Success = true
+			}
+			return nil
+		})
+
+		if err != nil {
+			resp.RolledBack = true
+			for i := range resp.Results {
+				resp.Results[i].Success = false
+				switch {
+				case i == failed || failed == -1:
+					resp.Results[i].Error = err.Error()
+				case i < failed:
+					resp.Results[i].Error = "rolled back"
+				default:
+					resp.Results[i].Error = "not attempted"
+				}
+			}
+		}
+	} else {
+		// Apply each task independently
+		for i, taskUUID := range req.TaskIDs {
+			if err := apply(ctx, taskUUID); err != nil {
+				resp.Results[i].Error = err.Error()
+				continue
+			}
+			resp.Results[i].Success = true
+		}
+	}
+
+	// Count results
+	for _, result := range resp.Results {
+		if result.Success {
+			resp.Succeeded++
+		} else {
+			resp.Failed++
+		}
+	}
+
+	return resp, nil
+}
+
+// ExportTasks passes each task visible to a user to write as an export record, oldest first.
+// Tasks are loaded a page at a time, so exports of any size use bounded memory.
+func (uc *TaskUseCase) ExportTasks(ctx context.Context, userUUID uuid.UUID, write func(record dto.TaskRecord) error) error {
+	var afterID int64
+	for {
+		// Get the next page of visible tasks
+		tasks, err := uc.taskService.GetTasksVisibleToUserAfter(ctx, userUUID, afterID, exportBatchSize)
+		if err != nil {
+			return err
+		}
+
+		// Convert to records and write them
+		for _, task := range tasks {
+			if err := write(uc.taskPresenter.ToRecord(task)); err != nil {
+				return err
+			}
+			afterID = task.ID
+		}
+
+		if len(tasks) < exportBatchSize {
+			return nil
+		}
+	}
+}
+
+// ImportTasks creates tasks from decoded import rows and reports the outcome of each row.
+// Rows are imported independently; in dry-run mode rows are only validated.
+func (uc *TaskUseCase) ImportTasks(ctx context.Context, rows []dto.TaskImportRow, dryRun bool, creatorUUID uuid.UUID) (*dto.ImportTasksResponse, error) {
+	resp := &dto.ImportTasksResponse{
+		DryRun: dryRun,
+		Total:  len(rows),
+		Rows:   make([]dto.TaskImportResult, 0, len(rows)),
+	}
+
+	// External IDs already seen in this file, mapped to their line
+	seen := make(map[string]int)
+
+	for _, row := range rows {
+		result := uc.importTask(ctx, row, dryRun, creatorUUID, seen)
+
+		switch result.Status {
+		case dto.ImportStatusCreated:
+			resp.Created++
+		case dto.ImportStatusValid:
+			resp.Valid++
+		case dto.ImportStatusSkipped:
+			resp.Skipped++
+		default:
+			resp.Failed++
+		}
+
+		resp.Rows = append(resp.Rows, result)
+	}
+
+	return resp, nil
+}
+
+// importTask validates and, unless in dry-run mode, creates the task for a single import row
+func (uc *TaskUseCase) importTask(ctx context.Context, row dto.TaskImportRow, dryRun bool, creatorUUID uuid.UUID, seen map[string]int) dto.TaskImportResult {
+	record := row.Record
+	result := dto.TaskImportResult{
+		Line:       row.Line,
+		ExternalID: record.ExternalID,
+		Errors:     append([]string(nil), row.Errors...),
+	}
+
+	// Deduplicate by external ID, within the file and against existing tasks
+	if record.ExternalID != "" {
+		if line, ok := seen[record.ExternalID]; ok {
+			result.Status = dto.ImportStatusSkipped
+			result.Errors = append(result.Errors, fmt.Sprintf("duplicate external_id, already on line %d", line))
+			return result
+		}
+		seen[record.ExternalID] = row.Line
+
+		exists, err := uc.taskService.ExternalIDExists(ctx, creatorUUID, record.ExternalID)
+		if err != nil {
+			result.Status = dto.ImportStatusFailed
+			result.Errors = append(result.Errors, "failed to check external_id")
+			return result
+		}
+		if exists {
+			result.Status = dto.ImportStatusSkipped
+			result.Errors = append(result.Errors, "a task with this external_id already exists")
+			return result
+		}
+	}
+
+	// Validate fields
+	if strings.TrimSpace(record.Title) == "" {
+		result.Errors = append(result.Errors, "title is required")
+	}
+
+	// Resolve assignee and members by email. Unknown users get one error per row that names
+	// neither the address nor the column, so imports cannot be used to probe which emails exist.
+	unknownUser := false
+	var assigneeUUID *uuid.UUID
+	if record.AssignedTo != "" {
+		user, err := uc.userService.GetUserByEmail(ctx, record.AssignedTo)
+		if err != nil {
+			unknownUser = true
+		} else {
+			assigneeUUID = &user.UUID
+		}
+	}
+
+	var memberUUIDs []uuid.UUID
+	for _, email := range record.Members {
+		user, err := uc.userService.GetUserByEmail(ctx, email)
+		if err != nil {
+			unknownUser = true
+			continue
+		}
+
+		// The assignee becomes a member when the task is assigned
+		if (assigneeUUID != nil && user.UUID == *assigneeUUID) || slices.Contains(memberUUIDs, user.UUID) {
+			continue
+		}
+		memberUUIDs = append(memberUUIDs, user.UUID)
+	}
+	if unknownUser {
+		result.Errors = append(result.Errors, fmt.Sprintf("unknown user in row %d", row.Line))
+	}
+
+	if len(result.Errors) > 0 {
+		result.Status = dto.ImportStatusFailed
+		return result
+	}
+
+	if dryRun {
+		result.Status = dto.ImportStatusValid
+		return result
+	}
+
+	// Create the task and its memberships together
+	task, err := entity.NewTask(record.Title, record.Description, creatorUUID)
+	if err != nil {
+		result.Status = dto.ImportStatusFailed
+		result.Errors = append(result.Errors, err.Error())
+		return result
+	}
+	task.ExternalID = record.ExternalID
+	task.Completed = record.Completed
+	task.DueDate = record.DueDate
+
+	err = uc.taskService.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.taskService.CreateTask(ctx, task); err != nil {
+			return err
+		}
+
+		if assigneeUUID != nil {
+			if err := uc.taskService.AssignTask(ctx, task.UUID, *assigneeUUID, creatorUUID); err != nil {
+				return err
+			}
+		}
+
+		for _, memberUUID := range memberUUIDs {
+			if err := uc.taskService.AddUserToTask(ctx, task.UUID, memberUUID, creatorUUID); err != nil {
+				return err
+			}
+		}
+
+		// Mentions count once the assignee and members are known
+		created, err := uc.taskService.GetTaskByUUID(ctx, task.UUID)
+		if err != nil {
+			return err
+		}
+		if err := uc.taskService.RecordMentions(ctx, created); err != nil {
+			return err
+		}
+		task = created
+		return nil
+	})
+	if err != nil {
+		log.Printf("Failed to import task on line %d: %v", row.Line, err)
+		result.Status = dto.ImportStatusFailed
+		result.Errors = append(result.Errors, err.Error())
+		return result
+	}
+
+	uc.notifyMentions(ctx, task, creatorUUID)
+
+	result.Status = dto.ImportStatusCreated
+	result.TaskID = &task.UUID
+	return result
+}
+
+// notifyMentions notifies the users mentioned in a task's description, except its author
+func (uc *TaskUseCase) notifyMentions(ctx context.Context, task *entity.Task, authorUUID uuid.UUID) {
+	if uc.notificationUseCase == nil {
+		return
+	}
+
+	author := "Someone"
+	if task.CreatedBy != nil && task.CreatedBy.UUID == authorUUID {
+		author = task.CreatedBy.Name
+	}
+
+	for _, user := range task.Mentions {
+		if user.UUID == authorUUID {
+			continue
+		}
+
+		uc.notificationUseCase.Notify(ctx, entity.NewNotification(
+			user.UUID,
+			entity.NotificationTypeMention,
+			"You were mentioned in "+task.Title,
+			fmt.Sprintf("%s mentioned you in the task %q.", author, task.Title),
+			&task.UUID,
+		))
+	}
+}
+
+// notifyReviewers tells a task's reviewers that it is awaiting their approval
+func (uc *TaskUseCase) notifyReviewers(ctx context.Context, task *entity.Task, submitterUUID uuid.UUID) {
+	if uc.notificationUseCase == nil {
+		return
+	}
+
+	for _, reviewer := range task.Reviewers {
+		if reviewer.UUID == submitterUUID {
+			continue
+		}
+
+		uc.notificationUseCase.Notify(ctx, entity.NewNotification(
+			reviewer.UUID,
+			entity.NotificationTypeApproval,
+			"Approval requested for "+task.Title,
+			fmt.Sprintf("The task %q is done and awaiting your approval.", task.Title),
+			&task.UUID,
+		))
+	}
+}
+
+// notifyTaskOwners notifies a task's creator and assignee, except the user who caused the notification
+func (uc *TaskUseCase) notifyTaskOwners(ctx context.Context, task *entity.Task, actorUUID uuid.UUID, title string, message string) {
+	if uc.notificationUseCase == nil {
+		return
+	}
+
+	recipients := []uuid.UUID{task.CreatedByID}
+	if task.AssignedToID != nil && *task.AssignedToID != task.CreatedByID {
+		recipients = append(recipients, *task.AssignedToID)
+	}
+
+	for _, recipient := range recipients {
+		if recipient == actorUUID {
+			continue
+		}
+
+		uc.notificationUseCase.Notify(ctx, entity.NewNotification(recipient, entity.NotificationTypeApproval, title, message, &task.UUID))
+	}
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"task2/internal/adapter/presenter"
+	"task2/internal/app/dto"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+// TransferUseCase handles application logic for task ownership transfers
+type TransferUseCase struct {
+	transferService     *service.TransferService
+	taskService         *service.TaskService
+	notificationUseCase *NotificationUseCase
+	transferPresenter   *presenter.TransferPresenter
+}
+
+// NewTransferUseCase creates a new ownership transfer use case
+func NewTransferUseCase(transferService *service.TransferService, taskService *service.TaskService, notificationUseCase *NotificationUseCase) *TransferUseCase {
+	return &TransferUseCase{
+		transferService:     transferService,
+		taskService:         taskService,
+		notificationUseCase: notificationUseCase,
+		transferPresenter:   presenter.NewTransferPresenter(),
+	}
+}
+
+// RequestTransfer asks a user to take over a task, or transfers it immediately on an admin or manager override
+func (uc *TransferUseCase) RequestTransfer(ctx context.Context, taskUUID uuid.UUID, req *dto.TransferRequest, requestorUUID uuid.UUID) (*dto.TransferResponse, error) {
+	transfer, err := uc.transferService.RequestTransfer(ctx, taskUUID, req.ToUserID, requestorUUID, req.Force)
+	if err != nil {
+		return nil, err
+	}
+	
+	if transfer.IsPending() {
+		uc.notify(ctx, transfer.ToUserID, transfer.TaskID, "Task transfer request", "You have been asked to take over the task %q.")
+	} else {
+		uc.notify(ctx, transfer.ToUserID, transfer.TaskID, "Task transferred to you", "You are now the owner of the task %q.")
+	}
+	
+	return uc.transferPresenter.ToDTO(transfer), nil
+}
+
+// RespondToTransfer accepts or declines a transfer addressed to the current user
+func (uc *TransferUseCase) RespondToTransfer(ctx context.Context, transferUUID uuid.UUID, userUUID uuid.UUID, accept bool) (*dto.TransferResponse, error) {
+	transfer, err := uc.transferService.RespondToTransfer(ctx, transferUUID, userUUID, accept)
+	if err != nil {
+		return nil, err
+	}
+	
+	if accept {
+		uc.notify(ctx, transfer.RequestedByID, transfer.TaskID, "Task transfer accepted", "Your request to transfer the task %q was accepted.")
+	} else {
+		uc.notify(ctx, transfer.RequestedByID, transfer.TaskID, "Task transfer declined", "Your request to transfer the task %q was declined.")
+	}
+	
+	return uc.transferPresenter.ToDTO(transfer), nil
+}
+
+// CancelTransfer withdraws a pending transfer the current user requested
+func (uc *TransferUseCase) CancelTransfer(ctx context.Context, transferUUID uuid.UUID, userUUID uuid.UUID) (*dto.TransferResponse, error) {
+	transfer, err := uc.transferService.CancelTransfer(ctx, transferUUID, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.transferPresenter.ToDTO(transfer), nil
+}
+
+// GetPendingTransfers gets pending transfers the current user requested or has to answer
+func (uc *TransferUseCase) GetPendingTransfers(ctx context.Context, userUUID uuid.UUID) ([]dto.TransferResponse, error) {
+	transfers, err := uc.transferService.GetPendingTransfers(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.transferPresenter.ToDTOList(transfers), nil
+}
+
+// TransferAllTasks moves every task created by one user to another
+func (uc *TransferUseCase) TransferAllTasks(ctx context.Context, fromUserUUID uuid.UUID, req *dto.BulkTransferRequest, requestorUUID uuid.UUID) (*dto.BulkTransferResponse, error) {
+	tasks, err := uc.transferService.TransferAllTasks(ctx, fromUserUUID, req.ToUserID, requestorUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	response := &dto.BulkTransferResponse{
+		Transferred: len(tasks),
+		TaskIDs:     make([]uuid.UUID, 0, len(tasks)),
+	}
+	for _, task := range tasks {
+		response.TaskIDs = append(response.TaskIDs, task.UUID)
+	}
+	
+	if len(tasks) > 0 {
+		message := fmt.Sprintf("%d tasks have been transferred to you.", len(tasks))
+		uc.notificationUseCase.Notify(ctx, entity.NewNotification(req.ToUserID, entity.NotificationTypeTransfer, "Tasks transferred to you", message, nil))
+	}
+	
+	return response, nil
+}
+
+// notify sends a transfer notification about a task; format receives the task title
+func (uc *TransferUseCase) notify(ctx context.Context, userUUID uuid.UUID, taskUUID uuid.UUID, title string, format string) {
+	task, err := uc.taskService.GetTaskByUUID(ctx, taskUUID)
+	if err != nil {
+		return
+	}
+	
+	uc.notificationUseCase.Notify(ctx, entity.NewNotification(userUUID, entity.NotificationTypeTransfer, title, fmt.Sprintf(format, task.Title), &taskUUID))
+}
@@ -3,36 +3,73 @@ package usecase
 import (
 	"context"
 	"errors"
+	"log"
+	"net/url"
+	"strings"
+	"time"
 	"task2/internal/adapter/presenter"
 	"task2/internal/app/dto"
 	"task2/internal/domain/entity"
 	"task2/internal/domain/service"
 	"task2/pkg/email"
+	"task2/pkg/utils"
 
 	"github.com/google/uuid"
 )
 
+// refreshTokenBytes is the number of random bytes in a refresh token
+const refreshTokenBytes = 32
+
+// passwordResetTokenBytes is the number of random bytes in a password reset token
+const passwordResetTokenBytes = 32
+
+// verificationResendInterval is how long a user waits before another verification email is sent
+const verificationResendInterval = time.Minute
+
 // UserUseCase handles application logic for users
 type UserUseCase struct {
-	userService   *service.UserService
-	authService   AuthService
-	emailService  *email.EmailService
-	userPresenter *presenter.UserPresenter
+	userService          *service.UserService
+	refreshTokenService  *service.RefreshTokenService
+	passwordResetService *service.PasswordResetService
+	authService          AuthService
+	tokenRevoker         TokenRevoker
+	emailService         *email.EmailService
+	userPresenter        *presenter.UserPresenter
+	
+	// Whether users must verify their email before logging in
+	requireVerifiedLogin bool
+	
+	// Scheme and host of the API that verification links point at
+	appBaseURL string
+	
+	// Page that password reset links point at; without it no reset email is sent
+	passwordResetURL string
 }
 
 // AuthService defines the interface for authentication
 type AuthService interface {
-	GenerateToken(userUUID uuid.UUID) (string, error)
+	GenerateToken(userUUID uuid.UUID, sessionID uuid.UUID) (string, time.Time, error)
 	ValidatePassword(hashedPassword, password string) bool
 	HashPassword(password string) (string, error)
+	GenerateEmailVerificationToken(userUUID uuid.UUID, email string) (string, error)
+	ValidateEmailVerificationToken(token string) (uuid.UUID, string, error)
+}
+
+// TokenRevoker defines the interface for revoking access tokens before they expire
+type TokenRevoker interface {
+	RevokeToken(ctx context.Context, tokenID uuid.UUID, userUUID uuid.UUID, expiresAt time.Time) error
+	RevokeAllTokens(ctx context.Context, userUUID uuid.UUID) error
 }
 
 // NewUserUseCase creates a new user use case
-func NewUserUseCase(userService *service.UserService, authService AuthService) *UserUseCase {
+func NewUserUseCase(userService *service.UserService, refreshTokenService *service.RefreshTokenService, passwordResetService *service.PasswordResetService, authService AuthService, tokenRevoker TokenRevoker) *UserUseCase {
 	return &UserUseCase{
-		userService:   userService,
-		authService:   authService,
-		userPresenter: presenter.NewUserPresenter(),
+		userService:          userService,
+		refreshTokenService:  refreshTokenService,
+		passwordResetService: passwordResetService,
+		authService:          authService,
+		tokenRevoker:         tokenRevoker,
+		userPresenter:        presenter.NewUserPresenter(),
 	}
 }
 
@@ -41,7 +78,24 @@ func (uc *UserUseCase) SetEmailService(emailService *email.EmailService) {
 	uc.emailService = emailService
 }
 
-// CreateUser creates a new user
+// SetRequireVerifiedLogin sets whether users must verify their email before logging in
+func (uc *UserUseCase) SetRequireVerifiedLogin(require bool) {
+	uc.requireVerifiedLogin = require
+}
+
+// SetAppBaseURL sets the scheme and host of the API that verification links point at.
+// Links are never built from request headers, which a client controls; without a base URL no verification email is sent.
+func (uc *UserUseCase) SetAppBaseURL(appBaseURL string) {
+	uc.appBaseURL = appBaseURL
+}
+
+// SetPasswordResetURL sets the page that password reset links point at, such as a front end's reset form.
+// The API has no reset page of its own, so password reset is refused until one is set.
+func (uc *UserUseCase) SetPasswordResetURL(passwordResetURL string) {
+	uc.passwordResetURL = passwordResetURL
+}
+
+// CreateUser creates a new user and emails them a link to verify their address
 func (uc *UserUseCase) CreateUser(ctx context.Context, req *dto.CreateUserRequest) (*dto.UserResponse, error) {
 	// Hash password
 	hashedPassword, err := uc.authService.HashPassword(req.Password)
@@ -60,11 +114,15 @@ func (uc *UserUseCase) CreateUser(ctx context.Context, req *dto.CreateUserReques
 		return nil, err
 	}
 	
-	// Send welcome email if email service is available
+	// Send welcome and verification emails if email service is available
 	if uc.emailService != nil {
 		go func() {
 			_ = uc.emailService.SendRegistrationEmail(user.Email, user.Name)
 		}()
+		
+		if err := uc.sendVerificationEmail(ctx, user); err != nil {
+			log.Printf("Failed to send verification email to user %s: %v", user.UUID, err)
+		}
 	}
 	
 	// Convert to DTO
@@ -84,8 +142,18 @@ func (uc *UserUseCase) Login(ctx context.Context, req *dto.LoginRequest) (*dto.L
 		return nil, errors.New("invalid email or password")
 	}
 	
-	// Generate token
-	token, err := uc.authService.GenerateToken(user.UUID)
+	// Suspended users cannot sign in
+	if user.IsSuspended() {
+		return nil, errors.New("account is suspended")
+	}
+	
+	// Check the email is verified, if required
+	if uc.requireVerifiedLogin && !user.EmailVerified {
+		return nil, errors.New("email address is not verified")
+	}
+	
+	// Start a new session
+	tokens, err := uc.issueTokens(ctx, user.UUID)
 	if err != nil {
 		return nil, err
 	}
@@ -94,11 +162,219 @@ func (uc *UserUseCase) Login(ctx context.Context, req *dto.LoginRequest) (*dto.L
 	userDTO := uc.userPresenter.ToDTO(user)
 	
 	return &dto.LoginResponse{
-		User:  *userDTO,
-		Token: token,
+		User:          *userDTO,
+		TokenResponse: *tokens,
 	}, nil
 }
 
+// issueTokens starts a new session for a user with an access token and a new refresh token family
+func (uc *UserUseCase) issueTokens(ctx context.Context, userUUID uuid.UUID) (*dto.TokenResponse, error) {
+	// Start a new refresh token family, which names the session
+	refreshToken, err := utils.GenerateRandomToken(refreshTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+	
+	issued, err := uc.refreshTokenService.IssueRefreshToken(ctx, userUUID, utils.HashToken(refreshToken))
+	if err != nil {
+		return nil, err
+	}
+	
+	// Generate an access token for the session
+	token, expiresAt, err := uc.authService.GenerateToken(userUUID, issued.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return &dto.TokenResponse{
+		Token:            token,
+		ExpiresAt:        expiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: issued.ExpiresAt,
+	}, nil
+}
+
+// RefreshToken exchanges a refresh token for a new access token and a new refresh token.
+// Only a hash of the refresh token is stored, so the new one is returned once.
+func (uc *UserUseCase) RefreshToken(ctx context.Context, refreshToken string) (*dto.TokenResponse, error) {
+	// Rotate the refresh token
+	newRefreshToken, err := utils.GenerateRandomToken(refreshTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+	
+	rotated, err := uc.refreshTokenService.RotateRefreshToken(ctx, utils.HashToken(refreshToken), utils.HashToken(newRefreshToken))
+	if err != nil {
+		return nil, err
+	}
+	
+	// The user may have been deleted or suspended since logging in
+	user, err := uc.userService.GetUserByUUID(ctx, rotated.UserID)
+	if err != nil || user.IsSuspended() {
+		return nil, errors.New("invalid refresh token")
+	}
+	
+	// Generate an access token
+	token, expiresAt, err := uc.authService.GenerateToken(rotated.UserID, rotated.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return &dto.TokenResponse{
+		Token:            token,
+		ExpiresAt:        expiresAt,
+		RefreshToken:     newRefreshToken,
+		RefreshExpiresAt: rotated.ExpiresAt,
+	}, nil
+}
+
+// Logout ends one session by revoking its access token and the refresh tokens of its session.
+// The refresh token cookie is not sent to the logout route, so the session is found from the access token;
+// a refresh token given in the body is revoked too.
+func (uc *UserUseCase) Logout(ctx context.Context, userUUID uuid.UUID, accessToken *utils.AccessToken, refreshToken string) error {
+	if accessToken != nil {
+		if err := uc.tokenRevoker.RevokeToken(ctx, accessToken.ID, userUUID, accessToken.ExpiresAt); err != nil {
+			return err
+		}
+		
+		if accessToken.SessionID != uuid.Nil {
+			if err := uc.refreshTokenService.RevokeSession(ctx, accessToken.SessionID); err != nil {
+				return err
+			}
+		}
+	}
+	
+	if refreshToken != "" {
+		if err := uc.refreshTokenService.RevokeRefreshToken(ctx, utils.HashToken(refreshToken)); err != nil {
+			return err
+		}
+	}
+	
+	return nil
+}
+
+// LogoutEverywhere ends every session of a user by revoking all of their access and refresh tokens
+func (uc *UserUseCase) LogoutEverywhere(ctx context.Context, userUUID uuid.UUID) error {
+	// Revoke refresh tokens first, so no new access token can be issued after the cut-off
+	if err := uc.refreshTokenService.RevokeAllRefreshTokens(ctx, userUUID); err != nil {
+		return err
+	}
+	
+	return uc.tokenRevoker.RevokeAllTokens(ctx, userUUID)
+}
+
+// VerifyEmail marks the address a verification token was sent to as verified
+func (uc *UserUseCase) VerifyEmail(ctx context.Context, token string) (*dto.UserResponse, error) {
+	userUUID, email, err := uc.authService.ValidateEmailVerificationToken(token)
+	if err != nil {
+		return nil, err
+	}
+	
+	// The token only verifies the address it was sent to, not one the user changed to since
+	if err := uc.userService.VerifyEmail(ctx, userUUID, email); err != nil {
+		return nil, err
+	}
+	
+	return uc.GetUserByUUID(ctx, userUUID)
+}
+
+// ResendVerificationEmail sends another verification email to an unverified user, at most once per resend interval.
+// It succeeds without sending anything for unknown or verified addresses, so it does not reveal which accounts exist.
+func (uc *UserUseCase) ResendVerificationEmail(ctx context.Context, email string) error {
+	if uc.emailService == nil {
+		return errors.New("email is not configured")
+	}
+	if uc.appBaseURL == "" {
+		return errors.New("email verification is not configured")
+	}
+	
+	user, err := uc.userService.GetUserByEmail(ctx, email)
+	if err != nil || user.EmailVerified {
+		return nil
+	}
+	
+	return uc.sendVerificationEmail(ctx, user)
+}
+
+// sendVerificationEmail emails a user a link to verify their address, unless one was sent within the resend interval.
+// Nothing is sent without a configured base URL for the link.
+func (uc *UserUseCase) sendVerificationEmail(ctx context.Context, user *entity.User) error {
+	if uc.appBaseURL == "" {
+		log.Printf("Not sending a verification email to user %s: APP_BASE_URL is not set", user.UUID)
+		return nil
+	}
+	
+	claimed, err := uc.userService.ClaimVerificationEmail(ctx, user.UUID, verificationResendInterval)
+	if err != nil || !claimed {
+		return err
+	}
+	
+	token, err := uc.authService.GenerateEmailVerificationToken(user.UUID, user.Email)
+	if err != nil {
+		return err
+	}
+	
+	link := uc.appBaseURL + "/api/v1/verify-email?token=" + url.QueryEscape(token)
+	go func() {
+		_ = uc.emailService.SendVerificationEmail(user.Email, user.Name, link)
+	}()
+	
+	return nil
+}
+
+// ForgotPassword emails a single-use password reset link to the user with the email.
+// It succeeds without sending anything for unknown addresses, so it does not reveal which accounts exist.
+func (uc *UserUseCase) ForgotPassword(ctx context.Context, email string) error {
+	if uc.emailService == nil {
+		return errors.New("email is not configured")
+	}
+	if uc.passwordResetURL == "" {
+		return errors.New("password reset is not configured")
+	}
+	
+	// Only a hash of the token is stored, so the token is only ever in the email
+	token, err := utils.GenerateRandomToken(passwordResetTokenBytes)
+	if err != nil {
+		return err
+	}
+	
+	user, err := uc.passwordResetService.RequestReset(ctx, email, utils.HashToken(token))
+	if err != nil || user == nil {
+		return err
+	}
+	
+	resetURL := uc.passwordResetURL
+	separator := "?"
+	if strings.Contains(resetURL, "?") {
+		separator = "&"
+	}
+	link := resetURL + separator + "token=" + url.QueryEscape(token)
+	
+	go func() {
+		_ = uc.emailService.SendPasswordResetEmail(user.Email, user.Name, link)
+	}()
+	
+	return nil
+}
+
+// ResetPassword sets a new password with a password reset token and ends every session of the user
+func (uc *UserUseCase) ResetPassword(ctx context.Context, req *dto.ResetPasswordRequest) error {
+	// Hash password
+	hashedPassword, err := uc.authService.HashPassword(req.Password)
+	if err != nil {
+		return err
+	}
+	
+	// Reset password
+	user, err := uc.passwordResetService.ResetPassword(ctx, utils.HashToken(req.Token), hashedPassword)
+	if err != nil {
+		return err
+	}
+	
+	// Whoever knew the old password is logged out
+	return uc.LogoutEverywhere(ctx, user.UUID)
+}
+
 // GetUserByUUID gets a user by UUID
 func (uc *UserUseCase) GetUserByUUID(ctx context.Context, userUUID uuid.UUID) (*dto.UserResponse, error) {
 	// Get user
@@ -123,8 +399,52 @@ func (uc *UserUseCase) GetAllUsers(ctx context.Context) (*dto.UsersResponse, err
 	return uc.userPresenter.ToDTOList(users), nil
 }
 
-// UpdateUser updates a user
-func (uc *UserUseCase) UpdateUser(ctx context.Context, userUUID uuid.UUID, req *dto.UserResponse) (*dto.UserResponse, error) {
+// ChangeUserRole changes a user's role
+func (uc *UserUseCase) ChangeUserRole(ctx context.Context, userUUID uuid.UUID, req *dto.ChangeRoleRequest) (*dto.UserResponse, error) {
+	user, err := uc.userService.ChangeRole(ctx, userUUID, entity.Role(req.Role))
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.userPresenter.ToDTO(user), nil
+}
+
+// SuspendUser suspends a user and ends all of their sessions
+func (uc *UserUseCase) SuspendUser(ctx context.Context, userUUID uuid.UUID) (*dto.UserResponse, error) {
+	user, err := uc.userService.SuspendUser(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := uc.LogoutEverywhere(ctx, user.UUID); err != nil {
+		return nil, err
+	}
+	
+	return uc.userPresenter.ToDTO(user), nil
+}
+
+// ReactivateUser lifts a user's suspension so they can sign in again
+func (uc *UserUseCase) ReactivateUser(ctx context.Context, userUUID uuid.UUID) (*dto.UserResponse, error) {
+	user, err := uc.userService.ReactivateUser(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return uc.userPresenter.ToDTO(user), nil
+}
+
+// DeleteUser deletes a user and ends all of their sessions
+func (uc *UserUseCase) DeleteUser(ctx context.Context, userUUID uuid.UUID) error {
+	if err := uc.userService.DeleteUser(ctx, userUUID); err != nil {
+		return err
+	}
+	
+	return uc.LogoutEverywhere(ctx, userUUID)
+}
+
+// UpdateUser updates the fields of a user's profile present in the request.
+// A new email address has to be verified again, so a verification link is sent to it.
+func (uc *UserUseCase) UpdateUser(ctx context.Context, userUUID uuid.UUID, req *dto.UpdateProfileRequest) (*dto.UserResponse, error) {
 	// Get user
 	user, err := uc.userService.GetUserByUUID(ctx, userUUID)
 	if err != nil {
@@ -132,16 +452,18 @@ func (uc *UserUseCase) UpdateUser(ctx context.Context, userUUID uuid.UUID, req *
 	}
 	
 	// Update user fields
-	if req.Name != "" {
-		if err := user.UpdateName(req.Name); err != nil {
+	if req.Name != nil {
+		if err := user.UpdateName(strings.TrimSpace(*req.Name)); err != nil {
 			return nil, err
 		}
 	}
 	
-	if req.Email != "" && req.Email != user.Email {
-		if err := user.UpdateEmail(req.Email); err != nil {
+	emailChanged := false
+	if req.Email != nil && *req.Email != user.Email {
+		if err := user.UpdateEmail(*req.Email); err != nil {
 			return nil, err
 		}
+		emailChanged = true
 	}
 	
 	// Update user
@@ -149,6 +471,49 @@ func (uc *UserUseCase) UpdateUser(ctx context.Context, userUUID uuid.UUID, req *
 		return nil, err
 	}
 	
+	// Ask the user to verify the new address
+	if emailChanged && uc.emailService != nil {
+		if err := uc.sendVerificationEmail(ctx, user); err != nil {
+			log.Printf("Failed to send verification email to user %s: %v", user.UUID, err)
+		}
+	}
+	
 	// Convert to DTO
 	return uc.userPresenter.ToDTO(user), nil
-}
\ No newline at end of file
+}
+
+// ChangePassword replaces a user's password after checking the current one.
+// Every session is logged out, and a new session is returned for the caller.
+func (uc *UserUseCase) ChangePassword(ctx context.Context, userUUID uuid.UUID, req *dto.ChangePasswordRequest) (*dto.TokenResponse, error) {
+	// Get user
+	user, err := uc.userService.GetUserByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Check the current password
+	if !uc.authService.ValidatePassword(user.Password, req.CurrentPassword) {
+		return nil, errors.New("current password is incorrect")
+	}
+	
+	// Hash and set the new password
+	hashedPassword, err := uc.authService.HashPassword(req.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := user.UpdatePassword(hashedPassword); err != nil {
+		return nil, err
+	}
+	
+	if err := uc.userService.UpdateUser(ctx, user); err != nil {
+		return nil, err
+	}
+	
+	// Log out every other session and start a new one for the caller
+	if err := uc.LogoutEverywhere(ctx, user.UUID); err != nil {
+		return nil, err
+	}
+	
+	return uc.issueTokens(ctx, user.UUID)
+}
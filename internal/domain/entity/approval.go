@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Approval decisions
+const (
+	ApprovalDecisionApproved = "approved"
+	ApprovalDecisionRejected = "rejected"
+)
+
+// ApprovalDecision records a reviewer approving or rejecting a task in one review round
+type ApprovalDecision struct {
+	ID         int64
+	UUID       uuid.UUID
+	TaskID     uuid.UUID
+	ReviewerID uuid.UUID
+	Round      int
+	Decision   string
+	Comment    string
+	CreatedAt  time.Time
+}
+
+// NewApprovalDecision creates a reviewer's decision on a task in its current review round.
+// Rejections must explain themselves with a comment.
+func NewApprovalDecision(task *Task, reviewerID uuid.UUID, decision string, comment string) (*ApprovalDecision, error) {
+	comment = strings.TrimSpace(comment)
+	if decision == ApprovalDecisionRejected && comment == "" {
+		return nil, errors.New("a comment is required when rejecting a task")
+	}
+	
+	return &ApprovalDecision{
+		UUID:       uuid.New(),
+		TaskID:     task.UUID,
+		ReviewerID: reviewerID,
+		Round:      task.ApprovalRound,
+		Decision:   decision,
+		Comment:    comment,
+		CreatedAt:  time.Now(),
+	}, nil
+}
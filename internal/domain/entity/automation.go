@@ -0,0 +1,216 @@
+package entity
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Automation condition operators
+const (
+	ConditionEquals     = "equals"
+	ConditionNotEquals  = "not_equals"
+	ConditionContains   = "contains"
+	ConditionIsEmpty    = "is_empty"
+	ConditionIsNotEmpty = "is_not_empty"
+)
+
+// Automation condition fields
+const (
+	ConditionFieldTitle       = "title"
+	ConditionFieldDescription = "description"
+	ConditionFieldAssignedTo  = "assigned_to"
+	ConditionFieldCompleted   = "completed"
+	ConditionFieldDueDate     = "due_date"
+)
+
+// Automation action types
+const (
+	ActionNotify    = "notify"
+	ActionAssign    = "assign"
+	ActionAddMember = "add_member"
+	ActionComplete  = "complete"
+)
+
+// Notify action targets
+const (
+	NotifyCreator  = "creator"
+	NotifyAssignee = "assignee"
+	NotifyMembers  = "members"
+)
+
+// Automation execution statuses
+const (
+	ExecutionRunning   = "running"
+	ExecutionSucceeded = "succeeded"
+	ExecutionFailed    = "failed"
+)
+
+// AutomationRule runs actions on a user's tasks when a trigger fires and all conditions hold
+type AutomationRule struct {
+	ID          int64
+	UUID        uuid.UUID
+	OwnerID     uuid.UUID // rules apply to tasks created by their owner
+	Name        string
+	Trigger     string
+	OverdueDays int // for task.overdue, how long past the due date the task must be
+	Conditions  []RuleCondition
+	Actions     []RuleAction
+	Enabled     bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// RuleCondition compares a task field with a value
+type RuleCondition struct {
+	Field    string
+	Operator string
+	Value    string
+}
+
+// RuleAction is a change or notification made when a rule runs
+type RuleAction struct {
+	Type    string
+	Target  string     // notify: creator, assignee or members
+	UserID  *uuid.UUID // assign and add_member
+	Message string     // notify: optional custom message
+}
+
+// AutomationExecution records one run of a rule against a task
+type AutomationExecution struct {
+	ID        int64
+	UUID      uuid.UUID
+	RuleID    int64
+	TaskID    uuid.UUID
+	Trigger   string
+	Status    string
+	Error     string
+	DedupeKey string // set for scheduled triggers so each occurrence runs once
+	CreatedAt time.Time
+}
+
+// NewAutomationRule creates a new enabled rule after validating it
+func NewAutomationRule(ownerID uuid.UUID, name, trigger string, overdueDays int, conditions []RuleCondition, actions []RuleAction) (*AutomationRule, error) {
+	rule := &AutomationRule{
+		UUID:        uuid.New(),
+		OwnerID:     ownerID,
+		Name:        name,
+		Trigger:     trigger,
+		OverdueDays: overdueDays,
+		Conditions:  conditions,
+		Actions:     actions,
+		Enabled:     true,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	
+	return rule, nil
+}
+
+// Validate checks that the rule's trigger, conditions and actions are well formed
+func (r *AutomationRule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("rule name is required")
+	}
+	
+	if !slices.Contains([]string{TaskEventCreated, TaskEventCompleted, TaskEventAssigned, TaskEventOverdue}, r.Trigger) {
+		return errors.New("unknown trigger: " + r.Trigger)
+	}
+	
+	if r.OverdueDays < 0 {
+		return errors.New("overdue_days cannot be negative")
+	}
+	
+	for _, condition := range r.Conditions {
+		if !slices.Contains([]string{ConditionFieldTitle, ConditionFieldDescription, ConditionFieldAssignedTo, ConditionFieldCompleted, ConditionFieldDueDate}, condition.Field) {
+			return errors.New("unknown condition field: " + condition.Field)
+		}
+		if !slices.Contains([]string{ConditionEquals, ConditionNotEquals, ConditionContains, ConditionIsEmpty, ConditionIsNotEmpty}, condition.Operator) {
+			return errors.New("unknown condition operator: " + condition.Operator)
+		}
+	}
+	
+	if len(r.Actions) == 0 {
+		return errors.New("rule must have at least one action")
+	}
+	
+	for _, action := range r.Actions {
+		switch action.Type {
+		case ActionNotify:
+			if !slices.Contains([]string{NotifyCreator, NotifyAssignee, NotifyMembers}, action.Target) {
+				return errors.New("notify action target must be creator, assignee or members")
+			}
+		case ActionAssign, ActionAddMember:
+			if action.UserID == nil {
+				return errors.New(action.Type + " action requires a user_id")
+			}
+		case ActionComplete:
+		default:
+			return errors.New("unknown action type: " + action.Type)
+		}
+	}
+	
+	return nil
+}
+
+// Matches checks if all of the rule's conditions hold for a task
+func (r *AutomationRule) Matches(task *Task) bool {
+	for _, condition := range r.Conditions {
+		if !condition.Matches(task) {
+			return false
+		}
+	}
+	
+	return true
+}
+
+// Matches checks if the condition holds for a task. String comparisons ignore case.
+func (c RuleCondition) Matches(task *Task) bool {
+	value := strings.ToLower(taskFieldValue(task, c.Field))
+	expected := strings.ToLower(c.Value)
+	
+	switch c.Operator {
+	case ConditionEquals:
+		return value == expected
+	case ConditionNotEquals:
+		return value != expected
+	case ConditionContains:
+		return strings.Contains(value, expected)
+	case ConditionIsEmpty:
+		return value == ""
+	case ConditionIsNotEmpty:
+		return value != ""
+	}
+	
+	return false
+}
+
+// taskFieldValue returns a task field as a string for condition matching
+func taskFieldValue(task *Task, field string) string {
+	switch field {
+	case ConditionFieldTitle:
+		return task.Title
+	case ConditionFieldDescription:
+		return task.Description
+	case ConditionFieldAssignedTo:
+		if task.AssignedToID != nil {
+			return task.AssignedToID.String()
+		}
+	case ConditionFieldCompleted:
+		return strconv.FormatBool(task.Completed)
+	case ConditionFieldDueDate:
+		if task.DueDate != nil {
+			return task.DueDate.Format(time.RFC3339)
+		}
+	}
+	
+	return ""
+}
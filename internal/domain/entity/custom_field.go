@@ -0,0 +1,242 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Custom field types
+const (
+	CustomFieldText        = "text"
+	CustomFieldNumber      = "number"
+	CustomFieldDate        = "date"
+	CustomFieldSelect      = "select"
+	CustomFieldMultiSelect = "multi_select"
+	CustomFieldUser        = "user"
+)
+
+// CustomFieldTypes lists the valid custom field types
+var CustomFieldTypes = []string{
+	CustomFieldText,
+	CustomFieldNumber,
+	CustomFieldDate,
+	CustomFieldSelect,
+	CustomFieldMultiSelect,
+	CustomFieldUser,
+}
+
+// maxCustomFieldTextLength is the longest text value a custom field accepts, in characters
+const maxCustomFieldTextLength = 1000
+
+// CustomField is a typed field definition owned by a user. It applies to the tasks its owner created.
+type CustomField struct {
+	ID        int64
+	UUID      uuid.UUID
+	OwnerID   uuid.UUID
+	Name      string
+	Type      string
+	Options   []string // the choices of a select or multi_select field
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CustomFieldValue is the value of a custom field on a task.
+// Value holds a string for text, date, select and user fields, a float64 for number fields
+// and a []string for multi_select fields.
+type CustomFieldValue struct {
+	Field *CustomField
+	Value any
+}
+
+// NewCustomField creates a new custom field after validating it
+func NewCustomField(ownerID uuid.UUID, name string, fieldType string, options []string) (*CustomField, error) {
+	field := &CustomField{
+		UUID:      uuid.New(),
+		OwnerID:   ownerID,
+		Name:      strings.TrimSpace(name),
+		Type:      fieldType,
+		Options:   options,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := field.Validate(); err != nil {
+		return nil, err
+	}
+
+	return field, nil
+}
+
+// Validate checks the field's name, type and options
+func (f *CustomField) Validate() error {
+	if f.Name == "" {
+		return errors.New("field name is required")
+	}
+
+	if !slices.Contains(CustomFieldTypes, f.Type) {
+		return errors.New("invalid field type")
+	}
+
+	if !f.hasOptions() {
+		if len(f.Options) > 0 {
+			return errors.New("only select and multi_select fields have options")
+		}
+		return nil
+	}
+
+	if len(f.Options) == 0 {
+		return errors.New("select fields need at least one option")
+	}
+	seen := make(map[string]bool, len(f.Options))
+	for _, option := range f.Options {
+		if strings.TrimSpace(option) == "" {
+			return errors.New("options cannot be empty")
+		}
+		if seen[option] {
+			return fmt.Errorf("duplicate option %q", option)
+		}
+		seen[option] = true
+	}
+
+	return nil
+}
+
+// hasOptions checks if values of the field are chosen from its options
+func (f *CustomField) hasOptions() bool {
+	return f.Type == CustomFieldSelect || f.Type == CustomFieldMultiSelect
+}
+
+// IsSortable checks if tasks can be sorted by the field
+func (f *CustomField) IsSortable() bool {
+	return f.Type != CustomFieldMultiSelect
+}
+
+// ParseValue validates a decoded JSON value against the field's type and returns it in its stored form
+func (f *CustomField) ParseValue(value any) (any, error) {
+	if f.Type == CustomFieldMultiSelect {
+		values, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a list of options", f.Name)
+		}
+
+		options := make([]string, 0, len(values))
+		for _, value := range values {
+			option, err := f.parseOption(value)
+			if err != nil {
+				return nil, err
+			}
+			if !slices.Contains(options, option) {
+				options = append(options, option)
+			}
+		}
+		return options, nil
+	}
+
+	return f.parseScalar(value)
+}
+
+// ParseFilterValue validates a value to filter tasks by. A multi_select field is filtered by one of its options.
+func (f *CustomField) ParseFilterValue(value any) (any, error) {
+	if f.Type == CustomFieldMultiSelect {
+		return f.parseOption(value)
+	}
+
+	return f.parseScalar(value)
+}
+
+// ParseFilterText is like ParseFilterValue for a value given as text, such as in a query string
+func (f *CustomField) ParseFilterText(text string) (any, error) {
+	if f.Type == CustomFieldNumber {
+		number, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a number", f.Name)
+		}
+		return f.ParseFilterValue(number)
+	}
+
+	return f.ParseFilterValue(text)
+}
+
+// parseScalar validates a single value of a field that is not multi_select
+func (f *CustomField) parseScalar(value any) (any, error) {
+	switch f.Type {
+	case CustomFieldText:
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be text", f.Name)
+		}
+		if len([]rune(text)) > maxCustomFieldTextLength {
+			return nil, fmt.Errorf("%s must be at most %d characters", f.Name, maxCustomFieldTextLength)
+		}
+		return text, nil
+
+	case CustomFieldNumber:
+		number, ok := value.(float64)
+		if !ok || math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, fmt.Errorf("%s must be a number", f.Name)
+		}
+		return number, nil
+
+	case CustomFieldDate:
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a date in YYYY-MM-DD format", f.Name)
+		}
+		date, err := time.Parse(time.DateOnly, text)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a date in YYYY-MM-DD format", f.Name)
+		}
+		return date.Format(time.DateOnly), nil
+
+	case CustomFieldSelect:
+		return f.parseOption(value)
+
+	case CustomFieldUser:
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a user ID", f.Name)
+		}
+		userUUID, err := uuid.Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a user ID", f.Name)
+		}
+		return userUUID.String(), nil
+	}
+
+	return nil, errors.New("invalid field type")
+}
+
+// parseOption validates that a value is one of the field's options
+func (f *CustomField) parseOption(value any) (string, error) {
+	option, ok := value.(string)
+	if !ok || !slices.Contains(f.Options, option) {
+		return "", fmt.Errorf("%s must be one of: %s", f.Name, strings.Join(f.Options, ", "))
+	}
+	return option, nil
+}
+
+// Update renames the field and replaces its options. Options can be added or reordered
+// but not removed, since tasks may have them as values.
+func (f *CustomField) Update(name string, options []string) error {
+	for _, option := range f.Options {
+		if !slices.Contains(options, option) {
+			return fmt.Errorf("option %q cannot be removed", option)
+		}
+	}
+
+	f.Name = strings.TrimSpace(name)
+	f.Options = options
+	if err := f.Validate(); err != nil {
+		return err
+	}
+
+	f.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+)
+
+// mentionPattern matches @handle and @email mentions that are not part of a larger word or address
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@.])@([A-Za-z0-9._%+-]+(?:@[A-Za-z0-9.-]+\.[A-Za-z]{2,})?)`)
+
+// ExtractMentions returns the distinct handles and emails mentioned in text, in order of appearance.
+// A handle is the part of a user's email before the @.
+func ExtractMentions(text string) []string {
+	var mentions []string
+	seen := make(map[string]bool)
+	
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		// Drop sentence punctuation after a handle, e.g. "thanks @alice."
+		mention := strings.TrimRight(match[1], ".-")
+		key := strings.ToLower(mention)
+		if mention == "" || seen[key] {
+			continue
+		}
+		
+		seen[key] = true
+		mentions = append(mentions, mention)
+	}
+	
+	return mentions
+}
+
+// IsEmailMention checks if a mention is a full email address rather than a handle
+func IsEmailMention(mention string) bool {
+	return strings.Contains(mention, "@")
+}
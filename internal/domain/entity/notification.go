@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification types
+const (
+	NotificationTypeMention    = "mention"
+	NotificationTypeAutomation = "automation"
+	NotificationTypeReminder   = "reminder"
+	NotificationTypeTransfer   = "transfer"
+	NotificationTypeApproval   = "approval"
+	NotificationTypeSnooze     = "snooze"
+)
+
+// Notification represents an in-app notification for a user
+type Notification struct {
+	ID        int64
+	UUID      uuid.UUID
+	UserID    uuid.UUID
+	Type      string
+	Title     string
+	Message   string
+	TaskID    *uuid.UUID
+	ReadAt    *time.Time
+	CreatedAt time.Time
+}
+
+// NewNotification creates a new unread notification for a user
+func NewNotification(userID uuid.UUID, notificationType, title, message string, taskID *uuid.UUID) *Notification {
+	return &Notification{
+		UUID:      uuid.New(),
+		UserID:    userID,
+		Type:      notificationType,
+		Title:     title,
+		Message:   message,
+		TaskID:    taskID,
+		CreatedAt: time.Now(),
+	}
+}
+
+// IsRead checks if the notification has been read
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+// MarkRead marks the notification as read
+func (n *Notification) MarkRead() {
+	if n.ReadAt != nil {
+		return
+	}
+	
+	now := time.Now()
+	n.ReadAt = &now
+}
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxEmailAttempts is the number of times an outbox email is tried before it is given up
+const MaxEmailAttempts = 10
+
+// OutboxEmail is an email waiting to be sent. It is stored in the same transaction as the change
+// it reports and sent by a background job, which retries failed sends with a growing delay.
+type OutboxEmail struct {
+	ID            int64
+	UUID          uuid.UUID
+	To            string
+	Subject       string
+	Body          string
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	SentAt        *time.Time
+	CreatedAt     time.Time
+}
+
+// NewOutboxEmail creates an email that is due to be sent now
+func NewOutboxEmail(to, subject, body string) *OutboxEmail {
+	now := time.Now()
+	return &OutboxEmail{
+		UUID:          uuid.New(),
+		To:            to,
+		Subject:       subject,
+		Body:          body,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+}
+
+// MarkSent records that the email was sent
+func (e *OutboxEmail) MarkSent(now time.Time) {
+	e.Attempts++
+	e.LastError = ""
+	e.SentAt = &now
+}
+
+// MarkFailed records a failed send and schedules the next attempt,
+// waiting one minute after the first failure and doubling up to an hour
+func (e *OutboxEmail) MarkFailed(now time.Time, err error) {
+	e.Attempts++
+	e.LastError = err.Error()
+	
+	delay := time.Minute << min(e.Attempts-1, 6)
+	e.NextAttemptAt = now.Add(min(delay, time.Hour))
+}
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ownership transfer statuses
+const (
+	TransferStatusPending   = "pending"
+	TransferStatusAccepted  = "accepted"
+	TransferStatusDeclined  = "declined"
+	TransferStatusCancelled = "cancelled"
+)
+
+// OwnershipTransfer is a request to hand a task over to a new owner, who has to accept it
+type OwnershipTransfer struct {
+	ID            int64
+	UUID          uuid.UUID
+	TaskID        uuid.UUID
+	FromUserID    uuid.UUID // owner when the transfer was requested
+	ToUserID      uuid.UUID
+	RequestedByID uuid.UUID
+	Status        string
+	CreatedAt     time.Time
+	RespondedAt   *time.Time
+}
+
+// NewOwnershipTransfer creates a pending transfer of a task to a new owner
+func NewOwnershipTransfer(task *Task, toUserID uuid.UUID, requestedByID uuid.UUID) (*OwnershipTransfer, error) {
+	if task.CreatedByID == toUserID {
+		return nil, errors.New("user already owns the task")
+	}
+	
+	return &OwnershipTransfer{
+		UUID:          uuid.New(),
+		TaskID:        task.UUID,
+		FromUserID:    task.CreatedByID,
+		ToUserID:      toUserID,
+		RequestedByID: requestedByID,
+		Status:        TransferStatusPending,
+		CreatedAt:     time.Now(),
+	}, nil
+}
+
+// IsPending checks if the transfer is still waiting for an answer
+func (t *OwnershipTransfer) IsPending() bool {
+	return t.Status == TransferStatusPending
+}
+
+// Resolve records the outcome of a pending transfer
+func (t *OwnershipTransfer) Resolve(status string) error {
+	if !t.IsPending() {
+		return errors.New("transfer is no longer pending")
+	}
+	
+	now := time.Now()
+	t.Status = status
+	t.RespondedAt = &now
+	return nil
+}
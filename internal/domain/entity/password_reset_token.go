@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordResetTokenTTL is how long a password reset link can be used
+const PasswordResetTokenTTL = time.Hour
+
+// PasswordResetToken is a single-use token, emailed to a user, that lets them set a new password
+type PasswordResetToken struct {
+	ID        int64
+	UserID    uuid.UUID
+	TokenHash string // SHA-256 hash of the secret token
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// NewPasswordResetToken creates a password reset token for a user
+func NewPasswordResetToken(userID uuid.UUID, tokenHash string) *PasswordResetToken {
+	now := time.Now()
+	return &PasswordResetToken{
+		UserID:    userID,
+		TokenHash: tokenHash,
+		ExpiresAt: now.Add(PasswordResetTokenTTL),
+		CreatedAt: now,
+	}
+}
+
+// IsUsable checks whether the token can be used at the given time
+func (t *PasswordResetToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
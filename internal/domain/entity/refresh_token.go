@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultRefreshTokenTTL is how long a refresh token can be used when no other lifetime is configured
+const DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+// RefreshToken is a single-use token that exchanges for a new access token.
+// Each use replaces it with a new token in the same family, so a family traces one login session.
+type RefreshToken struct {
+	ID        int64
+	UserID    uuid.UUID
+	FamilyID  uuid.UUID
+	TokenHash string // SHA-256 hash of the secret token
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// NewRefreshToken creates a refresh token in the given family that expires after ttl
+func NewRefreshToken(userID uuid.UUID, familyID uuid.UUID, tokenHash string, ttl time.Duration) *RefreshToken {
+	now := time.Now()
+	return &RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: tokenHash,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+}
+
+// IsUsable checks whether the token can be exchanged at the given time
+func (t *RefreshToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// MarkUsed records that the token was exchanged
+func (t *RefreshToken) MarkUsed(now time.Time) {
+	t.UsedAt = &now
+}
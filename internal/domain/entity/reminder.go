@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reminder notifies a set of users about a task at a fixed time or a set time before it is due
+type Reminder struct {
+	ID               int64
+	UUID             uuid.UUID
+	TaskID           uuid.UUID
+	CreatedByID      uuid.UUID
+	RemindAt         *time.Time // absolute reminder time
+	MinutesBeforeDue *int       // relative reminder; follows changes to the task's due date
+	Recipients       []uuid.UUID
+	SentAt           *time.Time
+	CreatedAt        time.Time
+}
+
+// NewReminder creates a new reminder for a task. Exactly one of remindAt and minutesBeforeDue must be set.
+// Recipients must take part in the task and default to the reminder's creator.
+func NewReminder(task *Task, createdByID uuid.UUID, remindAt *time.Time, minutesBeforeDue *int, recipients []uuid.UUID) (*Reminder, error) {
+	if (remindAt == nil) == (minutesBeforeDue == nil) {
+		return nil, errors.New("set either remind_at or minutes_before_due")
+	}
+	
+	if remindAt != nil && !remindAt.After(time.Now()) {
+		return nil, errors.New("remind_at must be in the future")
+	}
+	
+	if minutesBeforeDue != nil {
+		if *minutesBeforeDue < 0 {
+			return nil, errors.New("minutes_before_due cannot be negative")
+		}
+		if task.DueDate == nil {
+			return nil, errors.New("task has no due date")
+		}
+	}
+	
+	if len(recipients) == 0 {
+		recipients = []uuid.UUID{createdByID}
+	}
+	
+	// Deduplicate and check recipients
+	var unique []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, recipient := range recipients {
+		if seen[recipient] {
+			continue
+		}
+		if !task.HasParticipant(recipient) {
+			return nil, errors.New("reminder recipients must be the task's creator, assignee or members")
+		}
+		
+		seen[recipient] = true
+		unique = append(unique, recipient)
+	}
+	
+	return &Reminder{
+		UUID:             uuid.New(),
+		TaskID:           task.UUID,
+		CreatedByID:      createdByID,
+		RemindAt:         remindAt,
+		MinutesBeforeDue: minutesBeforeDue,
+		Recipients:       unique,
+		CreatedAt:        time.Now(),
+	}, nil
+}
+
+// FireAt returns when the reminder fires for a task due at dueDate, or nil if it never will
+func (r *Reminder) FireAt(dueDate *time.Time) *time.Time {
+	if r.RemindAt != nil {
+		return r.RemindAt
+	}
+	
+	if dueDate == nil || r.MinutesBeforeDue == nil {
+		return nil
+	}
+	
+	fireAt := dueDate.Add(-time.Duration(*r.MinutesBeforeDue) * time.Minute)
+	return &fireAt
+}
+
+// IsSent checks if the reminder has been delivered
+func (r *Reminder) IsSent() bool {
+	return r.SentAt != nil
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RevokedToken is an access token that was revoked before it expired, identified by its jti claim
+type RevokedToken struct {
+	TokenID   uuid.UUID
+	UserID    uuid.UUID
+	ExpiresAt time.Time
+	RevokedAt time.Time
+}
+
+// NewRevokedToken revokes the access token with the given ID
+func NewRevokedToken(tokenID uuid.UUID, userID uuid.UUID, expiresAt time.Time) *RevokedToken {
+	return &RevokedToken{
+		TokenID:   tokenID,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+		RevokedAt: time.Now(),
+	}
+}
@@ -0,0 +1,76 @@
+package entity
+
+// Role is a user's role, which decides what they are allowed to do
+type Role string
+
+// User roles, from most to least privileged
+const (
+	RoleAdmin   Role = "admin"
+	RoleManager Role = "manager"
+	RoleMember  Role = "member"
+	RoleGuest   Role = "guest"
+)
+
+// Permission is an action a role may be allowed to take
+type Permission string
+
+// Permissions granted by roles
+const (
+	PermissionTasksRead        Permission = "tasks:read"         // view tasks and everything on them
+	PermissionTasksWrite       Permission = "tasks:write"        // create, change and delete tasks
+	PermissionTasksTransferAny Permission = "tasks:transfer_any" // transfer any task without the new owner's consent
+	PermissionUsersList        Permission = "users:list"         // list every user
+	PermissionUsersManage      Permission = "users:manage"       // suspend, reactivate and delete users and change roles
+)
+
+// rolePermissions is the permission matrix: the permissions each role is granted
+var rolePermissions = map[Role][]Permission{
+	RoleAdmin: {
+		PermissionTasksRead,
+		PermissionTasksWrite,
+		PermissionTasksTransferAny,
+		PermissionUsersList,
+		PermissionUsersManage,
+	},
+	RoleManager: {
+		PermissionTasksRead,
+		PermissionTasksWrite,
+		PermissionTasksTransferAny,
+		PermissionUsersList,
+	},
+	RoleMember: {
+		PermissionTasksRead,
+		PermissionTasksWrite,
+	},
+	RoleGuest: {
+		PermissionTasksRead,
+	},
+}
+
+// IsValid checks if the role is one of the known roles
+func (r Role) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Can checks if the role grants a permission
+func (r Role) Can(permission Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid checks if the permission is one of the known permissions
+func (p Permission) IsValid() bool {
+	for _, permissions := range rolePermissions {
+		for _, permission := range permissions {
+			if permission == p {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package entity
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Task sort fields
+const (
+	SortByCreatedAt = "created_at"
+	SortByUpdatedAt = "updated_at"
+	SortByDueDate   = "due_date"
+	SortByTitle     = "title"
+	
+	// SortByCustomField sorts by the custom field in TaskSort.CustomFieldID
+	SortByCustomField = "custom_field"
+)
+
+// ErrInvalidTaskQuery marks errors caused by a task list's filter or sort, rather than by loading the tasks
+var ErrInvalidTaskQuery = errors.New("invalid task query")
+
+// TaskColumns lists the task fields a view can show, by their JSON names
+var TaskColumns = []string{
+	"id", "title", "description", "description_html", "completed", "due_date", "version",
+	"created_at", "updated_at", "created_by", "assigned_to", "users", "mentions", "custom_fields",
+}
+
+// DefaultTaskColumns are shown by views that do not choose their own columns
+var DefaultTaskColumns = []string{"title", "completed", "due_date", "assigned_to"}
+
+// TaskFilter selects tasks; unset fields match every task
+type TaskFilter struct {
+	Completed  *bool
+	CreatedBy  *uuid.UUID
+	AssignedTo *uuid.UUID
+	Search     string // case-insensitive match on title or description
+	DueBefore  *time.Time
+	DueAfter   *time.Time
+	HasDueDate *bool
+	
+	// CustomFields match tasks whose custom fields have the given values
+	CustomFields []CustomFieldFilter
+}
+
+// CustomFieldFilter matches tasks whose value of a custom field equals Value, or for a
+// multi_select field includes it. Value is in the form returned by CustomField.ParseFilterValue.
+type CustomFieldFilter struct {
+	FieldID uuid.UUID
+	Value   any
+}
+
+// TaskSort orders tasks
+type TaskSort struct {
+	Field         string
+	Descending    bool
+	CustomFieldID *uuid.UUID // set when Field is SortByCustomField
+}
+
+// SavedView is a named task filter, sort and column set owned by a user
+type SavedView struct {
+	ID        int64
+	UUID      uuid.UUID
+	OwnerID   uuid.UUID
+	Name      string
+	Filter    TaskFilter
+	Sort      TaskSort
+	Columns   []string
+	Pinned    bool // the owner's default task list
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewSavedView creates a new saved view after validating it
+func NewSavedView(ownerID uuid.UUID, name string, filter TaskFilter, sort TaskSort, columns []string) (*SavedView, error) {
+	view := &SavedView{
+		UUID:      uuid.New(),
+		OwnerID:   ownerID,
+		Name:      name,
+		Filter:    filter,
+		Sort:      sort,
+		Columns:   columns,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	
+	if err := view.Validate(); err != nil {
+		return nil, err
+	}
+	
+	return view, nil
+}
+
+// Validate checks the view's name, sort and columns, filling in defaults for the sort and columns
+func (v *SavedView) Validate() error {
+	if strings.TrimSpace(v.Name) == "" {
+		return errors.New("view name is required")
+	}
+	
+	if err := v.Sort.Validate(); err != nil {
+		return err
+	}
+	
+	if len(v.Columns) == 0 {
+		v.Columns = DefaultTaskColumns
+	}
+	for _, column := range v.Columns {
+		if !slices.Contains(TaskColumns, column) {
+			return errors.New("unknown column: " + column)
+		}
+	}
+	
+	return v.Filter.Validate()
+}
+
+// Validate checks the sort field, defaulting to the newest tasks first
+func (s *TaskSort) Validate() error {
+	if s.Field == "" {
+		*s = TaskSort{Field: SortByCreatedAt, Descending: true}
+	}
+	if !slices.Contains([]string{SortByCreatedAt, SortByUpdatedAt, SortByDueDate, SortByTitle, SortByCustomField}, s.Field) {
+		return errors.New("unknown sort field: " + s.Field)
+	}
+	if s.Field == SortByCustomField && s.CustomFieldID == nil {
+		return errors.New("custom_field_id is required to sort by custom_field")
+	}
+	if s.Field != SortByCustomField && s.CustomFieldID != nil {
+		return errors.New("custom_field_id is only used to sort by custom_field")
+	}
+	
+	return nil
+}
+
+// Validate checks that the filter's due date range can match a task
+func (f TaskFilter) Validate() error {
+	if f.DueBefore != nil && f.DueAfter != nil && !f.DueAfter.Before(*f.DueBefore) {
+		return errors.New("due_after must be before due_before")
+	}
+	
+	return nil
+}
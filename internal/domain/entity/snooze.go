@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Snooze hides a task from one user's assigned tasks until a given time
+type Snooze struct {
+	ID        int64
+	TaskID    uuid.UUID
+	UserID    uuid.UUID
+	Until     time.Time
+	CreatedAt time.Time
+}
+
+// NewSnooze snoozes a task the user takes part in until a future time
+func NewSnooze(task *Task, userID uuid.UUID, until time.Time) (*Snooze, error) {
+	if !task.HasParticipant(userID) {
+		return nil, errors.New("task not found")
+	}
+	if !until.After(time.Now()) {
+		return nil, errors.New("snooze time must be in the future")
+	}
+	
+	return &Snooze{
+		TaskID:    task.UUID,
+		UserID:    userID,
+		Until:     until,
+		CreatedAt: time.Now(),
+	}, nil
+}
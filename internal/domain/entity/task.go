@@ -7,24 +7,43 @@ import (
 	"github.com/google/uuid"
 )
 
+// ErrVersionConflict is returned when a task was modified since the version the caller expected
+var ErrVersionConflict = errors.New("task has been modified by another request")
+
 // Task represents the core task entity
 type Task struct {
 	ID          int64
 	UUID        uuid.UUID
+	Key         string // short human-readable key such as TASK-12, assigned when the task is created
 	Title       string
 	Description string
 	Completed   bool
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	DeletedAt   *time.Time
+	ExternalID  string // identifier in an external system, used to deduplicate imports
+	DueDate     *time.Time
+	Version     int64 // incremented on every write, used for optimistic concurrency control
+	
+	// Review: a task with reviewers waits for their approval before it counts as completed
+	RequiredApprovals int // approvals needed to complete the task; zero means no review
+	AwaitingApproval  bool
+	ApprovalRound     int // incremented each time the task is submitted for approval
+	
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time
 
-	CreatedByID uuid.UUID
+	CreatedByID  uuid.UUID
 	AssignedToID *uuid.UUID
 
 	// References to other entities
 	CreatedBy  *User
 	AssignedTo *User
 	Users      []*User
+	Mentions   []*User     // users mentioned in the description
+	Reviewers  []*User     // users who approve the task before it completes
+	Links      []*TaskLink // links to other tasks, as seen from this task
+	
+	// CustomFields are the values set for its creator's custom fields
+	CustomFields []*CustomFieldValue
 }
 
 // NewTask creates a new task with the given parameters
@@ -38,6 +57,7 @@ func NewTask(title, description string, createdByID uuid.UUID) (*Task, error) {
 		Title:       title,
 		Description: description,
 		Completed:   false,
+		Version:     1,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 		CreatedByID: createdByID,
@@ -55,6 +75,12 @@ func (t *Task) Complete() error {
 	return nil
 }
 
+// SetDueDate sets or clears the task's due date
+func (t *Task) SetDueDate(dueDate *time.Time) {
+	t.DueDate = dueDate
+	t.UpdatedAt = time.Now()
+}
+
 // CanBeModifiedBy checks if a user can modify this task
 func (t *Task) CanBeModifiedBy(userID uuid.UUID) bool {
 	// Task creator can always modify
@@ -72,6 +98,122 @@ func (t *Task) CanBeModifiedBy(userID uuid.UUID) bool {
 	return false
 }
 
+// HasParticipant checks if a user created, is assigned to, is a member of or reviews the task
+func (t *Task) HasParticipant(userID uuid.UUID) bool {
+	if t.CreatedByID == userID || (t.AssignedToID != nil && *t.AssignedToID == userID) || t.IsReviewer(userID) {
+		return true
+	}
+	
+	for _, user := range t.Users {
+		if user.UUID == userID {
+			return true
+		}
+	}
+	
+	return false
+}
+
+// RequiresApproval checks if the task has to be approved before it completes
+func (t *Task) RequiresApproval() bool {
+	return t.RequiredApprovals > 0
+}
+
+// IsReviewer checks if a user reviews the task
+func (t *Task) IsReviewer(userID uuid.UUID) bool {
+	for _, reviewer := range t.Reviewers {
+		if reviewer.UUID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// CanReview checks if a user reviews the task and is neither its creator nor its assignee,
+// so nobody approves their own work
+func (t *Task) CanReview(userID uuid.UUID) bool {
+	if t.CreatedByID == userID || (t.AssignedToID != nil && *t.AssignedToID == userID) {
+		return false
+	}
+	return t.IsReviewer(userID)
+}
+
+// SetReviewers sets who reviews the task and how many of them must approve it.
+// A required count of zero means every reviewer must approve; no reviewers turns review off.
+// The task's creator and assignee cannot review it.
+func (t *Task) SetReviewers(reviewers []*User, requiredApprovals int) error {
+	if t.AwaitingApproval {
+		return errors.New("task is awaiting approval")
+	}
+	
+	for _, reviewer := range reviewers {
+		if reviewer.UUID == t.CreatedByID || (t.AssignedToID != nil && *t.AssignedToID == reviewer.UUID) {
+			return errors.New("the task's creator and assignee cannot review it")
+		}
+	}
+	
+	if requiredApprovals == 0 {
+		requiredApprovals = len(reviewers)
+	}
+	if requiredApprovals < 0 || requiredApprovals > len(reviewers) {
+		return errors.New("required approvals must be between 1 and the number of reviewers")
+	}
+	
+	t.Reviewers = reviewers
+	t.RequiredApprovals = requiredApprovals
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// SubmitForApproval moves a task that requires approval to awaiting approval, starting a new review round
+func (t *Task) SubmitForApproval() error {
+	if t.Completed {
+		return errors.New("task is already completed")
+	}
+	if t.AwaitingApproval {
+		return errors.New("task is already awaiting approval")
+	}
+	
+	t.AwaitingApproval = true
+	t.ApprovalRound++
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// Approve completes a task awaiting approval once it has enough approvals
+func (t *Task) Approve() error {
+	t.AwaitingApproval = false
+	return t.Complete()
+}
+
+// Reject returns a task awaiting approval to in progress
+func (t *Task) Reject() {
+	t.AwaitingApproval = false
+	t.UpdatedAt = time.Now()
+}
+
+// FindLink finds the task's link of the given type to another task
+func (t *Task) FindLink(linkType string, taskID uuid.UUID) *TaskLink {
+	for _, link := range t.Links {
+		if link.Type == linkType && link.TargetTaskID == taskID {
+			return link
+		}
+	}
+	return nil
+}
+
+// CloseAsDuplicate completes a task that duplicates another, without waiting for approval
+func (t *Task) CloseAsDuplicate() error {
+	t.AwaitingApproval = false
+	return t.Complete()
+}
+
+// TransferOwnership makes another user the task's creator and owner
+func (t *Task) TransferOwnership(newOwnerID uuid.UUID) {
+	t.CreatedByID = newOwnerID
+	t.CreatedBy = nil
+	t.UpdatedAt = time.Now()
+}
+
 // AssignTo assigns the task to a user
 func (t *Task) AssignTo(userID uuid.UUID) {
 	t.AssignedToID = &userID
@@ -89,4 +231,4 @@ func (t *Task) AddUser(user *User) {
 	
 	t.Users = append(t.Users, user)
 	t.UpdatedAt = time.Now()
-}
\ No newline at end of file
+}
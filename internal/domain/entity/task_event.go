@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Task event types
+const (
+	TaskEventCreated   = "task.created"
+	TaskEventCompleted = "task.completed"
+	TaskEventAssigned  = "task.assigned"
+	TaskEventOverdue   = "task.overdue"
+)
+
+// TaskEvent records something that happened to a task
+type TaskEvent struct {
+	Type       string
+	TaskUUID   uuid.UUID
+	ActorUUID  uuid.UUID // user whose action raised the event; uuid.Nil for scheduled events
+	OccurredAt time.Time
+}
+
+// NewTaskEvent creates a new task event that occurred now
+func NewTaskEvent(eventType string, taskUUID uuid.UUID, actorUUID uuid.UUID) TaskEvent {
+	return TaskEvent{
+		Type:       eventType,
+		TaskUUID:   taskUUID,
+		ActorUUID:  actorUUID,
+		OccurredAt: time.Now(),
+	}
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultTaskKeyPrefix is the prefix of task keys when none is configured
+const DefaultTaskKeyPrefix = "TASK"
+
+// taskKeyPrefixPattern matches a key prefix: an upper-case letter followed by up to nine letters or digits
+var taskKeyPrefixPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]{0,9}$`)
+
+// taskKeyPattern matches a task key such as OPS-123
+var taskKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]{0,9}-[1-9][0-9]*$`)
+
+// IsValidTaskKeyPrefix checks if a prefix can be used for task keys
+func IsValidTaskKeyPrefix(prefix string) bool {
+	return taskKeyPrefixPattern.MatchString(prefix)
+}
+
+// FormatTaskKey builds the key of the task with the given number under a prefix
+func FormatTaskKey(prefix string, number int64) string {
+	return fmt.Sprintf("%s-%d", prefix, number)
+}
+
+// ParseTaskKey normalises a task key to upper case, reporting whether it is a well-formed key
+func ParseTaskKey(value string) (string, bool) {
+	key := strings.ToUpper(value)
+	return key, taskKeyPattern.MatchString(key)
+}
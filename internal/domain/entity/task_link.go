@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Task link types. Each type has an inverse, which is how the link reads from the other task.
+const (
+	TaskLinkRelatesTo      = "relates_to"
+	TaskLinkDuplicates     = "duplicates"
+	TaskLinkIsDuplicatedBy = "is_duplicated_by"
+	TaskLinkClones         = "clones"
+	TaskLinkIsClonedBy     = "is_cloned_by"
+	TaskLinkCausedBy       = "caused_by"
+	TaskLinkCauses         = "causes"
+)
+
+// taskLinkInverses maps each link type to its inverse
+var taskLinkInverses = map[string]string{
+	TaskLinkRelatesTo:      TaskLinkRelatesTo,
+	TaskLinkDuplicates:     TaskLinkIsDuplicatedBy,
+	TaskLinkIsDuplicatedBy: TaskLinkDuplicates,
+	TaskLinkClones:         TaskLinkIsClonedBy,
+	TaskLinkIsClonedBy:     TaskLinkClones,
+	TaskLinkCausedBy:       TaskLinkCauses,
+	TaskLinkCauses:         TaskLinkCausedBy,
+}
+
+// storedTaskLinkTypes are the types links are stored as; the others are stored as their inverse
+var storedTaskLinkTypes = map[string]bool{
+	TaskLinkRelatesTo:  true,
+	TaskLinkDuplicates: true,
+	TaskLinkClones:     true,
+	TaskLinkCausedBy:   true,
+}
+
+// InverseTaskLinkType returns how a link of the given type reads from the other task
+func InverseTaskLinkType(linkType string) string {
+	return taskLinkInverses[linkType]
+}
+
+// TaskLink is a typed, non-blocking link from one task to another.
+// Links are stored once; a task's Links hold each link as seen from that task.
+type TaskLink struct {
+	ID           int64
+	UUID         uuid.UUID
+	SourceTaskID uuid.UUID
+	TargetTaskID uuid.UUID
+	Type         string
+	CreatedByID  uuid.UUID
+	CreatedAt    time.Time
+
+	// TargetTask is the linked task, when loaded
+	TargetTask *Task
+}
+
+// NewTaskLink creates a link of the given type from source to target, stored in the direction of its stored type
+func NewTaskLink(source *Task, target *Task, linkType string, createdByID uuid.UUID) (*TaskLink, error) {
+	if _, ok := taskLinkInverses[linkType]; !ok {
+		return nil, errors.New("invalid link type")
+	}
+	if source.UUID == target.UUID {
+		return nil, errors.New("a task cannot be linked to itself")
+	}
+	
+	link := &TaskLink{
+		UUID:         uuid.New(),
+		SourceTaskID: source.UUID,
+		TargetTaskID: target.UUID,
+		Type:         linkType,
+		CreatedByID:  createdByID,
+		CreatedAt:    time.Now(),
+		TargetTask:   target,
+	}
+	if !storedTaskLinkTypes[linkType] {
+		link = link.Inverse(source)
+	}
+	
+	return link, nil
+}
+
+// Inverse returns the link as seen from its target task, pointing back at sourceTask
+func (l *TaskLink) Inverse(sourceTask *Task) *TaskLink {
+	return &TaskLink{
+		ID:           l.ID,
+		UUID:         l.UUID,
+		SourceTaskID: l.TargetTaskID,
+		TargetTaskID: l.SourceTaskID,
+		Type:         InverseTaskLinkType(l.Type),
+		CreatedByID:  l.CreatedByID,
+		CreatedAt:    l.CreatedAt,
+		TargetTask:   sourceTask,
+	}
+}
@@ -9,14 +9,19 @@ import (
 
 // User represents the core user entity
 type User struct {
-	ID        int64
-	UUID      uuid.UUID
-	Name      string
-	Email     string
-	Password  string
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt *time.Time
+	ID                 int64
+	UUID               uuid.UUID
+	Name               string
+	Email              string
+	Password           string
+	CalendarTokenHash  string // SHA-256 hash of the secret calendar feed token
+	EmailVerified      bool
+	VerificationSentAt *time.Time // when a verification email was last sent
+	Role               Role
+	SuspendedAt        *time.Time // when the user was suspended; nil while active
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	DeletedAt          *time.Time
 
 	// References to other entities - initialized as empty slice to avoid nil issues
 	Tasks []*Task
@@ -33,6 +38,7 @@ func NewUser(name, email, hashedPassword string) (*User, error) {
 		Name:      name,
 		Email:     email,
 		Password:  hashedPassword,
+		Role:      RoleMember,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 		Tasks:     make([]*Task, 0), // Initialize with empty slice
@@ -56,7 +62,10 @@ func (u *User) UpdateEmail(email string) error {
 		return errors.New("email cannot be empty")
 	}
 	
+	// A new address has not been verified yet
 	u.Email = email
+	u.EmailVerified = false
+	u.VerificationSentAt = nil
 	u.UpdatedAt = time.Now()
 	return nil
 }
@@ -70,4 +79,42 @@ func (u *User) UpdatePassword(hashedPassword string) error {
 	u.Password = hashedPassword
 	u.UpdatedAt = time.Now()
 	return nil
-}
\ No newline at end of file
+}
+
+// ChangeRole changes the user's role
+func (u *User) ChangeRole(role Role) error {
+	if !role.IsValid() {
+		return errors.New("invalid role")
+	}
+	
+	u.Role = role
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// Can checks if the user may take an action; suspended users may not take any
+func (u *User) Can(permission Permission) bool {
+	return !u.IsSuspended() && u.Role.Can(permission)
+}
+
+// IsSuspended checks if the user is suspended
+func (u *User) IsSuspended() bool {
+	return u.SuspendedAt != nil
+}
+
+// Suspend suspends the user, blocking them from signing in
+func (u *User) Suspend() {
+	if u.IsSuspended() {
+		return
+	}
+	
+	now := time.Now()
+	u.SuspendedAt = &now
+	u.UpdatedAt = now
+}
+
+// Reactivate lifts the user's suspension
+func (u *User) Reactivate() {
+	u.SuspendedAt = nil
+	u.UpdatedAt = time.Now()
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"task2/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// ApprovalRepository defines the interface for task approval decision data access
+type ApprovalRepository interface {
+	// Record a decision
+	Create(ctx context.Context, decision *entity.ApprovalDecision) error
+	
+	// Get every decision on a task, oldest first
+	GetByTask(ctx context.Context, taskUUID uuid.UUID) ([]*entity.ApprovalDecision, error)
+	
+	// Get the decisions on a task in one review round
+	GetByTaskAndRound(ctx context.Context, taskUUID uuid.UUID, round int) ([]*entity.ApprovalDecision, error)
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"task2/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// AutomationRepository defines the interface for automation rule data access
+type AutomationRepository interface {
+	// Create a new rule
+	Create(ctx context.Context, rule *entity.AutomationRule) error
+	
+	// Get a rule by its UUID
+	GetByUUID(ctx context.Context, uuid uuid.UUID) (*entity.AutomationRule, error)
+	
+	// Get the rules owned by a user
+	GetByOwner(ctx context.Context, ownerUUID uuid.UUID) ([]*entity.AutomationRule, error)
+	
+	// Get a user's enabled rules for a trigger
+	GetEnabledByOwnerAndTrigger(ctx context.Context, ownerUUID uuid.UUID, trigger string) ([]*entity.AutomationRule, error)
+	
+	// Get all enabled rules for a trigger
+	GetEnabledByTrigger(ctx context.Context, trigger string) ([]*entity.AutomationRule, error)
+	
+	// Update an existing rule
+	Update(ctx context.Context, rule *entity.AutomationRule) error
+	
+	// Delete a rule and its execution log
+	Delete(ctx context.Context, uuid uuid.UUID) error
+	
+	// Record the start of an execution.
+	// Returns false without recording anything if an execution with the same dedupe key exists.
+	CreateExecution(ctx context.Context, execution *entity.AutomationExecution) (bool, error)
+	
+	// Record the outcome of an execution
+	UpdateExecution(ctx context.Context, execution *entity.AutomationExecution) error
+	
+	// Get the most recent executions of a rule, newest first
+	GetExecutions(ctx context.Context, ruleID int64, limit int) ([]*entity.AutomationExecution, error)
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"task2/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// CustomFieldRepository defines the interface for custom field data access
+type CustomFieldRepository interface {
+	// Create a new field
+	Create(ctx context.Context, field *entity.CustomField) error
+	
+	// Get a field by its UUID
+	GetByUUID(ctx context.Context, uuid uuid.UUID) (*entity.CustomField, error)
+	
+	// Get the fields owned by a user
+	GetByOwner(ctx context.Context, ownerUUID uuid.UUID) ([]*entity.CustomField, error)
+	
+	// Update a field's name and options
+	Update(ctx context.Context, field *entity.CustomField) error
+	
+	// Delete a field and its values
+	Delete(ctx context.Context, uuid uuid.UUID) error
+	
+	// Set the value of a field on a task
+	SetValue(ctx context.Context, task *entity.Task, field *entity.CustomField, value any) error
+	
+	// Clear the value of a field on a task
+	ClearValue(ctx context.Context, task *entity.Task, field *entity.CustomField) error
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"task2/internal/domain/entity"
+)
+
+// EmailOutboxRepository defines the interface for email outbox data access
+type EmailOutboxRepository interface {
+	// Add an email to the outbox
+	Create(ctx context.Context, email *entity.OutboxEmail) error
+	
+	// Lock up to limit unsent emails whose next attempt is due at now and that have attempts left,
+	// skipping emails locked by other transactions.
+	// Must be called within a transaction; the locks are held until it ends.
+	ClaimDue(ctx context.Context, now time.Time, limit int) ([]*entity.OutboxEmail, error)
+	
+	// Save the outcome of an attempt to send an email
+	UpdateDelivery(ctx context.Context, email *entity.OutboxEmail) error
+}
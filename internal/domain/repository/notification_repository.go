@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"task2/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// NotificationRepository defines the interface for notification data access
+type NotificationRepository interface {
+	// Create a new notification
+	Create(ctx context.Context, notification *entity.Notification) error
+	
+	// Get a notification by its UUID
+	GetByUUID(ctx context.Context, uuid uuid.UUID) (*entity.Notification, error)
+	
+	// Get a user's notifications, newest first
+	GetByUser(ctx context.Context, userUUID uuid.UUID, unreadOnly bool) ([]*entity.Notification, error)
+	
+	// Mark a notification as read
+	MarkRead(ctx context.Context, notification *entity.Notification) error
+	
+	// Mark all of a user's notifications as read
+	MarkAllRead(ctx context.Context, userUUID uuid.UUID) error
+}
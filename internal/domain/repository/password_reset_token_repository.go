@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"task2/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// PasswordResetTokenRepository defines the interface for password reset token data access
+type PasswordResetTokenRepository interface {
+	// Create a new password reset token
+	Create(ctx context.Context, token *entity.PasswordResetToken) error
+	
+	// Get and lock a password reset token by its hash until the surrounding transaction ends
+	GetByHashForUpdate(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error)
+	
+	// Mark every unused password reset token of a user as used
+	MarkAllUsedForUser(ctx context.Context, userUUID uuid.UUID, usedAt time.Time) error
+}
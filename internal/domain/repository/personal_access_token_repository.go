@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"task2/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// PersonalAccessTokenRepository defines the interface for personal access token data access
+type PersonalAccessTokenRepository interface {
+	// Create a new personal access token
+	Create(ctx context.Context, token *entity.PersonalAccessToken) error
+	
+	// Get and lock a personal access token by UUID until the surrounding transaction ends
+	GetByUUIDForUpdate(ctx context.Context, uuid uuid.UUID) (*entity.PersonalAccessToken, error)
+	
+	// Get a personal access token by its hash
+	GetByHash(ctx context.Context, tokenHash string) (*entity.PersonalAccessToken, error)
+	
+	// Get a user's personal access tokens that are not revoked, newest first
+	GetByUser(ctx context.Context, userUUID uuid.UUID) ([]*entity.PersonalAccessToken, error)
+	
+	// Update the secret and revocation of a personal access token
+	Update(ctx context.Context, token *entity.PersonalAccessToken) error
+	
+	// Record when and from which IP a personal access token was last used
+	RecordUse(ctx context.Context, id int64, usedAt time.Time, ip string) error
+}
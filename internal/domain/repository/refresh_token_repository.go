@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"task2/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// RefreshTokenRepository defines the interface for refresh token data access
+type RefreshTokenRepository interface {
+	// Create a new refresh token
+	Create(ctx context.Context, token *entity.RefreshToken) error
+	
+	// Get and lock a refresh token by its hash until the surrounding transaction ends
+	GetByHashForUpdate(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	
+	// Record that a refresh token was exchanged
+	MarkUsed(ctx context.Context, id int64, usedAt time.Time) error
+	
+	// Revoke every refresh token in a family that is not already revoked
+	RevokeFamily(ctx context.Context, familyID uuid.UUID, revokedAt time.Time) error
+	
+	// Revoke every refresh token of a user that is not already revoked
+	RevokeAllForUser(ctx context.Context, userUUID uuid.UUID, revokedAt time.Time) error
+}
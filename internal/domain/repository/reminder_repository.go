@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"task2/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// ReminderRepository defines the interface for reminder data access
+type ReminderRepository interface {
+	// Create a new reminder
+	Create(ctx context.Context, reminder *entity.Reminder) error
+	
+	// Get a reminder by its UUID
+	GetByUUID(ctx context.Context, uuid uuid.UUID) (*entity.Reminder, error)
+	
+	// Get a task's reminders
+	GetByTask(ctx context.Context, taskUUID uuid.UUID) ([]*entity.Reminder, error)
+	
+	// Delete a reminder
+	Delete(ctx context.Context, uuid uuid.UUID) error
+	
+	// Lock up to limit unsent reminders that are due at now, skipping reminders locked by other transactions.
+	// Must be called within a transaction; the locks are held until it ends.
+	ClaimDue(ctx context.Context, now time.Time, limit int) ([]*entity.Reminder, error)
+	
+	// Mark a reminder as delivered
+	MarkSent(ctx context.Context, reminder *entity.Reminder) error
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"task2/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// SavedViewRepository defines the interface for saved view data access
+type SavedViewRepository interface {
+	// Create a new view
+	Create(ctx context.Context, view *entity.SavedView) error
+	
+	// Get a view by its UUID
+	GetByUUID(ctx context.Context, uuid uuid.UUID) (*entity.SavedView, error)
+	
+	// Get the views owned by a user
+	GetByOwner(ctx context.Context, ownerUUID uuid.UUID) ([]*entity.SavedView, error)
+	
+	// Update an existing view
+	Update(ctx context.Context, view *entity.SavedView) error
+	
+	// Delete a view
+	Delete(ctx context.Context, uuid uuid.UUID) error
+	
+	// Pin a view as its owner's default, unpinning any other
+	Pin(ctx context.Context, view *entity.SavedView) error
+	
+	// Unpin a view
+	Unpin(ctx context.Context, view *entity.SavedView) error
+	
+	// Remove a deleted custom field from every view's filter and sort
+	RemoveCustomField(ctx context.Context, fieldUUID uuid.UUID) error
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"task2/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// SnoozeRepository defines the interface for task snooze data access
+type SnoozeRepository interface {
+	// Snooze a task for a user, replacing any earlier snooze of it by the user
+	Save(ctx context.Context, snooze *entity.Snooze) error
+	
+	// Delete a user's snooze of a task, reporting whether there was one
+	Delete(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID) (bool, error)
+	
+	// Get a user's snoozes that have not ended by the given time
+	GetActiveByUser(ctx context.Context, userUUID uuid.UUID, now time.Time) ([]*entity.Snooze, error)
+	
+	// Lock and return up to limit snoozes on tasks that are not deleted that ended by the given time,
+	// skipping snoozes locked by another transaction
+	ClaimDue(ctx context.Context, now time.Time, limit int) ([]*entity.Snooze, error)
+}
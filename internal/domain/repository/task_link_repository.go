@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"task2/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// TaskLinkRepository defines the interface for task link data access.
+// Links are read through the tasks they belong to.
+type TaskLinkRepository interface {
+	// Create a new link
+	Create(ctx context.Context, link *entity.TaskLink) error
+	
+	// Delete a link by its UUID
+	Delete(ctx context.Context, uuid uuid.UUID) error
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 	"task2/internal/domain/entity"
 
 	"github.com/google/uuid"
@@ -15,14 +16,17 @@ type TaskRepository interface {
 	// Get a task by its UUID
 	GetByUUID(ctx context.Context, uuid uuid.UUID) (*entity.Task, error)
 	
+	// Get the UUID of a task, including a deleted one, by its short key
+	GetUUIDByKey(ctx context.Context, key string) (uuid.UUID, error)
+	
 	// Get all tasks
 	GetAll(ctx context.Context) ([]*entity.Task, error)
 	
 	// Update an existing task
 	Update(ctx context.Context, task *entity.Task) error
 	
-	// Delete a task
-	Delete(ctx context.Context, uuid uuid.UUID) error
+	// Move a task to the trash, unless it changed since it was read
+	Delete(ctx context.Context, task *entity.Task) error
 	
 	// Get tasks created by a specific user
 	GetTasksCreatedByUser(ctx context.Context, userUUID uuid.UUID) ([]*entity.Task, error)
@@ -30,12 +34,51 @@ type TaskRepository interface {
 	// Get tasks assigned to a specific user
 	GetTasksAssignedToUser(ctx context.Context, userUUID uuid.UUID) ([]*entity.Task, error)
 	
-	// Assign a task to a user
-	AssignTaskToUser(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID) error
+	// Assign a task to a user, failing with entity.ErrVersionConflict if the task changed since it was read
+	AssignTaskToUser(ctx context.Context, task *entity.Task, userUUID uuid.UUID) error
 	
 	// Complete a task
 	CompleteTask(ctx context.Context, taskUUID uuid.UUID) error
 	
-	// Add a user to a task
-	AddUserToTask(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID) error
-}
\ No newline at end of file
+	// Add a user to a task, failing with entity.ErrVersionConflict if the task changed since it was read
+	AddUserToTask(ctx context.Context, task *entity.Task, userUUID uuid.UUID) error
+	
+	// Record users as mentioned in a task, ignoring those already recorded
+	AddMentions(ctx context.Context, task *entity.Task, users []*entity.User) error
+	
+	// Remove a user from a task's members
+	RemoveUserFromTask(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID) error
+	
+	// Get up to limit tasks a user created, is assigned to or is a member of, in ID order after afterID
+	GetTasksVisibleToUserAfter(ctx context.Context, userUUID uuid.UUID, afterID int64, limit int) ([]*entity.Task, error)
+	
+	// Check if a user already created a task with the given external ID
+	ExternalIDExists(ctx context.Context, creatorUUID uuid.UUID, externalID string) (bool, error)
+	
+	// Get tasks visible to a user that match a filter, in the given order
+	FindTasksVisibleToUser(ctx context.Context, userUUID uuid.UUID, filter entity.TaskFilter, sort entity.TaskSort) ([]*entity.Task, error)
+	
+	// Get incomplete tasks created by a user that were due before the given time
+	GetOverdueTasksCreatedByUser(ctx context.Context, userUUID uuid.UUID, dueBefore time.Time) ([]*entity.Task, error)
+	
+	// Replace a task's reviewers and save its review settings
+	SetReviewers(ctx context.Context, task *entity.Task) error
+	
+	// Get tasks awaiting approval that a user reviews
+	GetTasksAwaitingReviewBy(ctx context.Context, userUUID uuid.UUID) ([]*entity.Task, error)
+	
+	// Get soft-deleted tasks created by a user
+	GetDeletedTasksCreatedByUser(ctx context.Context, userUUID uuid.UUID) ([]*entity.Task, error)
+	
+	// Get a soft-deleted task by its UUID
+	GetDeletedByUUID(ctx context.Context, uuid uuid.UUID) (*entity.Task, error)
+	
+	// Restore a soft-deleted task
+	Restore(ctx context.Context, task *entity.Task) error
+	
+	// Permanently delete a soft-deleted task and the rows that belong to it
+	Purge(ctx context.Context, task *entity.Task) error
+	
+	// Get up to limit tasks soft-deleted before the given time
+	GetTasksDeletedBefore(ctx context.Context, deletedBefore time.Time, limit int) ([]*entity.Task, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"task2/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// TokenRevocationRepository defines the interface for access token revocation data access
+type TokenRevocationRepository interface {
+	// Revoke an access token; revoking it again does nothing
+	RevokeToken(ctx context.Context, token *entity.RevokedToken) error
+	
+	// Check if an access token is revoked
+	IsTokenRevoked(ctx context.Context, tokenID uuid.UUID) (bool, error)
+	
+	// Revoke every access token issued to a user up to the given time
+	RevokeUserTokens(ctx context.Context, userUUID uuid.UUID, before time.Time) error
+	
+	// Get the time up to which a user's access tokens are revoked, or nil if they never were
+	GetUserTokensRevokedBefore(ctx context.Context, userUUID uuid.UUID) (*time.Time, error)
+	
+	// Delete revoked tokens that expired by the given time, returning how many were deleted
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+)
+
+// Transactor defines the interface for running repository calls in a single transaction
+type Transactor interface {
+	// Run fn within a transaction, committing if it returns nil and rolling back otherwise
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"task2/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// TransferRepository defines the interface for ownership transfer data access
+type TransferRepository interface {
+	// Create a new transfer
+	Create(ctx context.Context, transfer *entity.OwnershipTransfer) error
+	
+	// Get a transfer by its UUID
+	GetByUUID(ctx context.Context, uuid uuid.UUID) (*entity.OwnershipTransfer, error)
+	
+	// Get the pending transfer of a task, if any
+	GetPendingByTask(ctx context.Context, taskUUID uuid.UUID) (*entity.OwnershipTransfer, error)
+	
+	// Get pending transfers a user requested or has to answer
+	GetPendingForUser(ctx context.Context, userUUID uuid.UUID) ([]*entity.OwnershipTransfer, error)
+	
+	// Save a transfer's status
+	Update(ctx context.Context, transfer *entity.OwnershipTransfer) error
+	
+	// Cancel any pending transfer of a task
+	CancelPendingForTask(ctx context.Context, taskUUID uuid.UUID) error
+}
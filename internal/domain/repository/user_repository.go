@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 	"task2/internal/domain/entity"
 
 	"github.com/google/uuid"
@@ -29,4 +30,29 @@ type UserRepository interface {
 	
 	// Check if email exists
 	EmailExists(ctx context.Context, email string) (bool, error)
-}
\ No newline at end of file
+	
+	// Get a user by the hash of their calendar feed token
+	GetByCalendarTokenHash(ctx context.Context, tokenHash string) (*entity.User, error)
+	
+	// Replace the hash of a user's calendar feed token
+	UpdateCalendarTokenHash(ctx context.Context, uuid uuid.UUID, tokenHash string) error
+	
+	// Get the users whose email starts with the given handle followed by @
+	GetByHandle(ctx context.Context, handle string) ([]*entity.User, error)
+	
+	// Mark a user's email as verified if it is still the given address, reporting whether it is
+	MarkEmailVerified(ctx context.Context, uuid uuid.UUID, email string) (bool, error)
+	
+	// Record that a verification email is being sent to an unverified user, unless one was sent after the given time,
+	// reporting whether it should be sent
+	ClaimVerificationEmail(ctx context.Context, uuid uuid.UUID, notSentAfter time.Time) (bool, error)
+	
+	// Count every user ever registered, including deleted ones
+	Count(ctx context.Context) (int, error)
+	
+	// Count the users with the given role who are not suspended
+	CountActiveByRole(ctx context.Context, role entity.Role) (int, error)
+	
+	// Take the lock that serializes role changes; it is held until the surrounding transaction ends
+	LockRoles(ctx context.Context) error
+}
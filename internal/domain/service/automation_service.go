@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// maxExecutionLogEntries is the number of executions returned for a rule
+const maxExecutionLogEntries = 100
+
+// AutomationService provides domain logic for automation rules
+type AutomationService struct {
+	automationRepo repository.AutomationRepository
+}
+
+// NewAutomationService creates a new automation service
+func NewAutomationService(automationRepo repository.AutomationRepository) *AutomationService {
+	return &AutomationService{
+		automationRepo: automationRepo,
+	}
+}
+
+// CreateRule creates a new rule
+func (s *AutomationService) CreateRule(ctx context.Context, rule *entity.AutomationRule) error {
+	return s.automationRepo.Create(ctx, rule)
+}
+
+// GetRule gets one of a user's rules
+func (s *AutomationService) GetRule(ctx context.Context, ruleUUID uuid.UUID, ownerUUID uuid.UUID) (*entity.AutomationRule, error) {
+	rule, err := s.automationRepo.GetByUUID(ctx, ruleUUID)
+	if err != nil || rule.OwnerID != ownerUUID {
+		return nil, errors.New("rule not found")
+	}
+	
+	return rule, nil
+}
+
+// GetRulesForUser gets the rules owned by a user
+func (s *AutomationService) GetRulesForUser(ctx context.Context, ownerUUID uuid.UUID) ([]*entity.AutomationRule, error) {
+	return s.automationRepo.GetByOwner(ctx, ownerUUID)
+}
+
+// UpdateRule validates and saves changes to a rule
+func (s *AutomationService) UpdateRule(ctx context.Context, rule *entity.AutomationRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	
+	rule.UpdatedAt = time.Now()
+	return s.automationRepo.Update(ctx, rule)
+}
+
+// DeleteRule deletes one of a user's rules
+func (s *AutomationService) DeleteRule(ctx context.Context, ruleUUID uuid.UUID, ownerUUID uuid.UUID) error {
+	if _, err := s.GetRule(ctx, ruleUUID, ownerUUID); err != nil {
+		return err
+	}
+	
+	return s.automationRepo.Delete(ctx, ruleUUID)
+}
+
+// GetMatchingRules gets the enabled rules that a task event should run:
+// those owned by the task's creator, for the event's trigger, whose conditions hold
+func (s *AutomationService) GetMatchingRules(ctx context.Context, trigger string, task *entity.Task) ([]*entity.AutomationRule, error) {
+	rules, err := s.automationRepo.GetEnabledByOwnerAndTrigger(ctx, task.CreatedByID, trigger)
+	if err != nil {
+		return nil, err
+	}
+	
+	var matching []*entity.AutomationRule
+	for _, rule := range rules {
+		if rule.Matches(task) {
+			matching = append(matching, rule)
+		}
+	}
+	
+	return matching, nil
+}
+
+// GetEnabledRulesForTrigger gets every enabled rule for a trigger
+func (s *AutomationService) GetEnabledRulesForTrigger(ctx context.Context, trigger string) ([]*entity.AutomationRule, error) {
+	return s.automationRepo.GetEnabledByTrigger(ctx, trigger)
+}
+
+// StartExecution records that a rule is about to run against a task.
+// It returns false if dedupeKey is set and an execution with that key was already recorded.
+func (s *AutomationService) StartExecution(ctx context.Context, rule *entity.AutomationRule, taskUUID uuid.UUID, dedupeKey string) (*entity.AutomationExecution, bool, error) {
+	execution := &entity.AutomationExecution{
+		UUID:      uuid.New(),
+		RuleID:    rule.ID,
+		TaskID:    taskUUID,
+		Trigger:   rule.Trigger,
+		Status:    entity.ExecutionRunning,
+		DedupeKey: dedupeKey,
+		CreatedAt: time.Now(),
+	}
+	
+	started, err := s.automationRepo.CreateExecution(ctx, execution)
+	if err != nil || !started {
+		return nil, false, err
+	}
+	
+	return execution, true, nil
+}
+
+// FinishExecution records the outcome of an execution
+func (s *AutomationService) FinishExecution(ctx context.Context, execution *entity.AutomationExecution, runErr error) error {
+	execution.Status = entity.ExecutionSucceeded
+	execution.Error = ""
+	if runErr != nil {
+		execution.Status = entity.ExecutionFailed
+		execution.Error = runErr.Error()
+	}
+	
+	return s.automationRepo.UpdateExecution(ctx, execution)
+}
+
+// GetExecutions gets the most recent executions of one of a user's rules
+func (s *AutomationService) GetExecutions(ctx context.Context, ruleUUID uuid.UUID, ownerUUID uuid.UUID) ([]*entity.AutomationExecution, error) {
+	rule, err := s.GetRule(ctx, ruleUUID, ownerUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	return s.automationRepo.GetExecutions(ctx, rule.ID, maxExecutionLogEntries)
+}
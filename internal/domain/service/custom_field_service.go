@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// CustomFieldService provides domain logic for custom fields and their values on tasks
+type CustomFieldService struct {
+	customFieldRepo repository.CustomFieldRepository
+	savedViewRepo   repository.SavedViewRepository
+	taskRepo        repository.TaskRepository
+	userRepo        repository.UserRepository
+	transactor      repository.Transactor
+}
+
+// NewCustomFieldService creates a new custom field service
+func NewCustomFieldService(customFieldRepo repository.CustomFieldRepository, savedViewRepo repository.SavedViewRepository, taskRepo repository.TaskRepository, userRepo repository.UserRepository, transactor repository.Transactor) *CustomFieldService {
+	return &CustomFieldService{
+		customFieldRepo: customFieldRepo,
+		savedViewRepo:   savedViewRepo,
+		taskRepo:        taskRepo,
+		userRepo:        userRepo,
+		transactor:      transactor,
+	}
+}
+
+// CreateField creates a new field
+func (s *CustomFieldService) CreateField(ctx context.Context, field *entity.CustomField) error {
+	return s.customFieldRepo.Create(ctx, field)
+}
+
+// GetField gets one of a user's fields
+func (s *CustomFieldService) GetField(ctx context.Context, fieldUUID uuid.UUID, ownerUUID uuid.UUID) (*entity.CustomField, error) {
+	field, err := s.customFieldRepo.GetByUUID(ctx, fieldUUID)
+	if err != nil || field.OwnerID != ownerUUID {
+		return nil, errors.New("custom field not found")
+	}
+	
+	return field, nil
+}
+
+// GetFieldsForUser gets the fields owned by a user
+func (s *CustomFieldService) GetFieldsForUser(ctx context.Context, ownerUUID uuid.UUID) ([]*entity.CustomField, error) {
+	return s.customFieldRepo.GetByOwner(ctx, ownerUUID)
+}
+
+// UpdateField renames one of a user's fields and replaces its options
+func (s *CustomFieldService) UpdateField(ctx context.Context, fieldUUID uuid.UUID, ownerUUID uuid.UUID, name string, options []string) (*entity.CustomField, error) {
+	field, err := s.GetField(ctx, fieldUUID, ownerUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := field.Update(name, options); err != nil {
+		return nil, err
+	}
+	if err := s.customFieldRepo.Update(ctx, field); err != nil {
+		return nil, err
+	}
+	
+	return field, nil
+}
+
+// DeleteField deletes one of a user's fields with its values, and removes it from the views that filter or sort by it
+func (s *CustomFieldService) DeleteField(ctx context.Context, fieldUUID uuid.UUID, ownerUUID uuid.UUID) error {
+	if _, err := s.GetField(ctx, fieldUUID, ownerUUID); err != nil {
+		return err
+	}
+	
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.savedViewRepo.RemoveCustomField(ctx, fieldUUID); err != nil {
+			return err
+		}
+		
+		return s.customFieldRepo.Delete(ctx, fieldUUID)
+	})
+}
+
+// SetValue sets the value of a field on a task the requestor takes part in.
+// The field must belong to the task's creator; value is a decoded JSON value, or nil to clear the field.
+func (s *CustomFieldService) SetValue(ctx context.Context, taskUUID uuid.UUID, fieldUUID uuid.UUID, value any, requestorUUID uuid.UUID) (*entity.Task, error) {
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Get the task and the field
+		task, err := s.taskRepo.GetByUUID(ctx, taskUUID)
+		if err != nil || !task.HasParticipant(requestorUUID) {
+			return errors.New("task not found")
+		}
+		if err := checkVersion(ctx, task); err != nil {
+			return err
+		}
+		
+		field, err := s.customFieldRepo.GetByUUID(ctx, fieldUUID)
+		if err != nil || field.OwnerID != task.CreatedByID {
+			return errors.New("custom field not found")
+		}
+		
+		// Clear or set the value
+		if value == nil {
+			if err := s.customFieldRepo.ClearValue(ctx, task, field); err != nil {
+				return err
+			}
+		} else {
+			parsed, err := field.ParseValue(value)
+			if err != nil {
+				return err
+			}
+			if field.Type == entity.CustomFieldUser {
+				if _, err := s.userRepo.GetByUUID(ctx, uuid.MustParse(parsed.(string))); err != nil {
+					return errors.New("user not found")
+				}
+			}
+			if err := s.customFieldRepo.SetValue(ctx, task, field, parsed); err != nil {
+				return err
+			}
+		}
+		
+		// Bump the task version so that caches and If-Match see the change
+		task.UpdatedAt = time.Now()
+		return s.taskRepo.Update(ctx, task)
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	return s.taskRepo.GetByUUID(ctx, taskUUID)
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"time"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/repository"
+)
+
+// EmailOutboxService provides domain logic for the email outbox
+type EmailOutboxService struct {
+	outboxRepo repository.EmailOutboxRepository
+	transactor repository.Transactor
+}
+
+// NewEmailOutboxService creates a new email outbox service
+func NewEmailOutboxService(outboxRepo repository.EmailOutboxRepository, transactor repository.Transactor) *EmailOutboxService {
+	return &EmailOutboxService{
+		outboxRepo: outboxRepo,
+		transactor: transactor,
+	}
+}
+
+// Enqueue adds an email to the outbox, joining the caller's transaction if there is one
+func (s *EmailOutboxService) Enqueue(ctx context.Context, email *entity.OutboxEmail) error {
+	return s.outboxRepo.Create(ctx, email)
+}
+
+// SendDue claims up to limit emails that are due and passes each to send. The outcome of every
+// attempt is saved in the transaction holding the claim, so an email is sent by one instance at a
+// time and a failed send is retried later. An instance that stops between sending and committing
+// leaves the email unsent, so an email may occasionally be sent twice.
+// It returns the number of emails claimed.
+func (s *EmailOutboxService) SendDue(ctx context.Context, now time.Time, limit int, send func(email *entity.OutboxEmail) error) (int, error) {
+	claimed := 0
+	
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		emails, err := s.outboxRepo.ClaimDue(ctx, now, limit)
+		if err != nil {
+			return err
+		}
+		
+		for _, email := range emails {
+			if err := send(email); err != nil {
+				email.MarkFailed(time.Now(), err)
+			} else {
+				email.MarkSent(time.Now())
+			}
+			
+			if err := s.outboxRepo.UpdateDelivery(ctx, email); err != nil {
+				return err
+			}
+		}
+		
+		claimed = len(emails)
+		return nil
+	})
+	
+	return claimed, err
+}
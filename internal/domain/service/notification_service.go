@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// NotificationService provides domain logic for notifications
+type NotificationService struct {
+	notificationRepo repository.NotificationRepository
+}
+
+// NewNotificationService creates a new notification service
+func NewNotificationService(notificationRepo repository.NotificationRepository) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// CreateNotification creates a new notification
+func (s *NotificationService) CreateNotification(ctx context.Context, notification *entity.Notification) error {
+	return s.notificationRepo.Create(ctx, notification)
+}
+
+// GetNotificationsForUser gets a user's notifications, newest first
+func (s *NotificationService) GetNotificationsForUser(ctx context.Context, userUUID uuid.UUID, unreadOnly bool) ([]*entity.Notification, error) {
+	return s.notificationRepo.GetByUser(ctx, userUUID, unreadOnly)
+}
+
+// MarkRead marks one of a user's notifications as read
+func (s *NotificationService) MarkRead(ctx context.Context, notificationUUID uuid.UUID, userUUID uuid.UUID) (*entity.Notification, error) {
+	// Get the notification
+	notification, err := s.notificationRepo.GetByUUID(ctx, notificationUUID)
+	if err != nil || notification.UserID != userUUID {
+		return nil, errors.New("notification not found")
+	}
+	
+	if notification.IsRead() {
+		return notification, nil
+	}
+	
+	notification.MarkRead()
+	if err := s.notificationRepo.MarkRead(ctx, notification); err != nil {
+		return nil, err
+	}
+	
+	return notification, nil
+}
+
+// MarkAllRead marks all of a user's notifications as read
+func (s *NotificationService) MarkAllRead(ctx context.Context, userUUID uuid.UUID) error {
+	return s.notificationRepo.MarkAllRead(ctx, userUUID)
+}
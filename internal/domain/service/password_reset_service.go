@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/repository"
+)
+
+// PasswordResetService provides domain logic for resetting forgotten passwords
+type PasswordResetService struct {
+	resetTokenRepo repository.PasswordResetTokenRepository
+	userRepo       repository.UserRepository
+	transactor     repository.Transactor
+}
+
+// NewPasswordResetService creates a new password reset service
+func NewPasswordResetService(resetTokenRepo repository.PasswordResetTokenRepository, userRepo repository.UserRepository, transactor repository.Transactor) *PasswordResetService {
+	return &PasswordResetService{
+		resetTokenRepo: resetTokenRepo,
+		userRepo:       userRepo,
+		transactor:     transactor,
+	}
+}
+
+// RequestReset stores the hash of a new reset token for the user with the email, replacing any earlier token.
+// It returns a nil user, and stores nothing, if no user has the email.
+func (s *PasswordResetService) RequestReset(ctx context.Context, email string, tokenHash string) (*entity.User, error) {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, nil
+	}
+	
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.resetTokenRepo.MarkAllUsedForUser(ctx, user.UUID, time.Now()); err != nil {
+			return err
+		}
+		
+		return s.resetTokenRepo.Create(ctx, entity.NewPasswordResetToken(user.UUID, tokenHash))
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	return user, nil
+}
+
+// ResetPassword uses a reset token to replace the user's password with a new hashed password.
+// Every reset token of the user stops working, including the one used.
+func (s *PasswordResetService) ResetPassword(ctx context.Context, tokenHash string, hashedPassword string) (*entity.User, error) {
+	var user *entity.User
+	
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		token, err := s.resetTokenRepo.GetByHashForUpdate(ctx, tokenHash)
+		if err != nil {
+			return errors.New("invalid or expired reset token")
+		}
+		
+		now := time.Now()
+		if !token.IsUsable(now) {
+			return errors.New("invalid or expired reset token")
+		}
+		
+		user, err = s.userRepo.GetByUUID(ctx, token.UserID)
+		if err != nil {
+			return errors.New("invalid or expired reset token")
+		}
+		
+		if err := user.UpdatePassword(hashedPassword); err != nil {
+			return err
+		}
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+		
+		return s.resetTokenRepo.MarkAllUsedForUser(ctx, user.UUID, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	return user, nil
+}
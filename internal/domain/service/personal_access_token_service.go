@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// personalAccessTokenUseInterval is how often the last use of a token is recorded, unless its IP changes
+const personalAccessTokenUseInterval = time.Minute
+
+// PersonalAccessTokenService provides domain logic for personal access tokens
+type PersonalAccessTokenService struct {
+	tokenRepo  repository.PersonalAccessTokenRepository
+	userRepo   repository.UserRepository
+	transactor repository.Transactor
+}
+
+// NewPersonalAccessTokenService creates a new personal access token service
+func NewPersonalAccessTokenService(tokenRepo repository.PersonalAccessTokenRepository, userRepo repository.UserRepository, transactor repository.Transactor) *PersonalAccessTokenService {
+	return &PersonalAccessTokenService{
+		tokenRepo:  tokenRepo,
+		userRepo:   userRepo,
+		transactor: transactor,
+	}
+}
+
+// CreateToken creates a personal access token. Its scopes must be granted by the user's role.
+func (s *PersonalAccessTokenService) CreateToken(ctx context.Context, token *entity.PersonalAccessToken) error {
+	user, err := s.userRepo.GetByUUID(ctx, token.UserID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+
+	for _, scope := range token.Scopes {
+		if !user.Role.Can(scope) {
+			return fmt.Errorf("scope %q is not allowed for your role", scope)
+		}
+	}
+
+	return s.tokenRepo.Create(ctx, token)
+}
+
+// GetTokens gets a user's personal access tokens that are not revoked
+func (s *PersonalAccessTokenService) GetTokens(ctx context.Context, userUUID uuid.UUID) ([]*entity.PersonalAccessToken, error) {
+	return s.tokenRepo.GetByUser(ctx, userUUID)
+}
+
+// RevokeToken revokes one of a user's personal access tokens
+func (s *PersonalAccessTokenService) RevokeToken(ctx context.Context, tokenUUID uuid.UUID, userUUID uuid.UUID) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		token, err := s.tokenRepo.GetByUUIDForUpdate(ctx, tokenUUID)
+		if err != nil || token.UserID != userUUID || token.RevokedAt != nil {
+			return errors.New("token not found")
+		}
+
+		token.Revoke(time.Now())
+		return s.tokenRepo.Update(ctx, token)
+	})
+}
+
+// RotateToken replaces the secret of one of a user's personal access tokens with the hash of a new one.
+// The old secret stops working at once; the name, scopes and expiry are kept.
+func (s *PersonalAccessTokenService) RotateToken(ctx context.Context, tokenUUID uuid.UUID, userUUID uuid.UUID, tokenHash string, hint string) (*entity.PersonalAccessToken, error) {
+	var token *entity.PersonalAccessToken
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		token, err = s.tokenRepo.GetByUUIDForUpdate(ctx, tokenUUID)
+		if err != nil || token.UserID != userUUID || token.RevokedAt != nil {
+			return errors.New("token not found")
+		}
+		if !token.IsUsable(time.Now()) {
+			return errors.New("token has expired")
+		}
+
+		token.Rotate(tokenHash, hint)
+		return s.tokenRepo.Update(ctx, token)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// Authenticate gets the usable personal access token with the hash and records its use from the IP.
+// Tokens of suspended or deleted users do not authenticate.
+func (s *PersonalAccessTokenService) Authenticate(ctx context.Context, tokenHash string, ip string) (*entity.PersonalAccessToken, error) {
+	token, err := s.tokenRepo.GetByHash(ctx, tokenHash)
+	if err != nil {
+		return nil, errors.New("invalid token")
+	}
+
+	now := time.Now()
+	if !token.IsUsable(now) {
+		return nil, errors.New("invalid token")
+	}
+
+	user, err := s.userRepo.GetByUUID(ctx, token.UserID)
+	if err != nil || user.IsSuspended() {
+		return nil, errors.New("invalid token")
+	}
+
+	// Record the use, at most once per interval from the same IP
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= personalAccessTokenUseInterval || token.LastUsedIP != ip {
+		if err := s.tokenRepo.RecordUse(ctx, token.ID, now, ip); err != nil {
+			return nil, err
+		}
+		token.LastUsedAt = &now
+		token.LastUsedIP = ip
+	}
+
+	return token, nil
+}
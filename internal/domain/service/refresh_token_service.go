@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// RefreshTokenService provides domain logic for rotating refresh tokens
+type RefreshTokenService struct {
+	refreshTokenRepo repository.RefreshTokenRepository
+	transactor       repository.Transactor
+	ttl              time.Duration
+}
+
+// NewRefreshTokenService creates a new refresh token service
+func NewRefreshTokenService(refreshTokenRepo repository.RefreshTokenRepository, transactor repository.Transactor) *RefreshTokenService {
+	return &RefreshTokenService{
+		refreshTokenRepo: refreshTokenRepo,
+		transactor:       transactor,
+		ttl:              entity.DefaultRefreshTokenTTL,
+	}
+}
+
+// SetTTL sets how long new refresh tokens can be used
+func (s *RefreshTokenService) SetTTL(ttl time.Duration) {
+	s.ttl = ttl
+}
+
+// IssueRefreshToken starts a new token family for a user with the hash of a fresh secret token
+func (s *RefreshTokenService) IssueRefreshToken(ctx context.Context, userUUID uuid.UUID, tokenHash string) (*entity.RefreshToken, error) {
+	token := entity.NewRefreshToken(userUUID, uuid.New(), tokenHash, s.ttl)
+	
+	if err := s.refreshTokenRepo.Create(ctx, token); err != nil {
+		return nil, err
+	}
+	
+	return token, nil
+}
+
+// RotateRefreshToken exchanges a refresh token for a new one in the same family.
+// A token that was already exchanged is being replayed, so its whole family is revoked.
+func (s *RefreshTokenService) RotateRefreshToken(ctx context.Context, tokenHash string, newTokenHash string) (*entity.RefreshToken, error) {
+	var rotated *entity.RefreshToken
+	reused := false
+	
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		token, err := s.refreshTokenRepo.GetByHashForUpdate(ctx, tokenHash)
+		if err != nil {
+			return errors.New("invalid refresh token")
+		}
+		
+		now := time.Now()
+		if token.UsedAt != nil && token.RevokedAt == nil {
+			// Revoke the family and commit, so neither the thief nor the user can continue the session
+			reused = true
+			return s.refreshTokenRepo.RevokeFamily(ctx, token.FamilyID, now)
+		}
+		if !token.IsUsable(now) {
+			return errors.New("invalid refresh token")
+		}
+		
+		token.MarkUsed(now)
+		if err := s.refreshTokenRepo.MarkUsed(ctx, token.ID, now); err != nil {
+			return err
+		}
+		
+		rotated = entity.NewRefreshToken(token.UserID, token.FamilyID, newTokenHash, s.ttl)
+		return s.refreshTokenRepo.Create(ctx, rotated)
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	if reused {
+		return nil, errors.New("refresh token reuse detected")
+	}
+	
+	return rotated, nil
+}
+
+// RevokeRefreshToken ends the session a refresh token belongs to by revoking its family.
+// Unknown tokens are ignored, as there is nothing to end.
+func (s *RefreshTokenService) RevokeRefreshToken(ctx context.Context, tokenHash string) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		token, err := s.refreshTokenRepo.GetByHashForUpdate(ctx, tokenHash)
+		if err != nil {
+			return nil
+		}
+		
+		return s.refreshTokenRepo.RevokeFamily(ctx, token.FamilyID, time.Now())
+	})
+}
+
+// RevokeSession ends a session by revoking its refresh token family
+func (s *RefreshTokenService) RevokeSession(ctx context.Context, familyID uuid.UUID) error {
+	return s.refreshTokenRepo.RevokeFamily(ctx, familyID, time.Now())
+}
+
+// RevokeAllRefreshTokens ends every session of a user
+func (s *RefreshTokenService) RevokeAllRefreshTokens(ctx context.Context, userUUID uuid.UUID) error {
+	return s.refreshTokenRepo.RevokeAllForUser(ctx, userUUID, time.Now())
+}
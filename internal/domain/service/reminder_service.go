@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// ReminderService provides domain logic for task reminders
+type ReminderService struct {
+	reminderRepo repository.ReminderRepository
+	taskRepo     repository.TaskRepository
+	transactor   repository.Transactor
+}
+
+// NewReminderService creates a new reminder service
+func NewReminderService(reminderRepo repository.ReminderRepository, taskRepo repository.TaskRepository, transactor repository.Transactor) *ReminderService {
+	return &ReminderService{
+		reminderRepo: reminderRepo,
+		taskRepo:     taskRepo,
+		transactor:   transactor,
+	}
+}
+
+// getParticipatingTask gets a task the user takes part in
+func (s *ReminderService) getParticipatingTask(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID) (*entity.Task, error) {
+	task, err := s.taskRepo.GetByUUID(ctx, taskUUID)
+	if err != nil || !task.HasParticipant(userUUID) {
+		return nil, errors.New("task not found")
+	}
+	
+	return task, nil
+}
+
+// CreateReminder creates a reminder on a task the requestor takes part in
+func (s *ReminderService) CreateReminder(ctx context.Context, taskUUID uuid.UUID, requestorUUID uuid.UUID, remindAt *time.Time, minutesBeforeDue *int, recipients []uuid.UUID) (*entity.Reminder, *entity.Task, error) {
+	task, err := s.getParticipatingTask(ctx, taskUUID, requestorUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	reminder, err := entity.NewReminder(task, requestorUUID, remindAt, minutesBeforeDue, recipients)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	if err := s.reminderRepo.Create(ctx, reminder); err != nil {
+		return nil, nil, err
+	}
+	
+	return reminder, task, nil
+}
+
+// GetReminders gets the reminders on a task the requestor takes part in
+func (s *ReminderService) GetReminders(ctx context.Context, taskUUID uuid.UUID, requestorUUID uuid.UUID) ([]*entity.Reminder, *entity.Task, error) {
+	task, err := s.getParticipatingTask(ctx, taskUUID, requestorUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	reminders, err := s.reminderRepo.GetByTask(ctx, taskUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	return reminders, task, nil
+}
+
+// DeleteReminder deletes a reminder; only its creator or the task creator can delete it
+func (s *ReminderService) DeleteReminder(ctx context.Context, taskUUID uuid.UUID, reminderUUID uuid.UUID, requestorUUID uuid.UUID) error {
+	task, err := s.getParticipatingTask(ctx, taskUUID, requestorUUID)
+	if err != nil {
+		return err
+	}
+	
+	reminder, err := s.reminderRepo.GetByUUID(ctx, reminderUUID)
+	if err != nil || reminder.TaskID != taskUUID {
+		return errors.New("reminder not found")
+	}
+	
+	if reminder.CreatedByID != requestorUUID && task.CreatedByID != requestorUUID {
+		return errors.New("you are not authorized to delete this reminder")
+	}
+	
+	return s.reminderRepo.Delete(ctx, reminderUUID)
+}
+
+// DeliverDueReminders claims up to limit due reminders and passes each to deliver together with its task.
+// Claiming, delivery and marking the reminder sent share one transaction, so a reminder is handled
+// by exactly one instance and is retried if deliver fails. It returns the number of reminders delivered.
+func (s *ReminderService) DeliverDueReminders(ctx context.Context, now time.Time, limit int, deliver func(ctx context.Context, reminder *entity.Reminder, task *entity.Task) error) (int, error) {
+	delivered := 0
+	
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		reminders, err := s.reminderRepo.ClaimDue(ctx, now, limit)
+		if err != nil {
+			return err
+		}
+		
+		for _, reminder := range reminders {
+			task, err := s.taskRepo.GetByUUID(ctx, reminder.TaskID)
+			if err != nil {
+				return err
+			}
+			
+			if err := deliver(ctx, reminder, task); err != nil {
+				return err
+			}
+			
+			sentAt := time.Now()
+			reminder.SentAt = &sentAt
+			if err := s.reminderRepo.MarkSent(ctx, reminder); err != nil {
+				return err
+			}
+		}
+		
+		delivered = len(reminders)
+		return nil
+	})
+	
+	return delivered, err
+}
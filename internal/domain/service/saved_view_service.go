@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// SavedViewService provides domain logic for saved views
+type SavedViewService struct {
+	savedViewRepo   repository.SavedViewRepository
+	taskRepo        repository.TaskRepository
+	customFieldRepo repository.CustomFieldRepository
+}
+
+// NewSavedViewService creates a new saved view service
+func NewSavedViewService(savedViewRepo repository.SavedViewRepository, taskRepo repository.TaskRepository, customFieldRepo repository.CustomFieldRepository) *SavedViewService {
+	return &SavedViewService{
+		savedViewRepo:   savedViewRepo,
+		taskRepo:        taskRepo,
+		customFieldRepo: customFieldRepo,
+	}
+}
+
+// CreateView creates a new view
+func (s *SavedViewService) CreateView(ctx context.Context, view *entity.SavedView) error {
+	if err := s.checkCustomFields(ctx, view); err != nil {
+		return err
+	}
+	
+	return s.savedViewRepo.Create(ctx, view)
+}
+
+// GetView gets one of a user's views
+func (s *SavedViewService) GetView(ctx context.Context, viewUUID uuid.UUID, ownerUUID uuid.UUID) (*entity.SavedView, error) {
+	view, err := s.savedViewRepo.GetByUUID(ctx, viewUUID)
+	if err != nil || view.OwnerID != ownerUUID {
+		return nil, errors.New("view not found")
+	}
+	
+	return view, nil
+}
+
+// GetViewsForUser gets the views owned by a user
+func (s *SavedViewService) GetViewsForUser(ctx context.Context, ownerUUID uuid.UUID) ([]*entity.SavedView, error) {
+	return s.savedViewRepo.GetByOwner(ctx, ownerUUID)
+}
+
+// UpdateView validates and saves changes to a view
+func (s *SavedViewService) UpdateView(ctx context.Context, view *entity.SavedView) error {
+	if err := view.Validate(); err != nil {
+		return err
+	}
+	if err := s.checkCustomFields(ctx, view); err != nil {
+		return err
+	}
+	
+	view.UpdatedAt = time.Now()
+	return s.savedViewRepo.Update(ctx, view)
+}
+
+// checkCustomFields checks that the custom fields a view filters and sorts by exist and belong to its owner,
+// and converts its filter values to the fields' types
+func (s *SavedViewService) checkCustomFields(ctx context.Context, view *entity.SavedView) error {
+	return s.checkFilterFields(ctx, view.OwnerID, &view.Filter, view.Sort, (*entity.CustomField).ParseFilterValue)
+}
+
+// checkFilterFields checks that the custom fields a filter and sort use exist and belong to the user,
+// and converts the filter values to the fields' types with parse. Another user's field is reported
+// as not found, so its name and options are not revealed.
+func (s *SavedViewService) checkFilterFields(ctx context.Context, userUUID uuid.UUID, filter *entity.TaskFilter, sort entity.TaskSort, parse func(*entity.CustomField, any) (any, error)) error {
+	for i, fieldFilter := range filter.CustomFields {
+		field, err := s.customFieldRepo.GetByUUID(ctx, fieldFilter.FieldID)
+		if err != nil || field.OwnerID != userUUID {
+			return errors.New("custom field not found")
+		}
+		
+		value, err := parse(field, fieldFilter.Value)
+		if err != nil {
+			return err
+		}
+		filter.CustomFields[i].Value = value
+	}
+	
+	if sort.CustomFieldID != nil {
+		field, err := s.customFieldRepo.GetByUUID(ctx, *sort.CustomFieldID)
+		if err != nil || field.OwnerID != userUUID {
+			return errors.New("custom field not found")
+		}
+		if !field.IsSortable() {
+			return errors.New("cannot sort by a multi-select field")
+		}
+	}
+	
+	return nil
+}
+
+// DeleteView deletes one of a user's views
+func (s *SavedViewService) DeleteView(ctx context.Context, viewUUID uuid.UUID, ownerUUID uuid.UUID) error {
+	if _, err := s.GetView(ctx, viewUUID, ownerUUID); err != nil {
+		return err
+	}
+	
+	return s.savedViewRepo.Delete(ctx, viewUUID)
+}
+
+// PinView makes one of a user's views their default task list
+func (s *SavedViewService) PinView(ctx context.Context, viewUUID uuid.UUID, ownerUUID uuid.UUID) (*entity.SavedView, error) {
+	view, err := s.GetView(ctx, viewUUID, ownerUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := s.savedViewRepo.Pin(ctx, view); err != nil {
+		return nil, err
+	}
+	
+	view.Pinned = true
+	return view, nil
+}
+
+// UnpinView stops one of a user's views being their default task list
+func (s *SavedViewService) UnpinView(ctx context.Context, viewUUID uuid.UUID, ownerUUID uuid.UUID) (*entity.SavedView, error) {
+	view, err := s.GetView(ctx, viewUUID, ownerUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := s.savedViewRepo.Unpin(ctx, view); err != nil {
+		return nil, err
+	}
+	
+	view.Pinned = false
+	return view, nil
+}
+
+// GetPinnedView gets a user's pinned view, or nil if they have not pinned one
+func (s *SavedViewService) GetPinnedView(ctx context.Context, ownerUUID uuid.UUID) (*entity.SavedView, error) {
+	views, err := s.savedViewRepo.GetByOwner(ctx, ownerUUID)
+	if err != nil {
+		return nil, err
+	}
+	
+	for _, view := range views {
+		if view.Pinned {
+			return view, nil
+		}
+	}
+	
+	return nil, nil
+}
+
+// FindTasks gets the tasks visible to a user that match a filter, in the given order, as a task list does
+// without a saved view. Custom field values in the filter are text, such as from a query string.
+// Errors in the filter or sort wrap entity.ErrInvalidTaskQuery.
+func (s *SavedViewService) FindTasks(ctx context.Context, userUUID uuid.UUID, filter entity.TaskFilter, sort entity.TaskSort) ([]*entity.Task, error) {
+	if err := s.checkTaskQuery(ctx, userUUID, &filter, &sort); err != nil {
+		return nil, fmt.Errorf("%w: %w", entity.ErrInvalidTaskQuery, err)
+	}
+	
+	return s.taskRepo.FindTasksVisibleToUser(ctx, userUUID, filter, sort)
+}
+
+// checkTaskQuery checks a task list's filter and sort, converting its custom field values from text
+func (s *SavedViewService) checkTaskQuery(ctx context.Context, userUUID uuid.UUID, filter *entity.TaskFilter, sort *entity.TaskSort) error {
+	if err := sort.Validate(); err != nil {
+		return err
+	}
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+	
+	return s.checkFilterFields(ctx, userUUID, filter, *sort, func(field *entity.CustomField, value any) (any, error) {
+		text, _ := value.(string)
+		return field.ParseFilterText(text)
+	})
+}
+
+// ExecuteView gets the tasks a view selects, limited to those visible to its owner
+func (s *SavedViewService) ExecuteView(ctx context.Context, view *entity.SavedView) ([]*entity.Task, error) {
+	return s.taskRepo.FindTasksVisibleToUser(ctx, view.OwnerID, view.Filter, view.Sort)
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// SnoozeService provides domain logic for snoozing tasks
+type SnoozeService struct {
+	snoozeRepo repository.SnoozeRepository
+	taskRepo   repository.TaskRepository
+	transactor repository.Transactor
+}
+
+// NewSnoozeService creates a new snooze service
+func NewSnoozeService(snoozeRepo repository.SnoozeRepository, taskRepo repository.TaskRepository, transactor repository.Transactor) *SnoozeService {
+	return &SnoozeService{
+		snoozeRepo: snoozeRepo,
+		taskRepo:   taskRepo,
+		transactor: transactor,
+	}
+}
+
+// SnoozeTask hides a task the user takes part in from their assigned tasks until the given time
+func (s *SnoozeService) SnoozeTask(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID, until time.Time) (*entity.Snooze, error) {
+	task, err := s.taskRepo.GetByUUID(ctx, taskUUID)
+	if err != nil {
+		return nil, errors.New("task not found")
+	}
+	
+	snooze, err := entity.NewSnooze(task, userUUID, until)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := s.snoozeRepo.Save(ctx, snooze); err != nil {
+		return nil, err
+	}
+	
+	return snooze, nil
+}
+
+// UnsnoozeTask ends the user's snooze of a task straight away
+func (s *SnoozeService) UnsnoozeTask(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID) error {
+	deleted, err := s.snoozeRepo.Delete(ctx, taskUUID, userUUID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return errors.New("task is not snoozed")
+	}
+	
+	return nil
+}
+
+// GetActiveSnoozes gets when each task the user has snoozed wakes, by task UUID
+func (s *SnoozeService) GetActiveSnoozes(ctx context.Context, userUUID uuid.UUID, now time.Time) (map[uuid.UUID]time.Time, error) {
+	snoozes, err := s.snoozeRepo.GetActiveByUser(ctx, userUUID, now)
+	if err != nil {
+		return nil, err
+	}
+	
+	until := make(map[uuid.UUID]time.Time, len(snoozes))
+	for _, snooze := range snoozes {
+		until[snooze.TaskID] = snooze.Until
+	}
+	
+	return until, nil
+}
+
+// WakeDueSnoozes ends up to limit snoozes that ended by now, calling wake for each one on a task
+// that is still open. Snoozes are claimed, woken and deleted in one transaction, so a snooze is
+// woken once even when several instances run. It returns the number of snoozes ended.
+func (s *SnoozeService) WakeDueSnoozes(ctx context.Context, now time.Time, limit int, wake func(ctx context.Context, snooze *entity.Snooze, task *entity.Task) error) (int, error) {
+	woken := 0
+	
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		snoozes, err := s.snoozeRepo.ClaimDue(ctx, now, limit)
+		if err != nil {
+			return err
+		}
+		
+		for _, snooze := range snoozes {
+			task, err := s.taskRepo.GetByUUID(ctx, snooze.TaskID)
+			if err != nil {
+				return err
+			}
+			
+			// Completed tasks end their snoozes quietly
+			if !task.Completed {
+				if err := wake(ctx, snooze, task); err != nil {
+					return err
+				}
+			}
+			
+			if _, err := s.snoozeRepo.Delete(ctx, snooze.TaskID, snooze.UserID); err != nil {
+				return err
+			}
+		}
+		
+		woken = len(snoozes)
+		return nil
+	})
+	
+	return woken, err
+}
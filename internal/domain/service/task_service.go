@@ -3,24 +3,78 @@ package service
 import (
 	"context"
 	"errors"
+	"slices"
+	"time"
 	"task2/internal/domain/entity"
 	"task2/internal/domain/repository"
+	"task2/pkg/utils"
 
 	"github.com/google/uuid"
 )
 
+// TaskEventHandler handles an event published by the task service
+type TaskEventHandler func(ctx context.Context, event entity.TaskEvent)
+
 // TaskService provides domain logic for tasks
 type TaskService struct {
-	taskRepo repository.TaskRepository
-	userRepo repository.UserRepository
+	taskRepo     repository.TaskRepository
+	userRepo     repository.UserRepository
+	approvalRepo repository.ApprovalRepository
+	linkRepo     repository.TaskLinkRepository
+	transactor   repository.Transactor
+	handlers     []TaskEventHandler
 }
 
 // NewTaskService creates a new task service
-func NewTaskService(taskRepo repository.TaskRepository, userRepo repository.UserRepository) *TaskService {
+func NewTaskService(taskRepo repository.TaskRepository, userRepo repository.UserRepository, approvalRepo repository.ApprovalRepository, linkRepo repository.TaskLinkRepository, transactor repository.Transactor) *TaskService {
 	return &TaskService{
-		taskRepo: taskRepo,
-		userRepo: userRepo,
+		taskRepo:     taskRepo,
+		userRepo:     userRepo,
+		approvalRepo: approvalRepo,
+		linkRepo:     linkRepo,
+		transactor:   transactor,
+	}
+}
+
+// pendingEventsKey is the context key for the events raised inside a task service transaction.
+// They are held back until the transaction commits, and dropped if it rolls back.
+type pendingEventsKey struct{}
+
+// Subscribe registers a handler for task events.
+// Handlers run synchronously with the caller's context, after the change that raised the event
+// is committed; events raised inside WithinTransaction wait for the outermost transaction.
+func (s *TaskService) Subscribe(handler TaskEventHandler) {
+	s.handlers = append(s.handlers, handler)
+}
+
+// publish passes an event to every subscribed handler, or holds it back until the surrounding transaction commits
+func (s *TaskService) publish(ctx context.Context, event entity.TaskEvent) {
+	if pending, ok := ctx.Value(pendingEventsKey{}).(*[]entity.TaskEvent); ok {
+		*pending = append(*pending, event)
+		return
+	}
+	
+	for _, handler := range s.handlers {
+		handler(ctx, event)
+	}
+}
+
+// WithinTransaction runs fn in a single transaction so that several task operations succeed or fail together.
+// Events raised by fn are published once the transaction commits.
+func (s *TaskService) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	var pending []entity.TaskEvent
+	
+	err := s.transactor.WithinTransaction(context.WithValue(ctx, pendingEventsKey{}, &pending), fn)
+	if err != nil {
+		return err
+	}
+	
+	// A nested transaction hands its events to the enclosing one, which may still roll back
+	for _, event := range pending {
+		s.publish(ctx, event)
 	}
+	
+	return nil
 }
 
 // CreateTask creates a new task
@@ -32,7 +86,57 @@ func (s *TaskService) CreateTask(ctx context.Context, task *entity.Task) error {
 	}
 	
 	task.CreatedBy = creator
-	return s.taskRepo.Create(ctx, task)
+	if err := s.taskRepo.Create(ctx, task); err != nil {
+		return err
+	}
+	
+	s.publish(ctx, entity.NewTaskEvent(entity.TaskEventCreated, task.UUID, task.CreatedByID))
+	return nil
+}
+
+// RecordMentions records the users mentioned in a task's description who take part in the task.
+// Call it once the task's assignee and members are set. Other mentions stay plain text, so a mention
+// cannot reveal or notify anyone about a task they cannot see.
+func (s *TaskService) RecordMentions(ctx context.Context, task *entity.Task) error {
+	task.Mentions = s.resolveMentions(ctx, task)
+	if len(task.Mentions) == 0 {
+		return nil
+	}
+	
+	return s.taskRepo.AddMentions(ctx, task, task.Mentions)
+}
+
+// resolveMentions returns the participants of a task mentioned in its description.
+// Unknown and ambiguous handles, and users outside the task, are left as plain text.
+func (s *TaskService) resolveMentions(ctx context.Context, task *entity.Task) []*entity.User {
+	var users []*entity.User
+	seen := make(map[uuid.UUID]bool)
+	
+	for _, mention := range entity.ExtractMentions(task.Description) {
+		user := s.ResolveMention(ctx, mention)
+		if user == nil || seen[user.UUID] || !task.HasParticipant(user.UUID) {
+			continue
+		}
+		
+		seen[user.UUID] = true
+		users = append(users, user)
+	}
+	
+	return users
+}
+
+// ResolveMention returns the user a handle or email mention refers to, or nil if it is unknown or ambiguous
+func (s *TaskService) ResolveMention(ctx context.Context, mention string) *entity.User {
+	if entity.IsEmailMention(mention) {
+		user, _ := s.userRepo.GetByEmail(ctx, mention)
+		return user
+	}
+	
+	if matches, err := s.userRepo.GetByHandle(ctx, mention); err == nil && len(matches) == 1 {
+		return matches[0]
+	}
+	
+	return nil
 }
 
 // GetTaskByUUID gets a task by UUID
@@ -40,6 +144,16 @@ func (s *TaskService) GetTaskByUUID(ctx context.Context, taskUUID uuid.UUID) (*e
 	return s.taskRepo.GetByUUID(ctx, taskUUID)
 }
 
+// ResolveTaskKey gets the UUID of the task with a short key such as OPS-123
+func (s *TaskService) ResolveTaskKey(ctx context.Context, key string) (uuid.UUID, error) {
+	taskUUID, err := s.taskRepo.GetUUIDByKey(ctx, key)
+	if err != nil {
+		return uuid.Nil, errors.New("task not found")
+	}
+	
+	return taskUUID, nil
+}
+
 // GetAllTasks gets all tasks
 func (s *TaskService) GetAllTasks(ctx context.Context) ([]*entity.Task, error) {
 	return s.taskRepo.GetAll(ctx)
@@ -55,6 +169,45 @@ func (s *TaskService) GetTasksAssignedToUser(ctx context.Context, userUUID uuid.
 	return s.taskRepo.GetTasksAssignedToUser(ctx, userUUID)
 }
 
+// GetTasksVisibleToUserAfter gets up to limit tasks a user created, is assigned to or is a member of,
+// in ID order starting after afterID
+func (s *TaskService) GetTasksVisibleToUserAfter(ctx context.Context, userUUID uuid.UUID, afterID int64, limit int) ([]*entity.Task, error) {
+	return s.taskRepo.GetTasksVisibleToUserAfter(ctx, userUUID, afterID, limit)
+}
+
+// ExternalIDExists checks if a user already created a task with the given external ID
+func (s *TaskService) ExternalIDExists(ctx context.Context, creatorUUID uuid.UUID, externalID string) (bool, error) {
+	return s.taskRepo.ExternalIDExists(ctx, creatorUUID, externalID)
+}
+
+// AddUserToTask adds a user to a task without changing its assignee
+func (s *TaskService) AddUserToTask(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID, requestorUUID uuid.UUID) error {
+	// Get the task
+	task, err := s.taskRepo.GetByUUID(ctx, taskUUID)
+	if err != nil {
+		return errors.New("task not found")
+	}
+	
+	// Check if requestor is authorized to add users
+	if task.CreatedByID != requestorUUID {
+		return errors.New("only the task creator can add users")
+	}
+	
+	// Check the caller's expected version
+	if err := checkVersion(ctx, task); err != nil {
+		return err
+	}
+	
+	// Check if user exists
+	_, err = s.userRepo.GetByUUID(ctx, userUUID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	
+	// Add the user
+	return s.taskRepo.AddUserToTask(ctx, task, userUUID)
+}
+
 // AssignTask assigns a task to a user
 func (s *TaskService) AssignTask(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID, requestorUUID uuid.UUID) error {
 	// Get the task
@@ -68,6 +221,11 @@ func (s *TaskService) AssignTask(ctx context.Context, taskUUID uuid.UUID, userUU
 		return errors.New("only the task creator can assign users")
 	}
 	
+	// Check the caller's expected version
+	if err := checkVersion(ctx, task); err != nil {
+		return err
+	}
+	
 	// Check if user exists
 	_, err = s.userRepo.GetByUUID(ctx, userUUID)
 	if err != nil {
@@ -75,10 +233,16 @@ func (s *TaskService) AssignTask(ctx context.Context, taskUUID uuid.UUID, userUU
 	}
 	
 	// Assign the task
-	return s.taskRepo.AssignTaskToUser(ctx, taskUUID, userUUID)
+	if err := s.taskRepo.AssignTaskToUser(ctx, task, userUUID); err != nil {
+		return err
+	}
+	
+	s.publish(ctx, entity.NewTaskEvent(entity.TaskEventAssigned, taskUUID, requestorUUID))
+	return nil
 }
 
-// CompleteTask marks a task as completed
+// CompleteTask marks a task as completed.
+// A task with reviewers moves to awaiting approval instead and completes once enough reviewers approve it.
 func (s *TaskService) CompleteTask(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID) error {
 	// Get the task
 	task, err := s.taskRepo.GetByUUID(ctx, taskUUID)
@@ -91,12 +255,168 @@ func (s *TaskService) CompleteTask(ctx context.Context, taskUUID uuid.UUID, user
 		return errors.New("you are not authorized to complete this task")
 	}
 	
+	// Check the caller's expected version
+	if err := checkVersion(ctx, task); err != nil {
+		return err
+	}
+	
+	// Submit the task for approval if it needs it; a reviewer cannot submit work they would approve
+	if task.RequiresApproval() {
+		if task.IsReviewer(userUUID) {
+			return errors.New("reviewers cannot submit a task they review")
+		}
+		if err := task.SubmitForApproval(); err != nil {
+			return err
+		}
+		return s.taskRepo.Update(ctx, task)
+	}
+	
 	// Complete the task
 	if err := task.Complete(); err != nil {
 		return err
 	}
 	
-	return s.taskRepo.Update(ctx, task)
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return err
+	}
+	
+	s.publish(ctx, entity.NewTaskEvent(entity.TaskEventCompleted, taskUUID, userUUID))
+	return nil
+}
+
+// SetReviewers sets who must approve a task before it completes; only the task creator can set them.
+// requiredApprovals of zero means every reviewer must approve, and no reviewers turns review off.
+func (s *TaskService) SetReviewers(ctx context.Context, taskUUID uuid.UUID, reviewerUUIDs []uuid.UUID, requiredApprovals int, requestorUUID uuid.UUID) (*entity.Task, error) {
+	// Get the task
+	task, err := s.taskRepo.GetByUUID(ctx, taskUUID)
+	if err != nil {
+		return nil, errors.New("task not found")
+	}
+	
+	// Check if requestor is authorized to set reviewers
+	if task.CreatedByID != requestorUUID {
+		return nil, errors.New("only the task creator can set reviewers")
+	}
+	
+	// Check the caller's expected version
+	if err := checkVersion(ctx, task); err != nil {
+		return nil, err
+	}
+	
+	// Resolve reviewers
+	var reviewers []*entity.User
+	seen := make(map[uuid.UUID]bool)
+	for _, reviewerUUID := range reviewerUUIDs {
+		if seen[reviewerUUID] {
+			continue
+		}
+		reviewer, err := s.userRepo.GetByUUID(ctx, reviewerUUID)
+		if err != nil {
+			return nil, errors.New("user not found")
+		}
+		seen[reviewerUUID] = true
+		reviewers = append(reviewers, reviewer)
+	}
+	
+	if err := task.SetReviewers(reviewers, requiredApprovals); err != nil {
+		return nil, err
+	}
+	
+	if err := s.taskRepo.SetReviewers(ctx, task); err != nil {
+		return nil, err
+	}
+	
+	return s.taskRepo.GetByUUID(ctx, taskUUID)
+}
+
+// ReviewTask records a reviewer approving or rejecting a task awaiting approval.
+// A rejection returns the task to in progress; the approval that reaches the required count completes it.
+// Every decision updates the task, so concurrent decisions cannot both miss the required count.
+func (s *TaskService) ReviewTask(ctx context.Context, taskUUID uuid.UUID, reviewerUUID uuid.UUID, decision string, comment string) (*entity.Task, error) {
+	err := s.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Get the task
+		task, err := s.taskRepo.GetByUUID(ctx, taskUUID)
+		if err != nil {
+			return errors.New("task not found")
+		}
+		
+		if !task.IsReviewer(reviewerUUID) {
+			return errors.New("only reviewers can approve or reject this task")
+		}
+		// The task may have been reassigned or transferred to a reviewer since reviewers were set
+		if !task.CanReview(reviewerUUID) {
+			return errors.New("you cannot review your own task")
+		}
+		if !task.AwaitingApproval {
+			return errors.New("task is not awaiting approval")
+		}
+		
+		// Check the reviewer has not decided in this round yet
+		decisions, err := s.approvalRepo.GetByTaskAndRound(ctx, taskUUID, task.ApprovalRound)
+		if err != nil {
+			return err
+		}
+		approvals := 0
+		for _, previous := range decisions {
+			if previous.ReviewerID == reviewerUUID {
+				return errors.New("you have already reviewed this task")
+			}
+			if previous.Decision == entity.ApprovalDecisionApproved {
+				approvals++
+			}
+		}
+		
+		// Record the decision
+		approval, err := entity.NewApprovalDecision(task, reviewerUUID, decision, comment)
+		if err != nil {
+			return err
+		}
+		if err := s.approvalRepo.Create(ctx, approval); err != nil {
+			return err
+		}
+		
+		completed := false
+		switch {
+		case decision == entity.ApprovalDecisionRejected:
+			task.Reject()
+		case approvals+1 >= task.RequiredApprovals:
+			if err := task.Approve(); err != nil {
+				return err
+			}
+			completed = true
+		default:
+			task.UpdatedAt = time.Now()
+		}
+		
+		if err := s.taskRepo.Update(ctx, task); err != nil {
+			return err
+		}
+		
+		if completed {
+			s.publish(ctx, entity.NewTaskEvent(entity.TaskEventCompleted, taskUUID, reviewerUUID))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	return s.taskRepo.GetByUUID(ctx, taskUUID)
+}
+
+// GetApprovals gets every review decision on a task the user takes part in
+func (s *TaskService) GetApprovals(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID) ([]*entity.ApprovalDecision, error) {
+	task, err := s.taskRepo.GetByUUID(ctx, taskUUID)
+	if err != nil || !task.HasParticipant(userUUID) {
+		return nil, errors.New("task not found")
+	}
+	
+	return s.approvalRepo.GetByTask(ctx, taskUUID)
+}
+
+// GetTasksAwaitingReviewBy gets tasks awaiting approval that a user reviews
+func (s *TaskService) GetTasksAwaitingReviewBy(ctx context.Context, userUUID uuid.UUID) ([]*entity.Task, error) {
+	return s.taskRepo.GetTasksAwaitingReviewBy(ctx, userUUID)
 }
 
 // DeleteTask deletes a task
@@ -112,6 +432,246 @@ func (s *TaskService) DeleteTask(ctx context.Context, taskUUID uuid.UUID, userUU
 		return errors.New("only the task creator can delete the task")
 	}
 	
-	// Delete the task
-	return s.taskRepo.Delete(ctx, taskUUID)
-}
\ No newline at end of file
+	// Check the caller's expected version
+	if err := checkVersion(ctx, task); err != nil {
+		return err
+	}
+	
+	// Delete the task, unless it changed since it was read
+	return s.taskRepo.Delete(ctx, task)
+}
+
+// GetTrash gets the deleted tasks a user created
+func (s *TaskService) GetTrash(ctx context.Context, userUUID uuid.UUID) ([]*entity.Task, error) {
+	return s.taskRepo.GetDeletedTasksCreatedByUser(ctx, userUUID)
+}
+
+// RestoreTask restores a deleted task; only its creator can restore it
+func (s *TaskService) RestoreTask(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID) (*entity.Task, error) {
+	// Get the deleted task
+	task, err := s.taskRepo.GetDeletedByUUID(ctx, taskUUID)
+	if err != nil || task.CreatedByID != userUUID {
+		return nil, errors.New("task not found in trash")
+	}
+	
+	// Check the caller's expected version
+	if err := checkVersion(ctx, task); err != nil {
+		return nil, err
+	}
+	
+	task.UpdatedAt = time.Now()
+	if err := s.taskRepo.Restore(ctx, task); err != nil {
+		return nil, err
+	}
+	
+	return s.taskRepo.GetByUUID(ctx, taskUUID)
+}
+
+// PurgeTask permanently deletes a deleted task; only its creator can purge it
+func (s *TaskService) PurgeTask(ctx context.Context, taskUUID uuid.UUID, userUUID uuid.UUID) error {
+	// Get the deleted task
+	task, err := s.taskRepo.GetDeletedByUUID(ctx, taskUUID)
+	if err != nil || task.CreatedByID != userUUID {
+		return errors.New("task not found in trash")
+	}
+	
+	return s.taskRepo.Purge(ctx, task)
+}
+
+// PurgeExpiredTasks permanently deletes up to limit tasks deleted before the given time.
+// It returns the number of tasks purged.
+func (s *TaskService) PurgeExpiredTasks(ctx context.Context, deletedBefore time.Time, limit int) (int, error) {
+	tasks, err := s.taskRepo.GetTasksDeletedBefore(ctx, deletedBefore, limit)
+	if err != nil {
+		return 0, err
+	}
+	
+	for i, task := range tasks {
+		if err := s.taskRepo.Purge(ctx, task); err != nil {
+			return i, err
+		}
+	}
+	
+	return len(tasks), nil
+}
+
+// DuplicateTask copies a task the requestor takes part in into a new task created by the requestor.
+// With includeMembers the copy keeps the original's members and assignee. The copy is created in a
+// single transaction, so a failure leaves no partial task behind.
+// There is no move counterpart: tasks have no projects, and owners change through TransferService.
+func (s *TaskService) DuplicateTask(ctx context.Context, taskUUID uuid.UUID, requestorUUID uuid.UUID, includeMembers bool) (*entity.Task, error) {
+	var duplicate *entity.Task
+	
+	err := s.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Get the task
+		task, err := s.taskRepo.GetByUUID(ctx, taskUUID)
+		if err != nil || !task.HasParticipant(requestorUUID) {
+			return errors.New("task not found")
+		}
+		
+		// Create the copy
+		duplicate, err = entity.NewTask(task.Title, task.Description, requestorUUID)
+		if err != nil {
+			return err
+		}
+		duplicate.DueDate = task.DueDate
+		
+		if err := s.CreateTask(ctx, duplicate); err != nil {
+			return err
+		}
+		
+		if includeMembers {
+			if err := s.copyMembers(ctx, task, duplicate, requestorUUID); err != nil {
+				return err
+			}
+		}
+		
+		// Mentions are recorded once the copy's members are known
+		copied, err := s.taskRepo.GetByUUID(ctx, duplicate.UUID)
+		if err != nil {
+			return err
+		}
+		return s.RecordMentions(ctx, copied)
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	return s.taskRepo.GetByUUID(ctx, duplicate.UUID)
+}
+
+// copyMembers adds a task's members and assignee to its duplicate
+func (s *TaskService) copyMembers(ctx context.Context, task *entity.Task, duplicate *entity.Task, requestorUUID uuid.UUID) error {
+	// Any If-Match precondition applies to the original task, not the copy
+	ctx = utils.WithExpectedVersions(ctx, nil)
+	
+	for _, user := range task.Users {
+		if user.UUID == requestorUUID {
+			continue
+		}
+		if err := s.AddUserToTask(ctx, duplicate.UUID, user.UUID, requestorUUID); err != nil {
+			return err
+		}
+	}
+	
+	if task.AssignedToID != nil {
+		return s.AssignTask(ctx, duplicate.UUID, *task.AssignedToID, requestorUUID)
+	}
+	
+	return nil
+}
+
+// LinkTasks links a task to another task, both of which the requestor takes part in.
+// With closeDuplicate, a duplicates link also completes the duplicate task and moves its
+// members onto the original; the requestor must be able to modify both tasks.
+func (s *TaskService) LinkTasks(ctx context.Context, taskUUID uuid.UUID, targetUUID uuid.UUID, linkType string, requestorUUID uuid.UUID, closeDuplicate bool) (*entity.Task, error) {
+	err := s.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Get the tasks
+		task, err := s.taskRepo.GetByUUID(ctx, taskUUID)
+		if err != nil || !task.HasParticipant(requestorUUID) {
+			return errors.New("task not found")
+		}
+		
+		target, err := s.taskRepo.GetByUUID(ctx, targetUUID)
+		if err != nil || !target.HasParticipant(requestorUUID) {
+			return errors.New("linked task not found")
+		}
+		
+		// Create the link
+		if task.FindLink(linkType, targetUUID) != nil {
+			return errors.New("tasks are already linked")
+		}
+		
+		link, err := entity.NewTaskLink(task, target, linkType, requestorUUID)
+		if err != nil {
+			return err
+		}
+		
+		if err := s.linkRepo.Create(ctx, link); err != nil {
+			return err
+		}
+		
+		if !closeDuplicate {
+			return nil
+		}
+		if link.Type != entity.TaskLinkDuplicates {
+			return errors.New("only duplicate links can close a task")
+		}
+		
+		// The link is stored as duplicate -> original
+		duplicate, original := task, target
+		if link.SourceTaskID != task.UUID {
+			duplicate, original = target, task
+		}
+		
+		if !duplicate.CanBeModifiedBy(requestorUUID) || !original.CanBeModifiedBy(requestorUUID) {
+			return errors.New("you are not authorized to close this duplicate")
+		}
+		
+		// Close the duplicate
+		if !duplicate.Completed {
+			if err := duplicate.CloseAsDuplicate(); err != nil {
+				return err
+			}
+			if err := s.taskRepo.Update(ctx, duplicate); err != nil {
+				return err
+			}
+			s.publish(ctx, entity.NewTaskEvent(entity.TaskEventCompleted, duplicate.UUID, requestorUUID))
+		}
+		
+		// Move its members onto the original
+		for _, user := range duplicate.Users {
+			if !original.CanBeModifiedBy(user.UUID) {
+				if err := s.taskRepo.AddUserToTask(ctx, original, user.UUID); err != nil {
+					return err
+				}
+			}
+			if err := s.taskRepo.RemoveUserFromTask(ctx, duplicate.UUID, user.UUID); err != nil {
+				return err
+			}
+		}
+		
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	return s.taskRepo.GetByUUID(ctx, taskUUID)
+}
+
+// UnlinkTasks deletes a link from a task the requestor takes part in
+func (s *TaskService) UnlinkTasks(ctx context.Context, taskUUID uuid.UUID, linkUUID uuid.UUID, requestorUUID uuid.UUID) (*entity.Task, error) {
+	// Get the task
+	task, err := s.taskRepo.GetByUUID(ctx, taskUUID)
+	if err != nil || !task.HasParticipant(requestorUUID) {
+		return nil, errors.New("task not found")
+	}
+	
+	// Find the link among the task's links
+	for _, link := range task.Links {
+		if link.UUID == linkUUID {
+			if err := s.linkRepo.Delete(ctx, linkUUID); err != nil {
+				return nil, err
+			}
+			return s.taskRepo.GetByUUID(ctx, taskUUID)
+		}
+	}
+	
+	return nil, errors.New("link not found")
+}
+
+// GetOverdueTasksCreatedByUser gets incomplete tasks created by a user that were due before the given time
+func (s *TaskService) GetOverdueTasksCreatedByUser(ctx context.Context, userUUID uuid.UUID, dueBefore time.Time) ([]*entity.Task, error) {
+	return s.taskRepo.GetOverdueTasksCreatedByUser(ctx, userUUID, dueBefore)
+}
+
+// checkVersion enforces an If-Match precondition carried by the context
+func checkVersion(ctx context.Context, task *entity.Task) error {
+	versions, ok := utils.GetExpectedVersionsFromContext(ctx)
+	if ok && !slices.Contains(versions, task.Version) {
+		return entity.ErrVersionConflict
+	}
+	
+	return nil
+}
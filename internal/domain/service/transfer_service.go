@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// TransferService provides domain logic for transferring task ownership
+type TransferService struct {
+	transferRepo repository.TransferRepository
+	taskRepo     repository.TaskRepository
+	userRepo     repository.UserRepository
+	transactor   repository.Transactor
+}
+
+// NewTransferService creates a new ownership transfer service
+func NewTransferService(transferRepo repository.TransferRepository, taskRepo repository.TaskRepository, userRepo repository.UserRepository, transactor repository.Transactor) *TransferService {
+	return &TransferService{
+		transferRepo: transferRepo,
+		taskRepo:     taskRepo,
+		userRepo:     userRepo,
+		transactor:   transactor,
+	}
+}
+
+// hasPermission checks if a user's role grants a permission
+func (s *TransferService) hasPermission(ctx context.Context, userUUID uuid.UUID, permission entity.Permission) bool {
+	user, err := s.userRepo.GetByUUID(ctx, userUUID)
+	return err == nil && user.Can(permission)
+}
+
+// RequestTransfer asks a user to take over a task. Only the task creator can ask.
+// With force, an admin or manager transfers the task immediately without the new owner's consent.
+func (s *TransferService) RequestTransfer(ctx context.Context, taskUUID uuid.UUID, toUserUUID uuid.UUID, requestorUUID uuid.UUID, force bool) (*entity.OwnershipTransfer, error) {
+	var transfer *entity.OwnershipTransfer
+	
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Get the task
+		task, err := s.taskRepo.GetByUUID(ctx, taskUUID)
+		if err != nil {
+			return errors.New("task not found")
+		}
+		
+		// Check if requestor is authorized to transfer the task
+		transferAny := s.hasPermission(ctx, requestorUUID, entity.PermissionTasksTransferAny)
+		if force && !transferAny {
+			return errors.New("only admins and managers can transfer a task without consent")
+		}
+		if task.CreatedByID != requestorUUID && !transferAny {
+			return errors.New("only the task creator can transfer the task")
+		}
+		
+		// Check if the new owner exists
+		if _, err := s.userRepo.GetByUUID(ctx, toUserUUID); err != nil {
+			return errors.New("user not found")
+		}
+		
+		transfer, err = entity.NewOwnershipTransfer(task, toUserUUID, requestorUUID)
+		if err != nil {
+			return err
+		}
+		
+		if force {
+			// Check the caller's expected version
+			if err := checkVersion(ctx, task); err != nil {
+				return err
+			}
+			if err := transfer.Resolve(entity.TransferStatusAccepted); err != nil {
+				return err
+			}
+			if err := s.applyTransfer(ctx, task, toUserUUID); err != nil {
+				return err
+			}
+			return s.transferRepo.Create(ctx, transfer)
+		}
+		
+		// Allow one open request per task
+		pending, err := s.transferRepo.GetPendingByTask(ctx, taskUUID)
+		if err != nil {
+			return err
+		}
+		if pending != nil {
+			return errors.New("task already has a pending transfer")
+		}
+		
+		return s.transferRepo.Create(ctx, transfer)
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	return transfer, nil
+}
+
+// RespondToTransfer accepts or declines a transfer addressed to the user
+func (s *TransferService) RespondToTransfer(ctx context.Context, transferUUID uuid.UUID, userUUID uuid.UUID, accept bool) (*entity.OwnershipTransfer, error) {
+	var transfer *entity.OwnershipTransfer
+	
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		transfer, err = s.transferRepo.GetByUUID(ctx, transferUUID)
+		if err != nil || transfer.ToUserID != userUUID {
+			return errors.New("transfer not found")
+		}
+		
+		if !accept {
+			if err := transfer.Resolve(entity.TransferStatusDeclined); err != nil {
+				return err
+			}
+			return s.transferRepo.Update(ctx, transfer)
+		}
+		
+		if err := transfer.Resolve(entity.TransferStatusAccepted); err != nil {
+			return err
+		}
+		
+		// Get the task; ownership changes cancel pending transfers, so the owner is unchanged
+		task, err := s.taskRepo.GetByUUID(ctx, transfer.TaskID)
+		if err != nil {
+			return errors.New("task not found")
+		}
+		if task.CreatedByID != transfer.FromUserID {
+			return errors.New("task owner has changed since the transfer was requested")
+		}
+		
+		if err := s.applyTransfer(ctx, task, userUUID); err != nil {
+			return err
+		}
+		
+		return s.transferRepo.Update(ctx, transfer)
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	return transfer, nil
+}
+
+// CancelTransfer withdraws a pending transfer the user requested
+func (s *TransferService) CancelTransfer(ctx context.Context, transferUUID uuid.UUID, userUUID uuid.UUID) (*entity.OwnershipTransfer, error) {
+	transfer, err := s.transferRepo.GetByUUID(ctx, transferUUID)
+	if err != nil || transfer.RequestedByID != userUUID {
+		return nil, errors.New("transfer not found")
+	}
+	
+	if err := transfer.Resolve(entity.TransferStatusCancelled); err != nil {
+		return nil, err
+	}
+	
+	if err := s.transferRepo.Update(ctx, transfer); err != nil {
+		return nil, err
+	}
+	
+	return transfer, nil
+}
+
+// GetPendingTransfers gets pending transfers a user requested or has to answer
+func (s *TransferService) GetPendingTransfers(ctx context.Context, userUUID uuid.UUID) ([]*entity.OwnershipTransfer, error) {
+	return s.transferRepo.GetPendingForUser(ctx, userUUID)
+}
+
+// TransferAllTasks moves every task created by one user to another, for offboarding. Admins only.
+// All tasks move in a single transaction and each move is recorded as an accepted transfer.
+func (s *TransferService) TransferAllTasks(ctx context.Context, fromUserUUID uuid.UUID, toUserUUID uuid.UUID, requestorUUID uuid.UUID) ([]*entity.Task, error) {
+	if !s.hasPermission(ctx, requestorUUID, entity.PermissionUsersManage) {
+		return nil, errors.New("admin access required")
+	}
+	
+	if fromUserUUID == toUserUUID {
+		return nil, errors.New("cannot transfer tasks to the same user")
+	}
+	
+	var tasks []*entity.Task
+	
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Check both users exist
+		if _, err := s.userRepo.GetByUUID(ctx, fromUserUUID); err != nil {
+			return errors.New("user not found")
+		}
+		if _, err := s.userRepo.GetByUUID(ctx, toUserUUID); err != nil {
+			return errors.New("user not found")
+		}
+		
+		var err error
+		tasks, err = s.taskRepo.GetTasksCreatedByUser(ctx, fromUserUUID)
+		if err != nil {
+			return err
+		}
+		
+		for _, task := range tasks {
+			transfer, err := entity.NewOwnershipTransfer(task, toUserUUID, requestorUUID)
+			if err != nil {
+				return err
+			}
+			if err := transfer.Resolve(entity.TransferStatusAccepted); err != nil {
+				return err
+			}
+			if err := s.applyTransfer(ctx, task, toUserUUID); err != nil {
+				return err
+			}
+			if err := s.transferRepo.Create(ctx, transfer); err != nil {
+				return err
+			}
+		}
+		
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	return tasks, nil
+}
+
+// applyTransfer makes a user the task's owner and cancels any other pending transfer of it
+func (s *TransferService) applyTransfer(ctx context.Context, task *entity.Task, toUserUUID uuid.UUID) error {
+	// External IDs are unique per creator; drop the ID if the new owner already uses it
+	if task.ExternalID != "" {
+		exists, err := s.taskRepo.ExternalIDExists(ctx, toUserUUID, task.ExternalID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			task.ExternalID = ""
+		}
+	}
+	
+	task.TransferOwnership(toUserUUID)
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return err
+	}
+	
+	return s.transferRepo.CancelPendingForTask(ctx, task.UUID)
+}
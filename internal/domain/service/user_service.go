@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
 	"task2/internal/domain/entity"
 	"task2/internal/domain/repository"
 
@@ -11,17 +12,19 @@ import (
 
 // UserService provides domain logic for users
 type UserService struct {
-	userRepo repository.UserRepository
+	userRepo   repository.UserRepository
+	transactor repository.Transactor
 }
 
 // NewUserService creates a new user service
-func NewUserService(userRepo repository.UserRepository) *UserService {
+func NewUserService(userRepo repository.UserRepository, transactor repository.Transactor) *UserService {
 	return &UserService{
-		userRepo: userRepo,
+		userRepo:   userRepo,
+		transactor: transactor,
 	}
 }
 
-// CreateUser creates a new user
+// CreateUser creates a new user. The first user ever registered becomes the admin.
 func (s *UserService) CreateUser(ctx context.Context, user *entity.User) error {
 	// Check if email already exists
 	exists, err := s.userRepo.EmailExists(ctx, user.Email)
@@ -33,7 +36,22 @@ func (s *UserService) CreateUser(ctx context.Context, user *entity.User) error {
 		return errors.New("email already registered")
 	}
 	
-	return s.userRepo.Create(ctx, user)
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Serialize with other registrations, so only one of them can be the first
+		if err := s.userRepo.LockRoles(ctx); err != nil {
+			return err
+		}
+		
+		count, err := s.userRepo.Count(ctx)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			user.Role = entity.RoleAdmin
+		}
+		
+		return s.userRepo.Create(ctx, user)
+	})
 }
 
 // GetUserByEmail retrieves a user by email
@@ -70,13 +88,152 @@ func (s *UserService) UpdateUser(ctx context.Context, user *entity.User) error {
 	return s.userRepo.Update(ctx, user)
 }
 
-// DeleteUser deletes a user
-func (s *UserService) DeleteUser(ctx context.Context, uuid uuid.UUID) error {
-	// Check if user exists
-	_, err := s.userRepo.GetByUUID(ctx, uuid)
+// GetUserByCalendarTokenHash retrieves a user by the hash of their calendar feed token
+func (s *UserService) GetUserByCalendarTokenHash(ctx context.Context, tokenHash string) (*entity.User, error) {
+	return s.userRepo.GetByCalendarTokenHash(ctx, tokenHash)
+}
+
+// SetCalendarTokenHash replaces the hash of a user's calendar feed token
+func (s *UserService) SetCalendarTokenHash(ctx context.Context, uuid uuid.UUID, tokenHash string) error {
+	return s.userRepo.UpdateCalendarTokenHash(ctx, uuid, tokenHash)
+}
+
+// VerifyEmail marks a user's email as verified, provided it is still the address the verification was sent to
+func (s *UserService) VerifyEmail(ctx context.Context, uuid uuid.UUID, email string) error {
+	verified, err := s.userRepo.MarkEmailVerified(ctx, uuid, email)
 	if err != nil {
-		return errors.New("user not found")
+		return err
+	}
+	if !verified {
+		return errors.New("invalid or expired verification token")
+	}
+	
+	return nil
+}
+
+// ClaimVerificationEmail records that a verification email is being sent to an unverified user.
+// It reports false, and nothing is recorded, if the user is verified or was sent one within the interval.
+func (s *UserService) ClaimVerificationEmail(ctx context.Context, uuid uuid.UUID, interval time.Duration) (bool, error) {
+	return s.userRepo.ClaimVerificationEmail(ctx, uuid, time.Now().Add(-interval))
+}
+
+// IsEmailVerified checks if a user has verified their email
+func (s *UserService) IsEmailVerified(ctx context.Context, uuid uuid.UUID) (bool, error) {
+	user, err := s.userRepo.GetByUUID(ctx, uuid)
+	if err != nil {
+		return false, err
+	}
+	
+	return user.EmailVerified, nil
+}
+
+// GetActiveRole gets the role of a user who is not suspended
+func (s *UserService) GetActiveRole(ctx context.Context, uuid uuid.UUID) (entity.Role, error) {
+	user, err := s.userRepo.GetByUUID(ctx, uuid)
+	if err != nil {
+		return "", errors.New("user not found")
+	}
+	if user.IsSuspended() {
+		return "", errors.New("account is suspended")
+	}
+	
+	return user.Role, nil
+}
+
+// ChangeRole changes a user's role. The last active admin cannot be demoted.
+func (s *UserService) ChangeRole(ctx context.Context, uuid uuid.UUID, role entity.Role) (*entity.User, error) {
+	return s.updateAccess(ctx, uuid, func(user *entity.User) error {
+		if role != entity.RoleAdmin {
+			if err := s.checkNotLastAdmin(ctx, user); err != nil {
+				return err
+			}
+		}
+		return user.ChangeRole(role)
+	})
+}
+
+// SuspendUser suspends a user, blocking them from signing in. The last active admin cannot be suspended.
+func (s *UserService) SuspendUser(ctx context.Context, uuid uuid.UUID) (*entity.User, error) {
+	return s.updateAccess(ctx, uuid, func(user *entity.User) error {
+		if err := s.checkNotLastAdmin(ctx, user); err != nil {
+			return err
+		}
+		user.Suspend()
+		return nil
+	})
+}
+
+// ReactivateUser lifts a user's suspension
+func (s *UserService) ReactivateUser(ctx context.Context, uuid uuid.UUID) (*entity.User, error) {
+	return s.updateAccess(ctx, uuid, func(user *entity.User) error {
+		user.Reactivate()
+		return nil
+	})
+}
+
+// updateAccess applies a change to a user's role or suspension while holding the role lock
+func (s *UserService) updateAccess(ctx context.Context, uuid uuid.UUID, change func(user *entity.User) error) (*entity.User, error) {
+	var user *entity.User
+	
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.LockRoles(ctx); err != nil {
+			return err
+		}
+		
+		var err error
+		user, err = s.userRepo.GetByUUID(ctx, uuid)
+		if err != nil {
+			return errors.New("user not found")
+		}
+		
+		if err := change(user); err != nil {
+			return err
+		}
+		
+		return s.userRepo.Update(ctx, user)
+	})
+	if err != nil {
+		return nil, err
 	}
 	
-	return s.userRepo.Delete(ctx, uuid)
-}
\ No newline at end of file
+	return user, nil
+}
+
+// checkNotLastAdmin fails if the user is the only active admin, so there is always someone to manage users.
+// It must be called while holding the role lock.
+func (s *UserService) checkNotLastAdmin(ctx context.Context, user *entity.User) error {
+	if user.Role != entity.RoleAdmin || user.IsSuspended() {
+		return nil
+	}
+	
+	admins, err := s.userRepo.CountActiveByRole(ctx, entity.RoleAdmin)
+	if err != nil {
+		return err
+	}
+	if admins <= 1 {
+		return errors.New("cannot remove the last admin")
+	}
+	
+	return nil
+}
+
+// DeleteUser deletes a user. The last active admin cannot be deleted.
+func (s *UserService) DeleteUser(ctx context.Context, uuid uuid.UUID) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.LockRoles(ctx); err != nil {
+			return err
+		}
+		
+		// Check if user exists
+		user, err := s.userRepo.GetByUUID(ctx, uuid)
+		if err != nil {
+			return errors.New("user not found")
+		}
+		
+		if err := s.checkNotLastAdmin(ctx, user); err != nil {
+			return err
+		}
+		
+		return s.userRepo.Delete(ctx, uuid)
+	})
+}
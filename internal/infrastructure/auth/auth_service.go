@@ -2,6 +2,7 @@ package auth
 
 import (
 	"errors"
+	"math"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -9,37 +10,72 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// DefaultAccessTokenTTL is how long an access token is valid when no other lifetime is configured
+const DefaultAccessTokenTTL = 15 * time.Minute
+
+// TokenClaims holds the claims of a validated access token
+type TokenClaims struct {
+	UserUUID  uuid.UUID
+	TokenID   uuid.UUID
+	SessionID uuid.UUID // the refresh token family the token was issued with, or uuid.Nil for none
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// EmailVerificationTokenTTL is how long an email verification link can be used
+const EmailVerificationTokenTTL = 24 * time.Hour
+
+// emailVerificationPurpose marks email verification tokens, so they cannot be used as access tokens or the other way round
+const emailVerificationPurpose = "verify_email"
+
 // AuthService implements the authentication service
 type AuthService struct {
-	jwtSecret []byte
+	jwtSecret      []byte
+	accessTokenTTL time.Duration
 }
 
 // NewAuthService creates a new authentication service
 func NewAuthService(jwtSecret string) *AuthService {
 	return &AuthService{
-		jwtSecret: []byte(jwtSecret),
+		jwtSecret:      []byte(jwtSecret),
+		accessTokenTTL: DefaultAccessTokenTTL,
 	}
 }
 
-// GenerateToken generates a JWT token for a user
-func (s *AuthService) GenerateToken(userUUID uuid.UUID) (string, error) {
+// SetAccessTokenTTL sets how long new access tokens are valid
+func (s *AuthService) SetAccessTokenTTL(ttl time.Duration) {
+	s.accessTokenTTL = ttl
+}
+
+// GenerateToken generates a short-lived JWT access token for a user, returning when it expires.
+// Each token has its own ID in the jti claim. The iat claim has millisecond precision, so a token
+// issued just after all of a user's tokens were revoked is told apart from those revoked.
+// The sid claim names the session, the refresh token family, so logging out can end it.
+func (s *AuthService) GenerateToken(userUUID uuid.UUID, sessionID uuid.UUID) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(s.accessTokenTTL)
+	
 	// Create token
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"sub": userUUID.String(),
-		"exp": time.Now().Add(time.Hour * 24 * 7).Unix(), // 7 days
+		"jti": uuid.New().String(),
+		"sid": sessionID.String(),
+		"iat": float64(now.UnixMilli()) / 1000,
+		"exp": expiresAt.Unix(),
 	})
 	
 	// Sign token
 	tokenString, err := token.SignedString(s.jwtSecret)
 	if err != nil {
-		return "", err
+		return "", time.Time{}, err
 	}
 	
-	return tokenString, nil
+	return tokenString, expiresAt, nil
 }
 
-// ValidateToken validates a JWT token
-func (s *AuthService) ValidateToken(tokenString string) (uuid.UUID, error) {
+// ValidateToken validates a JWT access token and returns its claims.
+// Tokens without a jti or iat claim cannot be revoked, so they are rejected.
+func (s *AuthService) ValidateToken(tokenString string) (*TokenClaims, error) {
 	// Parse token
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		// Validate signing method
@@ -51,27 +87,107 @@ func (s *AuthService) ValidateToken(tokenString string) (uuid.UUID, error) {
 	})
 	
 	if err != nil {
-		return uuid.Nil, err
+		return nil, err
 	}
 	
-	// Validate claims
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		// Get user UUID
-		userUUIDStr, ok := claims["sub"].(string)
-		if !ok {
-			return uuid.Nil, errors.New("invalid token claims")
-		}
-		
-		// Parse UUID
-		userUUID, err := uuid.Parse(userUUIDStr)
+	// Validate claims; tokens for another purpose are not access tokens
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	if _, ok := claims["purpose"]; ok {
+		return nil, errors.New("invalid token")
+	}
+	
+	// Get user UUID and token ID
+	userUUIDStr, _ := claims["sub"].(string)
+	tokenIDStr, _ := claims["jti"].(string)
+	
+	userUUID, err := uuid.Parse(userUUIDStr)
+	if err != nil {
+		return nil, errors.New("invalid token claims")
+	}
+	
+	tokenID, err := uuid.Parse(tokenIDStr)
+	if err != nil {
+		return nil, errors.New("invalid token claims")
+	}
+	
+	// Get the session ID; tokens issued before sessions were named have none
+	sessionID := uuid.Nil
+	if sessionIDStr, ok := claims["sid"].(string); ok {
+		sessionID, err = uuid.Parse(sessionIDStr)
 		if err != nil {
-			return uuid.Nil, err
+			return nil, errors.New("invalid token claims")
+		}
+	}
+	
+	// Get issue and expiry times; iat is read directly, as the library truncates it to whole seconds
+	issuedAtSeconds, ok := claims["iat"].(float64)
+	if !ok {
+		return nil, errors.New("invalid token claims")
+	}
+	issuedAt := time.UnixMilli(int64(math.Round(issuedAtSeconds * 1000)))
+	
+	expiresAt, err := claims.GetExpirationTime()
+	if err != nil || expiresAt == nil {
+		return nil, errors.New("invalid token claims")
+	}
+	
+	return &TokenClaims{
+		UserUUID:  userUUID,
+		TokenID:   tokenID,
+		SessionID: sessionID,
+		IssuedAt:  issuedAt,
+		ExpiresAt: expiresAt.Time,
+	}, nil
+}
+
+// GenerateEmailVerificationToken generates a signed token proving that the user received an email at the address
+func (s *AuthService) GenerateEmailVerificationToken(userUUID uuid.UUID, email string) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":     userUUID.String(),
+		"email":   email,
+		"purpose": emailVerificationPurpose,
+		"exp":     time.Now().Add(EmailVerificationTokenTTL).Unix(),
+	})
+	
+	return token.SignedString(s.jwtSecret)
+}
+
+// ValidateEmailVerificationToken validates an email verification token and returns the user and address it was sent to
+func (s *AuthService) ValidateEmailVerificationToken(tokenString string) (uuid.UUID, string, error) {
+	invalid := errors.New("invalid or expired verification token")
+	
+	// Parse token
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		// Validate signing method
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
 		}
 		
-		return userUUID, nil
+		return s.jwtSecret, nil
+	})
+	
+	if err != nil {
+		return uuid.Nil, "", invalid
 	}
 	
-	return uuid.Nil, errors.New("invalid token")
+	// Validate claims
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid || claims["purpose"] != emailVerificationPurpose {
+		return uuid.Nil, "", invalid
+	}
+	
+	userUUIDStr, _ := claims["sub"].(string)
+	email, _ := claims["email"].(string)
+	
+	userUUID, err := uuid.Parse(userUUIDStr)
+	if err != nil || email == "" {
+		return uuid.Nil, "", invalid
+	}
+	
+	return userUUID, email, nil
 }
 
 // HashPassword hashes a password
@@ -88,4 +204,4 @@ func (s *AuthService) HashPassword(password string) (string, error) {
 func (s *AuthService) ValidatePassword(hashedPassword, password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 	return err == nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+	"task2/internal/domain/entity"
+	"task2/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// DefaultRevocationCacheTTL is how long a lookup that found no revocation is trusted
+const DefaultRevocationCacheTTL = 30 * time.Second
+
+// RevocationStore records revoked access tokens in Postgres and caches lookups in memory,
+// so authenticating a request does not query the database every time.
+// Revocations made through this store apply at once; revocations made by another server
+// apply here once the cached lookup is older than the cache TTL.
+type RevocationStore struct {
+	revocationRepo repository.TokenRevocationRepository
+	cacheTTL       time.Duration
+	
+	mu     sync.Mutex
+	tokens map[uuid.UUID]cachedTokenRevocation
+	users  map[uuid.UUID]cachedUserRevocation
+}
+
+// cachedTokenRevocation is a cached lookup of one token, trusted until validUntil
+type cachedTokenRevocation struct {
+	revoked    bool
+	validUntil time.Time
+}
+
+// cachedUserRevocation is a cached lookup of the time up to which a user's tokens are revoked, trusted until validUntil
+type cachedUserRevocation struct {
+	revokedBefore time.Time
+	validUntil    time.Time
+}
+
+// NewRevocationStore creates a new revocation store
+func NewRevocationStore(revocationRepo repository.TokenRevocationRepository) *RevocationStore {
+	return &RevocationStore{
+		revocationRepo: revocationRepo,
+		cacheTTL:       DefaultRevocationCacheTTL,
+		tokens:         make(map[uuid.UUID]cachedTokenRevocation),
+		users:          make(map[uuid.UUID]cachedUserRevocation),
+	}
+}
+
+// RevokeToken revokes one access token until it expires
+func (s *RevocationStore) RevokeToken(ctx context.Context, tokenID uuid.UUID, userUUID uuid.UUID, expiresAt time.Time) error {
+	if err := s.revocationRepo.RevokeToken(ctx, entity.NewRevokedToken(tokenID, userUUID, expiresAt)); err != nil {
+		return err
+	}
+	
+	// A revoked token stays revoked, so trust this until the token expires
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[tokenID] = cachedTokenRevocation{revoked: true, validUntil: expiresAt}
+	
+	return nil
+}
+
+// RevokeAllTokens revokes every access token issued to a user so far.
+// The cut-off is in whole milliseconds, like token iat claims, so a token issued right after it stays valid.
+func (s *RevocationStore) RevokeAllTokens(ctx context.Context, userUUID uuid.UUID) error {
+	now := time.Now().Truncate(time.Millisecond)
+	if err := s.revocationRepo.RevokeUserTokens(ctx, userUUID, now); err != nil {
+		return err
+	}
+	
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[userUUID] = cachedUserRevocation{revokedBefore: now, validUntil: now.Add(s.cacheTTL)}
+	
+	return nil
+}
+
+// IsRevoked checks if an access token was revoked, on its own or with all of its user's tokens
+func (s *RevocationStore) IsRevoked(ctx context.Context, claims *TokenClaims) (bool, error) {
+	revokedBefore, err := s.userTokensRevokedBefore(ctx, claims.UserUUID)
+	if err != nil {
+		return false, err
+	}
+	if claims.IssuedAt.Before(revokedBefore) {
+		return true, nil
+	}
+	
+	return s.isTokenRevoked(ctx, claims.TokenID, claims.ExpiresAt)
+}
+
+// PurgeExpired forgets revoked tokens that have expired, as expired tokens are rejected anyway
+func (s *RevocationStore) PurgeExpired(ctx context.Context) error {
+	now := time.Now()
+	
+	s.mu.Lock()
+	for tokenID, cached := range s.tokens {
+		if !now.Before(cached.validUntil) {
+			delete(s.tokens, tokenID)
+		}
+	}
+	for userUUID, cached := range s.users {
+		if !now.Before(cached.validUntil) {
+			delete(s.users, userUUID)
+		}
+	}
+	s.mu.Unlock()
+	
+	deleted, err := s.revocationRepo.DeleteExpired(ctx, now)
+	if deleted > 0 {
+		log.Printf("Purged %d expired revoked tokens", deleted)
+	}
+	
+	return err
+}
+
+// isTokenRevoked looks up one token, through the cache
+func (s *RevocationStore) isTokenRevoked(ctx context.Context, tokenID uuid.UUID, expiresAt time.Time) (bool, error) {
+	now := time.Now()
+	
+	s.mu.Lock()
+	cached, ok := s.tokens[tokenID]
+	s.mu.Unlock()
+	if ok && now.Before(cached.validUntil) {
+		return cached.revoked, nil
+	}
+	
+	revoked, err := s.revocationRepo.IsTokenRevoked(ctx, tokenID)
+	if err != nil {
+		return false, err
+	}
+	
+	validUntil := now.Add(s.cacheTTL)
+	if revoked {
+		validUntil = expiresAt
+	}
+	
+	s.mu.Lock()
+	s.tokens[tokenID] = cachedTokenRevocation{revoked: revoked, validUntil: validUntil}
+	s.mu.Unlock()
+	
+	return revoked, nil
+}
+
+// userTokensRevokedBefore looks up the time up to which a user's tokens are revoked, through the cache.
+// It is zero if they never were.
+func (s *RevocationStore) userTokensRevokedBefore(ctx context.Context, userUUID uuid.UUID) (time.Time, error) {
+	now := time.Now()
+	
+	s.mu.Lock()
+	cached, ok := s.users[userUUID]
+	s.mu.Unlock()
+	if ok && now.Before(cached.validUntil) {
+		return cached.revokedBefore, nil
+	}
+	
+	before, err := s.revocationRepo.GetUserTokensRevokedBefore(ctx, userUUID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	
+	var revokedBefore time.Time
+	if before != nil {
+		revokedBefore = *before
+	}
+	
+	s.mu.Lock()
+	s.users[userUUID] = cachedUserRevocation{revokedBefore: revokedBefore, validUntil: now.Add(s.cacheTTL)}
+	s.mu.Unlock()
+	
+	return revokedBefore, nil
+}
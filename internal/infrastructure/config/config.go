@@ -1,8 +1,13 @@
 package config
 
 import (
+	"fmt"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
+	"time"
+	"task2/internal/domain/entity"
 
 	"github.com/joho/godotenv"
 )
@@ -20,6 +25,30 @@ type Config struct {
 	SMTPFrom    string
 	LogLevel    string
 	Debug       bool
+
+	// Markdown sanitizer allow-lists; empty means the renderer defaults
+	MarkdownAllowedTags       []string
+	MarkdownAllowedAttributes []string
+
+	// How long deleted tasks stay in the trash before they are purged; zero keeps them forever
+	TrashRetention time.Duration
+
+	// Prefix of the short keys given to new tasks, such as OPS in OPS-123
+	TaskKeyPrefix string
+
+	// How long access tokens and refresh tokens are valid
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+
+	// Whether users must verify their email to log in, and to use sensitive routes
+	RequireVerifiedEmailForLogin     bool
+	RequireVerifiedEmailForSensitive bool
+
+	// Scheme and host of the API that links in emails point at, such as https://tasks.example.com; empty disables those emails
+	AppBaseURL string
+
+	// Page that password reset links point at, such as a front end's reset form; empty disables password reset
+	PasswordResetURL string
 }
 
 // LoadConfig loads configuration from environment variables
@@ -72,6 +101,30 @@ func LoadConfig() (*Config, error) {
 	logLevel := os.Getenv("LOG_LEVEL")
 	debugStr := os.Getenv("DEBUG")
 	
+	// Markdown settings
+	markdownAllowedTags := splitList(os.Getenv("MARKDOWN_ALLOWED_TAGS"))
+	markdownAllowedAttributes := splitList(os.Getenv("MARKDOWN_ALLOWED_ATTRIBUTES"))
+	
+	// Trash settings
+	trashRetentionDays := os.Getenv("TRASH_RETENTION_DAYS")
+	
+	// Task key settings
+	taskKeyPrefix := strings.ToUpper(strings.TrimSpace(os.Getenv("TASK_KEY_PREFIX")))
+	
+	// Token settings
+	accessTokenTTLMinutes := os.Getenv("ACCESS_TOKEN_TTL_MINUTES")
+	refreshTokenTTLDays := os.Getenv("REFRESH_TOKEN_TTL_DAYS")
+	
+	// Email verification settings
+	requireVerifiedLoginStr := os.Getenv("REQUIRE_VERIFIED_EMAIL_FOR_LOGIN")
+	requireVerifiedSensitiveStr := os.Getenv("REQUIRE_VERIFIED_EMAIL_FOR_SENSITIVE_ACTIONS")
+	
+	// Link settings
+	appBaseURL := strings.TrimRight(strings.TrimSpace(os.Getenv("APP_BASE_URL")), "/")
+	
+	// Password reset settings
+	passwordResetURL := os.Getenv("PASSWORD_RESET_URL")
+	
 	// Set defaults
 	if port == "" {
 		port = "8080"
@@ -85,6 +138,72 @@ func LoadConfig() (*Config, error) {
 		logLevel = "info"
 	}
 	
+	// Parse trash retention, defaulting to 30 days
+	trashRetention := 30 * 24 * time.Hour
+	if trashRetentionDays != "" {
+		days, err := strconv.Atoi(trashRetentionDays)
+		if err != nil || days < 0 {
+			return nil, fmt.Errorf("invalid TRASH_RETENTION_DAYS: %q", trashRetentionDays)
+		}
+		trashRetention = time.Duration(days) * 24 * time.Hour
+	}
+	
+	// Parse token lifetimes, defaulting to 15 minutes and 30 days
+	accessTokenTTL := 15 * time.Minute
+	if accessTokenTTLMinutes != "" {
+		minutes, err := strconv.Atoi(accessTokenTTLMinutes)
+		if err != nil || minutes <= 0 {
+			return nil, fmt.Errorf("invalid ACCESS_TOKEN_TTL_MINUTES: %q", accessTokenTTLMinutes)
+		}
+		accessTokenTTL = time.Duration(minutes) * time.Minute
+	}
+	
+	refreshTokenTTL := 30 * 24 * time.Hour
+	if refreshTokenTTLDays != "" {
+		days, err := strconv.Atoi(refreshTokenTTLDays)
+		if err != nil || days <= 0 {
+			return nil, fmt.Errorf("invalid REFRESH_TOKEN_TTL_DAYS: %q", refreshTokenTTLDays)
+		}
+		refreshTokenTTL = time.Duration(days) * 24 * time.Hour
+	}
+	
+	// Parse email verification requirements, which are off by default
+	requireVerifiedLogin := false
+	if requireVerifiedLoginStr != "" {
+		var err error
+		requireVerifiedLogin, err = strconv.ParseBool(requireVerifiedLoginStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid REQUIRE_VERIFIED_EMAIL_FOR_LOGIN: %q", requireVerifiedLoginStr)
+		}
+	}
+	
+	requireVerifiedSensitive := false
+	if requireVerifiedSensitiveStr != "" {
+		var err error
+		requireVerifiedSensitive, err = strconv.ParseBool(requireVerifiedSensitiveStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid REQUIRE_VERIFIED_EMAIL_FOR_SENSITIVE_ACTIONS: %q", requireVerifiedSensitiveStr)
+		}
+	}
+	
+	// Validate the base URL, which emailed links trust instead of request headers
+	if appBaseURL != "" && !isAbsoluteURL(appBaseURL) {
+		return nil, fmt.Errorf("invalid APP_BASE_URL: %q", appBaseURL)
+	}
+	
+	// Validate the password reset page, which reset links must not take from request headers
+	if passwordResetURL != "" && !isAbsoluteURL(passwordResetURL) {
+		return nil, fmt.Errorf("invalid PASSWORD_RESET_URL: %q", passwordResetURL)
+	}
+	
+	// Validate the task key prefix
+	if taskKeyPrefix == "" {
+		taskKeyPrefix = entity.DefaultTaskKeyPrefix
+	}
+	if !entity.IsValidTaskKeyPrefix(taskKeyPrefix) {
+		return nil, fmt.Errorf("invalid TASK_KEY_PREFIX: %q", taskKeyPrefix)
+	}
+	
 	// Parse debug flag
 	debug := false
 	if debugStr != "" {
@@ -104,16 +223,48 @@ func LoadConfig() (*Config, error) {
 		SMTPFrom:    smtpFrom,
 		LogLevel:    logLevel,
 		Debug:       debug,
+
+		MarkdownAllowedTags:       markdownAllowedTags,
+		MarkdownAllowedAttributes: markdownAllowedAttributes,
+
+		TrashRetention: trashRetention,
+		TaskKeyPrefix:  taskKeyPrefix,
+
+		AccessTokenTTL:  accessTokenTTL,
+		RefreshTokenTTL: refreshTokenTTL,
+
+		RequireVerifiedEmailForLogin:     requireVerifiedLogin,
+		RequireVerifiedEmailForSensitive: requireVerifiedSensitive,
+
+		AppBaseURL:       appBaseURL,
+		PasswordResetURL: passwordResetURL,
 	}
 	
 	return config, nil
 }
 
+// splitList splits a comma-separated setting into its trimmed, non-empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // IsDevelopment checks if the environment is development
 func (c *Config) IsDevelopment() bool {
 	return c.Environment == "development"
 }
 
+// isAbsoluteURL checks if a setting is an http or https URL with a host
+func isAbsoluteURL(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
 // IsProduction checks if the environment is production
 func (c *Config) IsProduction() bool {
 	return c.Environment == "production"
@@ -122,4 +273,4 @@ func (c *Config) IsProduction() bool {
 // IsTest checks if the environment is test
 func (c *Config) IsTest() bool {
 	return c.Environment == "test"
-}
\ No newline at end of file
+}
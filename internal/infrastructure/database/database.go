@@ -52,6 +52,8 @@ func RegisterModels(db *bun.DB) {
 	// Register models in the correct order
 	// Register the join table (UserTask) first before the models that use it in m2m relationships
 	db.RegisterModel((*persistence.UserTask)(nil))
+	db.RegisterModel((*persistence.TaskMention)(nil))
+	db.RegisterModel((*persistence.TaskReviewer)(nil))
 	db.RegisterModel((*persistence.User)(nil))
 	db.RegisterModel((*persistence.Task)(nil))
 }
@@ -77,4 +79,4 @@ func CloseDB() error {
 		return DB.Close()
 	}
 	return nil
-}
\ No newline at end of file
+}
@@ -39,6 +39,281 @@ func RunMigrations(db *bun.DB) error {
 		return fmt.Errorf("failed to create user_tasks table: %w", err)
 	}
 	
+	// Add tasks.external_id to tables created before it existed
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE tasks ADD COLUMN IF NOT EXISTS external_id TEXT;
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add tasks.external_id column: %w", err)
+	}
+	
+	// Add tasks.due_date to tables created before it existed
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_date TIMESTAMP;
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add tasks.due_date column: %w", err)
+	}
+	
+	// Add users.calendar_token_hash to tables created before it existed
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE users ADD COLUMN IF NOT EXISTS calendar_token_hash TEXT UNIQUE;
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add users.calendar_token_hash column: %w", err)
+	}
+	
+	// Add tasks.version to tables created before it existed
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE tasks ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add tasks.version column: %w", err)
+	}
+	
+	// Add tasks.short_key to tables created before it existed
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE tasks ADD COLUMN IF NOT EXISTS short_key TEXT;
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add tasks.short_key column: %w", err)
+	}
+	
+	// Add tasks approval columns to tables created before they existed
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE tasks ADD COLUMN IF NOT EXISTS required_approvals INTEGER NOT NULL DEFAULT 0;
+		ALTER TABLE tasks ADD COLUMN IF NOT EXISTS awaiting_approval BOOLEAN NOT NULL DEFAULT FALSE;
+		ALTER TABLE tasks ADD COLUMN IF NOT EXISTS approval_round INTEGER NOT NULL DEFAULT 0;
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add tasks approval columns: %w", err)
+	}
+	
+	// Create task_mentions table
+	_, err = db.NewCreateTable().
+		Model((*persistence.TaskMention)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create task_mentions table: %w", err)
+	}
+	
+	// Create notifications table
+	_, err = db.NewCreateTable().
+		Model((*persistence.Notification)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create notifications table: %w", err)
+	}
+	
+	// Create automation_rules table
+	_, err = db.NewCreateTable().
+		Model((*persistence.AutomationRule)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create automation_rules table: %w", err)
+	}
+	
+	// Create automation_executions table
+	_, err = db.NewCreateTable().
+		Model((*persistence.AutomationExecution)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create automation_executions table: %w", err)
+	}
+	
+	// Create saved_views table
+	_, err = db.NewCreateTable().
+		Model((*persistence.SavedView)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create saved_views table: %w", err)
+	}
+	
+	// Create reminders table
+	_, err = db.NewCreateTable().
+		Model((*persistence.Reminder)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create reminders table: %w", err)
+	}
+	
+	// Create ownership_transfers table
+	_, err = db.NewCreateTable().
+		Model((*persistence.OwnershipTransfer)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create ownership_transfers table: %w", err)
+	}
+	
+	// Create task_reviewers table
+	_, err = db.NewCreateTable().
+		Model((*persistence.TaskReviewer)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create task_reviewers table: %w", err)
+	}
+	
+	// Create task_approvals table
+	_, err = db.NewCreateTable().
+		Model((*persistence.ApprovalDecision)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create task_approvals table: %w", err)
+	}
+	
+	// Create task_key_sequences table
+	_, err = db.NewCreateTable().
+		Model((*persistence.TaskKeySequence)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create task_key_sequences table: %w", err)
+	}
+	
+	// Give tasks created before keys existed a TASK key, in creation order
+	_, err = db.ExecContext(ctx, `
+		UPDATE tasks SET short_key = 'TASK-' || numbered.number
+		FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY id) AS number FROM tasks WHERE short_key IS NULL) AS numbered
+		WHERE tasks.id = numbered.id
+			AND NOT EXISTS (SELECT 1 FROM task_key_sequences WHERE prefix = 'TASK');
+		INSERT INTO task_key_sequences (prefix, last_number)
+		SELECT 'TASK', COUNT(*) FROM tasks WHERE short_key LIKE 'TASK-%'
+		ON CONFLICT (prefix) DO NOTHING;
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to backfill tasks.short_key: %w", err)
+	}
+	
+	// Add users.email_verified to tables created before it existed; users who registered before then count as verified
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified BOOLEAN NOT NULL DEFAULT TRUE;
+		ALTER TABLE users ALTER COLUMN email_verified SET DEFAULT FALSE;
+		ALTER TABLE users ADD COLUMN IF NOT EXISTS verification_sent_at TIMESTAMP;
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add users.email_verified column: %w", err)
+	}
+	
+	// Create task_snoozes table
+	_, err = db.NewCreateTable().
+		Model((*persistence.Snooze)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create task_snoozes table: %w", err)
+	}
+	
+	// Create task_links table
+	_, err = db.NewCreateTable().
+		Model((*persistence.TaskLink)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create task_links table: %w", err)
+	}
+	
+	// Create refresh_tokens table
+	_, err = db.NewCreateTable().
+		Model((*persistence.RefreshToken)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create refresh_tokens table: %w", err)
+	}
+	
+	// Create revoked_tokens table
+	_, err = db.NewCreateTable().
+		Model((*persistence.RevokedToken)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create revoked_tokens table: %w", err)
+	}
+	
+	// Create user_token_revocations table
+	_, err = db.NewCreateTable().
+		Model((*persistence.UserTokenRevocation)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create user_token_revocations table: %w", err)
+	}
+	
+	// Create password_reset_tokens table
+	_, err = db.NewCreateTable().
+		Model((*persistence.PasswordResetToken)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create password_reset_tokens table: %w", err)
+	}
+	
+	// Add users.role and users.suspended_at to tables created before they existed;
+	// the earliest registered user becomes the admin of a deployment that has none
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE users ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'member';
+		ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_at TIMESTAMP;
+		UPDATE users SET role = 'admin'
+		WHERE id = (SELECT id FROM users WHERE deleted_at IS NULL ORDER BY id LIMIT 1)
+		  AND NOT EXISTS (SELECT 1 FROM users WHERE role = 'admin');
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add users.role column: %w", err)
+	}
+	
+	// Create personal_access_tokens table
+	_, err = db.NewCreateTable().
+		Model((*persistence.PersonalAccessToken)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create personal_access_tokens table: %w", err)
+	}
+	
+	// Create custom_fields table
+	_, err = db.NewCreateTable().
+		Model((*persistence.CustomField)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create custom_fields table: %w", err)
+	}
+	
+	// Create task_field_values table
+	_, err = db.NewCreateTable().
+		Model((*persistence.TaskFieldValue)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create task_field_values table: %w", err)
+	}
+	
+	// Create email_outbox table
+	_, err = db.NewCreateTable().
+		Model((*persistence.OutboxEmail)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create email_outbox table: %w", err)
+	}
+	
+	// Add saved_views.sort_field_id to tables created before it existed
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE saved_views ADD COLUMN IF NOT EXISTS sort_field_id UUID;
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add saved_views.sort_field_id column: %w", err)
+	}
+	
 	return nil
 }
 
@@ -86,5 +361,160 @@ func AddIndexes(db *bun.DB) error {
 		return fmt.Errorf("failed to create index on tasks.assigned_to_id: %w", err)
 	}
 	
+	// Add unique index on tasks.created_by_id and tasks.external_id
+	_, err = db.ExecContext(ctx, `
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_created_by_id_external_id
+			ON tasks (created_by_id, external_id) WHERE external_id IS NOT NULL;
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create index on tasks.external_id: %w", err)
+	}
+	
+	// Add index on task_mentions.user_id
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_task_mentions_user_id ON task_mentions (user_id);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create index on task_mentions.user_id: %w", err)
+	}
+	
+	// Add index on notifications.user_id
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications (user_id, created_at);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create index on notifications.user_id: %w", err)
+	}
+	
+	// Add index on automation_rules.owner_id and automation_rules.trigger
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_automation_rules_owner_id_trigger ON automation_rules (owner_id, trigger);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create index on automation_rules.owner_id: %w", err)
+	}
+	
+	// Add index on automation_executions.rule_id
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_automation_executions_rule_id ON automation_executions (rule_id, created_at);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create index on automation_executions.rule_id: %w", err)
+	}
+	
+	// Add index on saved_views.owner_id, allowing one pinned view per user
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_saved_views_owner_id ON saved_views (owner_id);
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_saved_views_owner_id_pinned ON saved_views (owner_id) WHERE pinned;
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create index on saved_views.owner_id: %w", err)
+	}
+	
+	// Add indexes on reminders.task_id and on pending reminders for the delivery job
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_reminders_task_id ON reminders (task_id);
+		CREATE INDEX IF NOT EXISTS idx_reminders_pending ON reminders (remind_at) WHERE sent_at IS NULL;
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes on reminders: %w", err)
+	}
+	
+	// Add indexes on pending ownership transfers, allowing one per task
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_ownership_transfers_to_user_id ON ownership_transfers (to_user_id) WHERE status = 'pending';
+		CREATE INDEX IF NOT EXISTS idx_ownership_transfers_requested_by_id ON ownership_transfers (requested_by_id) WHERE status = 'pending';
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_ownership_transfers_task_id_pending ON ownership_transfers (task_id) WHERE status = 'pending';
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes on ownership_transfers: %w", err)
+	}
+	
+	// Add indexes on task_reviewers.user_id and task_approvals, allowing one decision per reviewer per round
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_task_reviewers_user_id ON task_reviewers (user_id);
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_task_approvals_task_id_reviewer_id_round ON task_approvals (task_id, reviewer_id, round);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes on task approvals: %w", err)
+	}
+	
+	// Add unique index on tasks.short_key
+	_, err = db.ExecContext(ctx, `
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_short_key ON tasks (short_key);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create index on tasks.short_key: %w", err)
+	}
+	
+	// Add indexes on task_snoozes, allowing one snooze per user per task
+	_, err = db.ExecContext(ctx, `
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_task_snoozes_task_id_user_id ON task_snoozes (task_id, user_id);
+		CREATE INDEX IF NOT EXISTS idx_task_snoozes_user_id ON task_snoozes (user_id, snoozed_until);
+		CREATE INDEX IF NOT EXISTS idx_task_snoozes_snoozed_until ON task_snoozes (snoozed_until);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes on task_snoozes: %w", err)
+	}
+	
+	// Add indexes on task_links, allowing one link of each type between two tasks
+	_, err = db.ExecContext(ctx, `
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_task_links_source_task_id_target_task_id_type ON task_links (source_task_id, target_task_id, type);
+		CREATE INDEX IF NOT EXISTS idx_task_links_target_task_id ON task_links (target_task_id);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes on task_links: %w", err)
+	}
+	
+	// Add indexes on refresh_tokens
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+		CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes on refresh_tokens: %w", err)
+	}
+	
+	// Add index on revoked_tokens.expires_at
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires_at ON revoked_tokens (expires_at);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create index on revoked_tokens.expires_at: %w", err)
+	}
+	
+	// Add index on password_reset_tokens.user_id
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_user_id ON password_reset_tokens (user_id);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create index on password_reset_tokens.user_id: %w", err)
+	}
+	
+	// Add index on personal_access_tokens.user_id
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_personal_access_tokens_user_id ON personal_access_tokens (user_id);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create index on personal_access_tokens.user_id: %w", err)
+	}
+	
+	// Add index on custom_fields.owner_id, and on task_field_values.field_id for field deletion
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_custom_fields_owner_id ON custom_fields (owner_id);
+		CREATE INDEX IF NOT EXISTS idx_task_field_values_field_id ON task_field_values (field_id);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes on custom fields: %w", err)
+	}
+	
+	// Add index on unsent outbox emails for the sending job
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_email_outbox_pending ON email_outbox (next_attempt_at) WHERE sent_at IS NULL;
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create index on email_outbox: %w", err)
+	}
+	
 	return nil
-}
\ No newline at end of file
+}
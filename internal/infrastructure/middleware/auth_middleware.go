@@ -3,26 +3,46 @@ package middleware
 import (
 	"context"
 	"log"
+	"net"
 	"net/http"
 	"strings"
+	"task2/internal/domain/entity"
 	"task2/internal/infrastructure/auth"
 	"task2/pkg/utils"
+
+	"github.com/google/uuid"
 )
 
+// PersonalAccessTokenAuthenticator gets the user and scopes of a personal access token, recording its use from the IP
+type PersonalAccessTokenAuthenticator func(ctx context.Context, token string, ip string) (uuid.UUID, []string, error)
+
 // AuthMiddleware is a middleware for authentication
 type AuthMiddleware struct {
-	authService *auth.AuthService
+	authService     *auth.AuthService
+	revocationStore *auth.RevocationStore
+
+	// Authenticates personal access tokens; nil means they are not accepted
+	authenticatePersonalAccessToken PersonalAccessTokenAuthenticator
 }
 
 // NewAuthMiddleware creates a new authentication middleware
-func NewAuthMiddleware(authService *auth.AuthService) *AuthMiddleware {
+func NewAuthMiddleware(authService *auth.AuthService, revocationStore *auth.RevocationStore) *AuthMiddleware {
 	return &AuthMiddleware{
-		authService: authService,
+		authService:     authService,
+		revocationStore: revocationStore,
 	}
 }
 
-// Middleware authenticates the request
+// SetPersonalAccessTokenAuthenticator sets how personal access tokens are authenticated
+func (m *AuthMiddleware) SetPersonalAccessTokenAuthenticator(authenticate PersonalAccessTokenAuthenticator) {
+	m.authenticatePersonalAccessToken = authenticate
+}
+
+// Middleware authenticates the request with a login session's access token or a personal access token.
+// Personal access tokens are denied unless next is a PermissionMiddleware check, which limits them to their scopes.
 func (m *AuthMiddleware) Middleware(next http.Handler) http.Handler {
+	_, checksScopes := next.(scopedHandler)
+	
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Try to get token from different sources
 		var tokenString string
@@ -53,20 +73,82 @@ func (m *AuthMiddleware) Middleware(next http.Handler) http.Handler {
 			return
 		}
 		
+		// Personal access tokens are limited to their scopes
+		if strings.HasPrefix(tokenString, entity.PersonalAccessTokenPrefix) && m.authenticatePersonalAccessToken != nil {
+			userUUID, scopes, err := m.authenticatePersonalAccessToken(r.Context(), tokenString, clientIP(r))
+			if err != nil {
+				log.Printf("Auth: Invalid personal access token: %v", err)
+				utils.RespondJSON(w, http.StatusUnauthorized, "Invalid token", nil)
+				return
+			}
+			if !checksScopes {
+				log.Printf("Auth: Personal access token of user %s used on a route without scope checks", userUUID)
+				utils.RespondJSON(w, http.StatusForbidden, "Personal access tokens cannot be used here", nil)
+				return
+			}
+			
+			log.Printf("Auth: Personal access token validated successfully for user %s", userUUID)
+			
+			ctx := context.WithValue(r.Context(), utils.UserUUIDKey, userUUID)
+			ctx = context.WithValue(ctx, utils.TokenScopesKey, scopes)
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+		
 		// Validate token
-		userUUID, err := m.authService.ValidateToken(tokenString)
+		claims, err := m.authService.ValidateToken(tokenString)
 		if err != nil {
 			log.Printf("Auth: Invalid token: %v", err)
 			utils.RespondJSON(w, http.StatusUnauthorized, "Invalid token", nil)
 			return
 		}
 		
-		log.Printf("Auth: Token validated successfully for user %s", userUUID)
+		// Check the token was not revoked by a logout
+		revoked, err := m.revocationStore.IsRevoked(r.Context(), claims)
+		if err != nil {
+			log.Printf("Auth: Failed to check token revocation: %v", err)
+			utils.RespondJSON(w, http.StatusInternalServerError, "Failed to check token", nil)
+			return
+		}
+		if revoked {
+			log.Printf("Auth: Revoked token %s", claims.TokenID)
+			utils.RespondJSON(w, http.StatusUnauthorized, "Token has been revoked", nil)
+			return
+		}
+		
+		log.Printf("Auth: Token validated successfully for user %s", claims.UserUUID)
 		
-		// Add user UUID to context
-		ctx := context.WithValue(r.Context(), utils.UserUUIDKey, userUUID)
+		// Add user UUID and token to context
+		ctx := context.WithValue(r.Context(), utils.UserUUIDKey, claims.UserUUID)
+		ctx = context.WithValue(ctx, utils.AccessTokenKey, &utils.AccessToken{
+			ID:        claims.TokenID,
+			SessionID: claims.SessionID,
+			ExpiresAt: claims.ExpiresAt,
+		})
 		
 		// Call next handler
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
-}
\ No newline at end of file
+}
+
+// SessionOnly rejects requests authenticated by a personal access token, for routes that manage the account itself.
+// It must run after Middleware.
+func (m *AuthMiddleware) SessionOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := utils.GetTokenScopesFromContext(r.Context()); ok {
+			utils.RespondJSON(w, http.StatusForbidden, "Personal access tokens cannot be used here", nil)
+			return
+		}
+		
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientIP gets the IP address the request came from
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
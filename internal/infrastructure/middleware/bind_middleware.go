@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"reflect"
 	"task2/internal/infrastructure/validator"
 	"task2/pkg/utils"
 )
@@ -14,10 +15,14 @@ type contextKey string
 
 const BindKey contextKey = "bind"
 
-// BindAndValidate binds and validates the request body
+// BindAndValidate binds and validates the request body.
+// v is a pointer to a zero request struct; each request is decoded into a fresh value of the same type.
 func BindAndValidate(v interface{}) func(http.Handler) http.Handler {
+	requestType := reflect.TypeOf(v).Elem()
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			v := reflect.New(requestType).Interface()
+
 			// Check content type
 			contentType := r.Header.Get("Content-Type")
 			if contentType != "application/json" {
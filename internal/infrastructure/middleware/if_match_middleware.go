@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"task2/pkg/utils"
+)
+
+// IfMatch parses the If-Match header into the request context as the task versions the client accepts.
+// Tags that cannot match any version, such as weak tags, fail the precondition immediately.
+func IfMatch(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := strings.TrimSpace(r.Header.Get("If-Match"))
+		
+		// No precondition, or any current version is acceptable
+		if header == "" || header == "*" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		
+		// Parse the accepted versions
+		var versions []int64
+		for _, tag := range strings.Split(header, ",") {
+			if version, ok := utils.ParseETag(strings.TrimSpace(tag)); ok {
+				versions = append(versions, version)
+			}
+		}
+		
+		if len(versions) == 0 {
+			utils.RespondJSON(w, http.StatusPreconditionFailed, "If-Match does not match the current version", nil)
+			return
+		}
+		
+		// Add to context
+		ctx := utils.WithExpectedVersions(r.Context(), versions)
+		
+		// Call next handler
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"slices"
+	"task2/internal/domain/entity"
+	"task2/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// RoleResolver gets the role of a user, failing if the user is suspended or does not exist
+type RoleResolver func(ctx context.Context, userUUID uuid.UUID) (entity.Role, error)
+
+// PermissionMiddleware restricts routes to users whose role grants a permission
+type PermissionMiddleware struct {
+	resolveRole RoleResolver
+}
+
+// scopedHandler is a handler that checks the scopes of personal access tokens.
+// AuthMiddleware only lets personal access tokens reach handlers of this type.
+type scopedHandler http.HandlerFunc
+
+// ServeHTTP calls the handler function
+func (h scopedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h(w, r)
+}
+
+// NewPermissionMiddleware creates a new permission middleware
+func NewPermissionMiddleware(resolveRole RoleResolver) *PermissionMiddleware {
+	return &PermissionMiddleware{
+		resolveRole: resolveRole,
+	}
+}
+
+// Require rejects requests from users whose role does not grant the permission.
+// It must run after AuthMiddleware, which puts the user in the context.
+func (m *PermissionMiddleware) Require(permission entity.Permission) func(http.Handler) http.Handler {
+	return m.RequireByMethod(permission, permission)
+}
+
+// RequireByMethod is like Require, with one permission for GET and HEAD requests and another for the rest.
+// It is the only check of a personal access token's scopes, so AuthMiddleware must wrap it directly for a route to accept tokens.
+func (m *PermissionMiddleware) RequireByMethod(read entity.Permission, write entity.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return scopedHandler(func(w http.ResponseWriter, r *http.Request) {
+			permission := write
+			if r.Method == "GET" || r.Method == "HEAD" {
+				permission = read
+			}
+
+			role, err := m.resolveRole(r.Context(), utils.GetUserUUIDFromRequest(r))
+			if err != nil {
+				if err.Error() == "account is suspended" {
+					utils.RespondJSON(w, http.StatusForbidden, "Account is suspended", nil)
+					return
+				}
+				log.Printf("Permission: Failed to get user role: %v", err)
+				utils.RespondJSON(w, http.StatusUnauthorized, "User not found", nil)
+				return
+			}
+			if !role.Can(permission) {
+				utils.RespondJSON(w, http.StatusForbidden, "Permission denied", nil)
+				return
+			}
+
+			// A personal access token also needs the permission in its scopes
+			if scopes, ok := utils.GetTokenScopesFromContext(r.Context()); ok && !slices.Contains(scopes, string(permission)) {
+				utils.RespondJSON(w, http.StatusForbidden, "Token is not scoped for this", nil)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"task2/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// taskKeyPaths are the path prefixes followed by a task ID
+var taskKeyPaths = []string{"/api/v1/tasks/", "/api/v1/trash/"}
+
+// TaskKeyResolver gets the UUID of the task with a short key
+type TaskKeyResolver func(ctx context.Context, key string) (uuid.UUID, error)
+
+// TaskKeyMiddleware lets task routes take a short task key, such as OPS-123, in place of the task UUID
+type TaskKeyMiddleware struct {
+	resolve TaskKeyResolver
+}
+
+// NewTaskKeyMiddleware creates a new task key middleware
+func NewTaskKeyMiddleware(resolve TaskKeyResolver) *TaskKeyMiddleware {
+	return &TaskKeyMiddleware{
+		resolve: resolve,
+	}
+}
+
+// Middleware rewrites a task key in the request path to the task's UUID before routing.
+// Unknown keys become the nil UUID, so handlers report the task as not found once the caller is authenticated.
+func (m *TaskKeyMiddleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, prefix := range taskKeyPaths {
+			rest, ok := strings.CutPrefix(r.URL.Path, prefix)
+			if !ok {
+				continue
+			}
+			
+			// Check if the task ID segment is a key
+			segment, tail, hasTail := strings.Cut(rest, "/")
+			key, ok := entity.ParseTaskKey(segment)
+			if !ok {
+				break
+			}
+			
+			taskUUID, err := m.resolve(r.Context(), key)
+			if err != nil {
+				taskUUID = uuid.Nil
+			}
+			
+			// Rewrite the path
+			path := prefix + taskUUID.String()
+			if hasTail {
+				path += "/" + tail
+			}
+			
+			r = r.Clone(r.Context())
+			r.URL.Path = path
+			r.URL.RawPath = ""
+			break
+		}
+		
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"task2/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// EmailVerifiedChecker checks if a user has verified their email
+type EmailVerifiedChecker func(ctx context.Context, userUUID uuid.UUID) (bool, error)
+
+// VerifiedEmailMiddleware restricts routes to users who have verified their email
+type VerifiedEmailMiddleware struct {
+	isVerified EmailVerifiedChecker
+}
+
+// NewVerifiedEmailMiddleware creates a new verified email middleware
+func NewVerifiedEmailMiddleware(isVerified EmailVerifiedChecker) *VerifiedEmailMiddleware {
+	return &VerifiedEmailMiddleware{
+		isVerified: isVerified,
+	}
+}
+
+// Middleware rejects requests from users who have not verified their email.
+// It must run after AuthMiddleware, which puts the user in the context.
+func (m *VerifiedEmailMiddleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		verified, err := m.isVerified(r.Context(), utils.GetUserUUIDFromRequest(r))
+		if err != nil {
+			log.Printf("VerifiedEmail: Failed to check user: %v", err)
+			utils.RespondJSON(w, http.StatusUnauthorized, "User not found", nil)
+			return
+		}
+		if !verified {
+			utils.RespondJSON(w, http.StatusForbidden, "Verify your email address first", nil)
+			return
+		}
+		
+		next.ServeHTTP(w, r)
+	})
+}
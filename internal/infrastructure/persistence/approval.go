@@ -0,0 +1,21 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type ApprovalDecision struct {
+	bun.BaseModel `bun:"table:task_approvals,alias:ta"`
+
+	ID         int64     `bun:",pk,autoincrement"`
+	UUID       uuid.UUID `bun:",type:uuid,default:uuid_generate_v4()" json:"id"`
+	TaskID     uuid.UUID `bun:",type:uuid,notnull" json:"task_id"`
+	ReviewerID uuid.UUID `bun:",type:uuid,notnull" json:"reviewer_id"`
+	Round      int       `bun:",notnull" json:"round"`
+	Decision   string    `bun:",notnull" json:"decision"`
+	Comment    string    `json:"comment"`
+	CreatedAt  time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,51 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type AutomationRule struct {
+	bun.BaseModel `bun:"table:automation_rules"`
+
+	ID          int64                 `bun:",pk,autoincrement"`
+	UUID        uuid.UUID             `bun:",type:uuid,default:uuid_generate_v4()" json:"id"`
+	OwnerID     uuid.UUID             `bun:",type:uuid,notnull" json:"owner_id"`
+	Name        string                `bun:",notnull" json:"name"`
+	Trigger     string                `bun:",notnull" json:"trigger"`
+	OverdueDays int                   `bun:",notnull,default:0" json:"overdue_days"`
+	Conditions  []AutomationCondition `bun:",type:jsonb,notnull,default:'[]'" json:"conditions"`
+	Actions     []AutomationAction    `bun:",type:jsonb,notnull,default:'[]'" json:"actions"`
+	Enabled     bool                  `bun:",notnull" json:"enabled"`
+	CreatedAt   time.Time             `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt   time.Time             `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+type AutomationCondition struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Value    string `json:"value,omitempty"`
+}
+
+type AutomationAction struct {
+	Type    string     `json:"type"`
+	Target  string     `json:"target,omitempty"`
+	UserID  *uuid.UUID `json:"user_id,omitempty"`
+	Message string     `json:"message,omitempty"`
+}
+
+type AutomationExecution struct {
+	bun.BaseModel `bun:"table:automation_executions"`
+
+	ID        int64     `bun:",pk,autoincrement"`
+	UUID      uuid.UUID `bun:",type:uuid,default:uuid_generate_v4()" json:"id"`
+	RuleID    int64     `bun:",notnull" json:"rule_id"`
+	TaskID    uuid.UUID `bun:",type:uuid,notnull" json:"task_id"`
+	Trigger   string    `bun:",notnull" json:"trigger"`
+	Status    string    `bun:",notnull" json:"status"`
+	Error     string    `json:"error,omitempty"`
+	DedupeKey string    `bun:",nullzero,unique" json:"-"`
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,33 @@
+package persistence
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type CustomField struct {
+	bun.BaseModel `bun:"table:custom_fields,alias:cf"`
+
+	ID        int64     `bun:",pk,autoincrement"`
+	UUID      uuid.UUID `bun:",type:uuid,default:uuid_generate_v4()" json:"id"`
+	OwnerID   uuid.UUID `bun:",type:uuid,notnull" json:"owner_id"`
+	Name      string    `bun:",notnull" json:"name"`
+	Type      string    `bun:",notnull" json:"type"`
+	Options   []string  `bun:",type:jsonb,notnull,default:'[]'" json:"options"`
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+type TaskFieldValue struct {
+	bun.BaseModel `bun:"table:task_field_values,alias:tfv"`
+
+	TaskID    int64           `bun:",pk"`
+	FieldID   int64           `bun:",pk"`
+	Value     json.RawMessage `bun:",type:jsonb,notnull" json:"value"`
+	UpdatedAt time.Time       `bun:",nullzero,notnull,default:current_timestamp"`
+
+	Field *CustomField `bun:"rel:belongs-to,join:field_id=id"`
+}
@@ -0,0 +1,22 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type Notification struct {
+	bun.BaseModel `bun:"table:notifications"`
+
+	ID        int64      `bun:",pk,autoincrement"`
+	UUID      uuid.UUID  `bun:",type:uuid,default:uuid_generate_v4()" json:"id"`
+	UserID    uuid.UUID  `bun:",type:uuid,notnull" json:"user_id"`
+	Type      string     `bun:",notnull" json:"type"`
+	Title     string     `bun:",notnull" json:"title"`
+	Message   string     `json:"message"`
+	TaskID    *uuid.UUID `bun:",type:uuid" json:"task_id,omitempty"`
+	ReadAt    *time.Time `bun:",nullzero" json:"read_at,omitempty"`
+	CreatedAt time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
+}
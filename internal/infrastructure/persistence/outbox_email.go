@@ -0,0 +1,23 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type OutboxEmail struct {
+	bun.BaseModel `bun:"table:email_outbox"`
+
+	ID            int64      `bun:",pk,autoincrement"`
+	UUID          uuid.UUID  `bun:",type:uuid,default:uuid_generate_v4()" json:"id"`
+	To            string     `bun:"recipient,notnull" json:"to"`
+	Subject       string     `bun:",notnull" json:"subject"`
+	Body          string     `bun:",notnull" json:"body"`
+	Attempts      int        `bun:",notnull,default:0" json:"attempts"`
+	LastError     string     `bun:",nullzero" json:"last_error,omitempty"`
+	NextAttemptAt time.Time  `bun:",notnull,default:current_timestamp" json:"next_attempt_at"`
+	SentAt        *time.Time `bun:",nullzero" json:"sent_at,omitempty"`
+	CreatedAt     time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,22 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type OwnershipTransfer struct {
+	bun.BaseModel `bun:"table:ownership_transfers,alias:ot"`
+
+	ID            int64      `bun:",pk,autoincrement"`
+	UUID          uuid.UUID  `bun:",type:uuid,default:uuid_generate_v4()" json:"id"`
+	TaskID        uuid.UUID  `bun:",type:uuid,notnull" json:"task_id"`
+	FromUserID    uuid.UUID  `bun:",type:uuid,notnull" json:"from_user_id"`
+	ToUserID      uuid.UUID  `bun:",type:uuid,notnull" json:"to_user_id"`
+	RequestedByID uuid.UUID  `bun:",type:uuid,notnull" json:"requested_by_id"`
+	Status        string     `bun:",notnull" json:"status"`
+	CreatedAt     time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
+	RespondedAt   *time.Time `bun:",nullzero" json:"responded_at,omitempty"`
+}
@@ -0,0 +1,19 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type PasswordResetToken struct {
+	bun.BaseModel `bun:"table:password_reset_tokens,alias:prt"`
+
+	ID        int64      `bun:",pk,autoincrement"`
+	UserID    uuid.UUID  `bun:",type:uuid,notnull" json:"user_id"`
+	TokenHash string     `bun:",notnull,unique" json:"-"`
+	ExpiresAt time.Time  `bun:",notnull" json:"expires_at"`
+	UsedAt    *time.Time `bun:",nullzero" json:"used_at"`
+	CreatedAt time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
+}
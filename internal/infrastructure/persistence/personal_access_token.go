@@ -0,0 +1,26 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type PersonalAccessToken struct {
+	bun.BaseModel `bun:"table:personal_access_tokens,alias:pat"`
+
+	ID         int64      `bun:",pk,autoincrement"`
+	UUID       uuid.UUID  `bun:",type:uuid,notnull,unique" json:"id"`
+	UserID     uuid.UUID  `bun:",type:uuid,notnull" json:"user_id"`
+	Name       string     `bun:",notnull" json:"name"`
+	Scopes     []string   `bun:",type:jsonb,notnull" json:"scopes"`
+	TokenHash  string     `bun:",notnull,unique" json:"-"`
+	Hint       string     `bun:",notnull" json:"hint"`
+	ExpiresAt  time.Time  `bun:",notnull" json:"expires_at"`
+	LastUsedAt *time.Time `bun:",nullzero" json:"last_used_at"`
+	LastUsedIP string     `bun:",nullzero" json:"last_used_ip"`
+	RevokedAt  *time.Time `bun:",nullzero" json:"revoked_at"`
+	CreatedAt  time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt  time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,21 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type RefreshToken struct {
+	bun.BaseModel `bun:"table:refresh_tokens,alias:rt"`
+
+	ID        int64      `bun:",pk,autoincrement"`
+	UserID    uuid.UUID  `bun:",type:uuid,notnull" json:"user_id"`
+	FamilyID  uuid.UUID  `bun:",type:uuid,notnull" json:"family_id"`
+	TokenHash string     `bun:",notnull,unique" json:"-"`
+	ExpiresAt time.Time  `bun:",notnull" json:"expires_at"`
+	UsedAt    *time.Time `bun:",nullzero" json:"used_at"`
+	RevokedAt *time.Time `bun:",nullzero" json:"revoked_at"`
+	CreatedAt time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,22 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type Reminder struct {
+	bun.BaseModel `bun:"table:reminders"`
+
+	ID               int64       `bun:",pk,autoincrement"`
+	UUID             uuid.UUID   `bun:",type:uuid,default:uuid_generate_v4()" json:"id"`
+	TaskID           uuid.UUID   `bun:",type:uuid,notnull" json:"task_id"`
+	CreatedByID      uuid.UUID   `bun:",type:uuid,notnull" json:"created_by_id"`
+	RemindAt         *time.Time  `bun:",nullzero" json:"remind_at,omitempty"`
+	MinutesBeforeDue *int        `json:"minutes_before_due,omitempty"`
+	Recipients       []uuid.UUID `bun:",type:jsonb,notnull" json:"recipients"`
+	SentAt           *time.Time  `bun:",nullzero" json:"sent_at,omitempty"`
+	CreatedAt        time.Time   `bun:",nullzero,notnull,default:current_timestamp"`
+}
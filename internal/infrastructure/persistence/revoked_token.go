@@ -0,0 +1,24 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type RevokedToken struct {
+	bun.BaseModel `bun:"table:revoked_tokens,alias:revoked"`
+
+	TokenID   uuid.UUID `bun:"jti,pk,type:uuid" json:"jti"`
+	UserID    uuid.UUID `bun:",type:uuid,notnull" json:"user_id"`
+	ExpiresAt time.Time `bun:",notnull" json:"expires_at"`
+	RevokedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"revoked_at"`
+}
+
+type UserTokenRevocation struct {
+	bun.BaseModel `bun:"table:user_token_revocations,alias:utr"`
+
+	UserID        uuid.UUID `bun:",pk,type:uuid" json:"user_id"`
+	RevokedBefore time.Time `bun:",notnull" json:"revoked_before"`
+}
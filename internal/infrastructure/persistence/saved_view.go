@@ -0,0 +1,42 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type SavedView struct {
+	bun.BaseModel `bun:"table:saved_views"`
+
+	ID          int64      `bun:",pk,autoincrement"`
+	UUID        uuid.UUID  `bun:",type:uuid,default:uuid_generate_v4()" json:"id"`
+	OwnerID     uuid.UUID  `bun:",type:uuid,notnull" json:"owner_id"`
+	Name        string     `bun:",notnull" json:"name"`
+	Filter      TaskFilter `bun:",type:jsonb,notnull" json:"filter"`
+	SortBy      string     `bun:",notnull" json:"sort_by"`
+	SortDesc    bool       `bun:",notnull" json:"sort_desc"`
+	SortFieldID *uuid.UUID `bun:",type:uuid" json:"sort_field_id,omitempty"`
+	Columns     []string   `bun:",type:jsonb,notnull" json:"columns"`
+	Pinned      bool       `bun:",notnull" json:"pinned"`
+	CreatedAt   time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt   time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+type TaskFilter struct {
+	Completed  *bool      `json:"completed,omitempty"`
+	CreatedBy  *uuid.UUID `json:"created_by,omitempty"`
+	AssignedTo *uuid.UUID `json:"assigned_to,omitempty"`
+	Search     string     `json:"search,omitempty"`
+	DueBefore  *time.Time `json:"due_before,omitempty"`
+	DueAfter   *time.Time `json:"due_after,omitempty"`
+	HasDueDate *bool      `json:"has_due_date,omitempty"`
+
+	CustomFields []CustomFieldFilter `json:"custom_fields,omitempty"`
+}
+
+type CustomFieldFilter struct {
+	FieldID uuid.UUID `json:"field_id"`
+	Value   any       `json:"value"`
+}
@@ -0,0 +1,18 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type Snooze struct {
+	bun.BaseModel `bun:"table:task_snoozes,alias:snooze"`
+
+	ID        int64     `bun:",pk,autoincrement"`
+	TaskID    uuid.UUID `bun:",type:uuid,notnull" json:"task_id"`
+	UserID    uuid.UUID `bun:",type:uuid,notnull" json:"user_id"`
+	Until     time.Time `bun:"snoozed_until,notnull" json:"snoozed_until"`
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
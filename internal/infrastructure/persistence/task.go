@@ -15,9 +15,18 @@ type Task struct {
 	Title       string     `bun:",notnull" json:"title"`
 	Description string     `json:"description"`
 	Completed   bool       `bun:",default:false"`
-	CreatedAt   time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
-	UpdatedAt   time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
-	DeletedAt   *time.Time `bun:",soft_delete" json:"deleted_at,omitempty"`
+	ShortKey    string     `bun:",nullzero" json:"key"`
+	ExternalID  string     `bun:",nullzero" json:"external_id,omitempty"`
+	DueDate     *time.Time `bun:",nullzero" json:"due_date,omitempty"`
+	Version     int64      `bun:",notnull,default:1" json:"version"`
+
+	RequiredApprovals int  `bun:",notnull,default:0" json:"required_approvals"`
+	AwaitingApproval  bool `bun:",notnull,default:false" json:"awaiting_approval"`
+	ApprovalRound     int  `bun:",notnull,default:0" json:"approval_round"`
+
+	CreatedAt time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
+	DeletedAt *time.Time `bun:",soft_delete" json:"deleted_at,omitempty"`
 
 	CreatedByID uuid.UUID `bun:",type:uuid,notnull"`
 	CreatedBy   *User     `bun:"rel:belongs-to,join:created_by_id=uuid"`
@@ -25,5 +34,12 @@ type Task struct {
 	AssignedToID *uuid.UUID `bun:",type:uuid"`
 	AssignedTo   *User      `bun:"rel:belongs-to,join:assigned_to_id=uuid"`
 
-	Users []*User `bun:"m2m:user_tasks" json:"users,omitempty"`
+	Users     []*User `bun:"m2m:user_tasks" json:"users,omitempty"`
+	Mentions  []*User `bun:"m2m:task_mentions" json:"mentions,omitempty"`
+	Reviewers []*User `bun:"m2m:task_reviewers" json:"reviewers,omitempty"`
+
+	OutgoingLinks []*TaskLink `bun:"rel:has-many,join:uuid=source_task_id" json:"-"`
+	IncomingLinks []*TaskLink `bun:"rel:has-many,join:uuid=target_task_id" json:"-"`
+
+	FieldValues []*TaskFieldValue `bun:"rel:has-many,join:id=task_id" json:"-"`
 }
@@ -0,0 +1,13 @@
+package persistence
+
+import (
+	"github.com/uptrace/bun"
+)
+
+// TaskKeySequence holds the last task number handed out under a key prefix
+type TaskKeySequence struct {
+	bun.BaseModel `bun:"table:task_key_sequences"`
+
+	Prefix     string `bun:",pk"`
+	LastNumber int64  `bun:",notnull"`
+}
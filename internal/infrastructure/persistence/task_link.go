@@ -0,0 +1,23 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type TaskLink struct {
+	bun.BaseModel `bun:"table:task_links,alias:tl"`
+
+	ID           int64     `bun:",pk,autoincrement"`
+	UUID         uuid.UUID `bun:",type:uuid,default:uuid_generate_v4()" json:"id"`
+	SourceTaskID uuid.UUID `bun:",type:uuid,notnull" json:"source_task_id"`
+	TargetTaskID uuid.UUID `bun:",type:uuid,notnull" json:"target_task_id"`
+	Type         string    `bun:",notnull" json:"type"`
+	CreatedByID  uuid.UUID `bun:",type:uuid,notnull" json:"created_by_id"`
+	CreatedAt    time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+
+	SourceTask *Task `bun:"rel:belongs-to,join:source_task_id=uuid"`
+	TargetTask *Task `bun:"rel:belongs-to,join:target_task_id=uuid"`
+}
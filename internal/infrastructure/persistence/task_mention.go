@@ -0,0 +1,18 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type TaskMention struct {
+	bun.BaseModel `bun:"table:task_mentions,alias:tm"`
+
+	TaskID    int64     `bun:",pk"`
+	UserID    int64     `bun:",pk"`
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+
+	Task *Task `bun:"rel:belongs-to,join:task_id=id"`
+	User *User `bun:"rel:belongs-to,join:user_id=id"`
+}
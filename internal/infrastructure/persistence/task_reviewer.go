@@ -0,0 +1,15 @@
+package persistence
+
+import (
+	"github.com/uptrace/bun"
+)
+
+type TaskReviewer struct {
+	bun.BaseModel `bun:"table:task_reviewers,alias:tr"`
+
+	TaskID int64 `bun:",pk"`
+	UserID int64 `bun:",pk"`
+
+	Task *Task `bun:"rel:belongs-to,join:task_id=id"`
+	User *User `bun:"rel:belongs-to,join:user_id=id"`
+}
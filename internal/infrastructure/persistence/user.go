@@ -10,14 +10,19 @@ import (
 type User struct {
 	bun.BaseModel `bun:"table:users"`
 
-	ID        int64      `bun:",pk,autoincrement"`
-	UUID      uuid.UUID  `bun:",type:uuid,default:uuid_generate_v4()" json:"id"`
-	Name      string     `bun:",notnull" json:"name" validate:"required"`
-	Email     string     `bun:",unique,notnull" json:"email" validate:"required,email"`
-	Password  string     `bun:",notnull" json:"password,omitempty" validate:"required,min=6"`
-	CreatedAt time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
-	UpdatedAt time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
-	DeletedAt *time.Time `bun:",soft_delete" json:"deleted_at,omitempty"`
+	ID                 int64      `bun:",pk,autoincrement"`
+	UUID               uuid.UUID  `bun:",type:uuid,default:uuid_generate_v4()" json:"id"`
+	Name               string     `bun:",notnull" json:"name" validate:"required"`
+	Email              string     `bun:",unique,notnull" json:"email" validate:"required,email"`
+	Password           string     `bun:",notnull" json:"password,omitempty" validate:"required,min=6"`
+	CalendarTokenHash  string     `bun:",nullzero,unique" json:"-"`
+	EmailVerified      bool       `bun:",notnull,default:false" json:"email_verified"`
+	VerificationSentAt *time.Time `bun:",nullzero" json:"-"`
+	Role               string     `bun:",notnull,default:'member'" json:"role"`
+	SuspendedAt        *time.Time `bun:",nullzero" json:"suspended_at,omitempty"`
+	CreatedAt          time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt          time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
+	DeletedAt          *time.Time `bun:",soft_delete" json:"deleted_at,omitempty"`
 
 	Tasks []*Task `bun:"m2m:user_tasks" json:"tasks,omitempty"`
 }
@@ -6,24 +6,29 @@ import (
 	"strings"
 	"task2/internal/adapter/controller"
 	"task2/internal/app/dto"
+	"task2/internal/domain/entity"
 	"task2/internal/infrastructure/middleware"
 )
 
 // Router handles HTTP routing
 type Router struct {
-	mux               *http.ServeMux
-	authMiddleware    *middleware.AuthMiddleware
-	loggingMiddleware *middleware.LoggingMiddleware
-	corsMiddleware    *middleware.CorsMiddleware
-	logger            *log.Logger
+	mux                     *http.ServeMux
+	authMiddleware          *middleware.AuthMiddleware
+	permissionMiddleware    *middleware.PermissionMiddleware
+	loggingMiddleware       *middleware.LoggingMiddleware
+	corsMiddleware          *middleware.CorsMiddleware
+	taskKeyMiddleware       *middleware.TaskKeyMiddleware
+	verifiedEmailMiddleware *middleware.VerifiedEmailMiddleware
+	logger                  *log.Logger
 }
 
 // NewRouter creates a new router
-func NewRouter(authMiddleware *middleware.AuthMiddleware) *Router {
+func NewRouter(authMiddleware *middleware.AuthMiddleware, permissionMiddleware *middleware.PermissionMiddleware) *Router {
 	return &Router{
-		mux:            http.NewServeMux(),
-		authMiddleware: authMiddleware,
-		logger:         log.New(log.Writer(), "[ROUTER] ", log.LstdFlags),
+		mux:                  http.NewServeMux(),
+		authMiddleware:       authMiddleware,
+		permissionMiddleware: permissionMiddleware,
+		logger:               log.New(log.Writer(), "[ROUTER] ", log.LstdFlags),
 	}
 }
 
@@ -37,6 +42,16 @@ func (r *Router) SetCorsMiddleware(corsMiddleware *middleware.CorsMiddleware) {
 	r.corsMiddleware = corsMiddleware
 }
 
+// SetTaskKeyMiddleware sets the middleware that lets task routes take short task keys
+func (r *Router) SetTaskKeyMiddleware(taskKeyMiddleware *middleware.TaskKeyMiddleware) {
+	r.taskKeyMiddleware = taskKeyMiddleware
+}
+
+// SetVerifiedEmailMiddleware sets the middleware that restricts sensitive routes to users with a verified email
+func (r *Router) SetVerifiedEmailMiddleware(verifiedEmailMiddleware *middleware.VerifiedEmailMiddleware) {
+	r.verifiedEmailMiddleware = verifiedEmailMiddleware
+}
+
 // RegisterUserRoutes registers user routes
 func (r *Router) RegisterUserRoutes(userController *controller.UserController) {
 	r.logger.Println("Registering user routes")
@@ -74,17 +89,118 @@ func (r *Router) RegisterUserRoutes(userController *controller.UserController) {
 			}
 		})))
 
-	// Profile handler
+	// Verify email handler, authenticated by the token in the link
+	r.mux.Handle("/api/v1/verify-email", r.wrapHandler(
+		middleware.MethodCheck("GET")(
+			http.HandlerFunc(userController.VerifyEmail))))
+
+	// Resend verification email handler
+	r.mux.Handle("/api/v1/verify-email/resend", r.wrapHandler(
+		middleware.MethodCheck("POST")(
+			middleware.BindAndValidate(&dto.ResendVerificationRequest{})(
+				http.HandlerFunc(userController.ResendVerificationEmail)))))
+
+	// Forgot password handler
+	r.mux.Handle("/api/v1/password/forgot", r.wrapHandler(
+		middleware.MethodCheck("POST")(
+			middleware.BindAndValidate(&dto.ForgotPasswordRequest{})(
+				http.HandlerFunc(userController.ForgotPassword)))))
+
+	// Reset password handler, authenticated by the token from the reset email
+	r.mux.Handle("/api/v1/password/reset", r.wrapHandler(
+		middleware.MethodCheck("POST")(
+			middleware.BindAndValidate(&dto.ResetPasswordRequest{})(
+				http.HandlerFunc(userController.ResetPassword)))))
+
+	// Refresh token handler, authenticated by the refresh token itself
+	r.mux.Handle("/api/v1/token/refresh", r.wrapHandler(
+		middleware.MethodCheck("POST")(
+			http.HandlerFunc(userController.RefreshToken))))
+
+	// Logout handler
+	r.mux.Handle("/api/v1/logout", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.sessionOnly(
+				middleware.MethodCheck("POST")(
+					http.HandlerFunc(userController.Logout))))))
+
+	// Logout everywhere handler
+	r.mux.Handle("/api/v1/logout/all", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.sessionOnly(
+				middleware.MethodCheck("POST")(
+					http.HandlerFunc(userController.LogoutEverywhere))))))
+
+	// Get profile and update profile handler
 	r.mux.Handle("/api/v1/profile", r.wrapHandler(
 		r.authMiddleware.Middleware(
-			middleware.MethodCheck("GET")(
-				http.HandlerFunc(userController.GetProfile)))))
+			r.sessionOnly(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.Method {
+					case "GET":
+						userController.GetProfile(w, r)
+					case "PATCH":
+						middleware.BindAndValidate(&dto.UpdateProfileRequest{})(
+							http.HandlerFunc(userController.UpdateProfile)).ServeHTTP(w, r)
+					default:
+						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					}
+				})))))
+
+	// Change password handler
+	r.mux.Handle("/api/v1/profile/password", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.sessionOnly(
+				middleware.MethodCheck("PUT")(
+					middleware.BindAndValidate(&dto.ChangePasswordRequest{})(
+						http.HandlerFunc(userController.ChangePassword)))))))
 
 	// Users handler
 	r.mux.Handle("/api/v1/users", r.wrapHandler(
 		r.authMiddleware.Middleware(
-			middleware.MethodCheck("GET")(
-				http.HandlerFunc(userController.GetAllUsers)))))
+			r.authorize(entity.PermissionUsersList,
+				middleware.MethodCheck("GET")(
+					http.HandlerFunc(userController.GetAllUsers))))))
+
+	// Admin: list users handler
+	r.mux.Handle("/api/v1/admin/users", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.authorize(entity.PermissionUsersManage,
+				middleware.MethodCheck("GET")(
+					http.HandlerFunc(userController.GetAllUsers))))))
+
+	// Admin: delete user handler
+	r.mux.Handle("/api/v1/admin/users/{id}", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.authorize(entity.PermissionUsersManage,
+				r.sensitive(
+					middleware.MethodCheck("DELETE")(
+						http.HandlerFunc(userController.DeleteUser)))))))
+
+	// Admin: change role handler
+	r.mux.Handle("/api/v1/admin/users/{id}/role", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.authorize(entity.PermissionUsersManage,
+				r.sensitive(
+					middleware.MethodCheck("PUT")(
+						middleware.BindAndValidate(&dto.ChangeRoleRequest{})(
+							http.HandlerFunc(userController.ChangeRole))))))))
+
+	// Admin: suspend user handler
+	r.mux.Handle("/api/v1/admin/users/{id}/suspend", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.authorize(entity.PermissionUsersManage,
+				r.sensitive(
+					middleware.MethodCheck("POST")(
+						http.HandlerFunc(userController.SuspendUser)))))))
+
+	// Admin: reactivate user handler
+	r.mux.Handle("/api/v1/admin/users/{id}/reactivate", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.authorize(entity.PermissionUsersManage,
+				r.sensitive(
+					middleware.MethodCheck("POST")(
+						http.HandlerFunc(userController.ReactivateUser)))))))
 
 	// Add a debug cookie endpoint
 	r.mux.Handle("/api/v1/debug/cookie", r.wrapHandler(
@@ -126,52 +242,517 @@ func (r *Router) RegisterTaskRoutes(taskController *controller.TaskController) {
 	// Create task handler and Get all tasks handler
 	r.mux.Handle("/api/v1/tasks", r.wrapHandler(
 		r.authMiddleware.Middleware(
-			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				if r.Method == "POST" {
-					// Create task using BindAndValidate middleware
-					middleware.BindAndValidate(&dto.CreateTaskRequest{})(
-						http.HandlerFunc(taskController.CreateTask)).ServeHTTP(w, r)
-				} else if r.Method == "GET" {
-					// Get all tasks
-					taskController.GetAllTasks(w, r)
-				} else {
-					http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-				}
-			}))))
+			r.taskAccess(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.Method == "POST" {
+						// Create task using BindAndValidate middleware
+						middleware.BindAndValidate(&dto.CreateTaskRequest{})(
+							http.HandlerFunc(taskController.CreateTask)).ServeHTTP(w, r)
+					} else if r.Method == "GET" {
+						// Get all tasks
+						taskController.GetAllTasks(w, r)
+					} else {
+						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					}
+				})))))
 
 	// Get tasks created by user handler
 	r.mux.Handle("/api/v1/tasks/created", r.wrapHandler(
 		r.authMiddleware.Middleware(
-			middleware.MethodCheck("GET")(
-				http.HandlerFunc(taskController.GetTasksCreatedByUser)))))
+			r.taskAccess(
+				middleware.MethodCheck("GET")(
+					http.HandlerFunc(taskController.GetTasksCreatedByUser))))))
 
 	// Get tasks assigned to user handler
 	r.mux.Handle("/api/v1/tasks/assigned", r.wrapHandler(
 		r.authMiddleware.Middleware(
-			middleware.MethodCheck("GET")(
-				http.HandlerFunc(taskController.GetTasksAssignedToUser)))))
+			r.taskAccess(
+				middleware.MethodCheck("GET")(
+					http.HandlerFunc(taskController.GetTasksAssignedToUser))))))
+
+	// Quick-add task handler
+	r.mux.Handle("/api/v1/tasks/quick", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				middleware.MethodCheck("POST")(
+					middleware.BindAndValidate(&dto.QuickAddRequest{})(
+						http.HandlerFunc(taskController.QuickAddTask)))))))
+
+	// Get tasks awaiting the user's review handler
+	r.mux.Handle("/api/v1/tasks/reviews", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				middleware.MethodCheck("GET")(
+					http.HandlerFunc(taskController.GetTasksAwaitingReview))))))
 
-	// Get task by ID, Delete task, Complete task, and Assign task handlers
+	// Export tasks handler
+	r.mux.Handle("/api/v1/tasks/export", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				middleware.MethodCheck("GET")(
+					http.HandlerFunc(taskController.ExportTasks))))))
+
+	// Import tasks handler
+	r.mux.Handle("/api/v1/tasks/import", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				middleware.MethodCheck("POST")(
+					http.HandlerFunc(taskController.ImportTasks))))))
+
+	// Bulk task operations handler
+	r.mux.Handle("/api/v1/tasks/bulk", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				middleware.MethodCheck("POST")(
+					middleware.BindAndValidate(&dto.BulkTaskRequest{})(
+						http.HandlerFunc(taskController.BulkTaskOperation)))))))
+
+	// Get task by ID, Delete task, Duplicate task, Restore task, Complete task, Assign task,
+	// and review (reviewers, approve, reject, approvals) handlers
+	// Mutations honour If-Match against the task version
 	r.mux.Handle("/api/v1/tasks/", r.wrapHandler(
 		r.authMiddleware.Middleware(
-			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				switch r.Method {
-				case "GET":
-					taskController.GetTaskByID(w, r)
-				case "DELETE":
-					taskController.DeleteTask(w, r)
-				case "PUT":
-					if len(r.URL.Path) > 16 && r.URL.Path[len(r.URL.Path)-9:] == "/complete" {
-						taskController.CompleteTask(w, r)
-					} else if strings.Contains(r.URL.Path, "/assign/") {
-						taskController.AssignTask(w, r)
-					} else {
-						http.NotFound(w, r)
+			r.taskAccess(
+				middleware.IfMatch(
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						switch r.Method {
+						case "GET":
+							if strings.HasSuffix(r.URL.Path, "/approvals") {
+								taskController.GetApprovals(w, r)
+							} else {
+								taskController.GetTaskByID(w, r)
+							}
+						case "DELETE":
+							taskController.DeleteTask(w, r)
+						case "POST":
+							if strings.HasSuffix(r.URL.Path, "/approve") {
+								middleware.BindAndValidate(&dto.ReviewTaskRequest{})(
+									http.HandlerFunc(taskController.ApproveTask)).ServeHTTP(w, r)
+							} else if strings.HasSuffix(r.URL.Path, "/reject") {
+								middleware.BindAndValidate(&dto.ReviewTaskRequest{})(
+									http.HandlerFunc(taskController.RejectTask)).ServeHTTP(w, r)
+							} else if strings.HasSuffix(r.URL.Path, "/duplicate") {
+								middleware.BindAndValidate(&dto.DuplicateTaskRequest{})(
+									http.HandlerFunc(taskController.DuplicateTask)).ServeHTTP(w, r)
+							} else if strings.HasSuffix(r.URL.Path, "/restore") {
+								taskController.RestoreTask(w, r)
+							} else {
+								http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+							}
+						case "PUT":
+							if len(r.URL.Path) > 16 && r.URL.Path[len(r.URL.Path)-9:] == "/complete" {
+								taskController.CompleteTask(w, r)
+							} else if strings.Contains(r.URL.Path, "/assign/") {
+								taskController.AssignTask(w, r)
+							} else if strings.HasSuffix(r.URL.Path, "/reviewers") {
+								middleware.BindAndValidate(&dto.SetReviewersRequest{})(
+									http.HandlerFunc(taskController.SetReviewers)).ServeHTTP(w, r)
+							} else {
+								http.NotFound(w, r)
+							}
+						default:
+							http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+						}
+					}))))))
+
+	// Trash handler
+	r.mux.Handle("/api/v1/trash", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				middleware.MethodCheck("GET")(
+					http.HandlerFunc(taskController.GetTrash))))))
+
+	// Permanently delete task handler
+	r.mux.Handle("/api/v1/trash/", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				middleware.MethodCheck("DELETE")(
+					http.HandlerFunc(taskController.PurgeTask))))))
+
+	// Link task handler
+	r.mux.Handle("/api/v1/tasks/{id}/links", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				middleware.MethodCheck("POST")(
+					middleware.BindAndValidate(&dto.LinkTaskRequest{})(
+						http.HandlerFunc(taskController.LinkTask)))))))
+
+	// Delete link handler
+	r.mux.Handle("/api/v1/tasks/{id}/links/{linkID}", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				middleware.MethodCheck("DELETE")(
+					http.HandlerFunc(taskController.UnlinkTask))))))
+}
+
+// RegisterCalendarRoutes registers calendar feed routes
+func (r *Router) RegisterCalendarRoutes(calendarController *controller.CalendarController) {
+	r.logger.Println("Registering calendar routes")
+
+	// Regenerate calendar token handler
+	r.mux.Handle("/api/v1/calendar/token", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.sessionOnly(
+				r.sensitive(
+					middleware.MethodCheck("POST")(
+						http.HandlerFunc(calendarController.RegenerateToken)))))))
+
+	// Calendar feed handler, authenticated by the token in the path
+	r.mux.Handle("/api/v1/calendar/", r.wrapHandler(
+		middleware.MethodCheck("GET")(
+			http.HandlerFunc(calendarController.GetFeed))))
+}
+
+// RegisterNotificationRoutes registers notification routes
+func (r *Router) RegisterNotificationRoutes(notificationController *controller.NotificationController) {
+	r.logger.Println("Registering notification routes")
+
+	// List notifications handler
+	r.mux.Handle("/api/v1/notifications", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				middleware.MethodCheck("GET")(
+					http.HandlerFunc(notificationController.GetNotifications))))))
+
+	// Mark all notifications as read handler
+	r.mux.Handle("/api/v1/notifications/read", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				middleware.MethodCheck("PUT")(
+					http.HandlerFunc(notificationController.MarkAllRead))))))
+
+	// Mark notification as read handler
+	r.mux.Handle("/api/v1/notifications/", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				middleware.MethodCheck("PUT")(
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if !strings.HasSuffix(r.URL.Path, "/read") {
+							http.NotFound(w, r)
+							return
+						}
+						notificationController.MarkRead(w, r)
+					}))))))
+}
+
+// RegisterAutomationRoutes registers automation rule routes
+func (r *Router) RegisterAutomationRoutes(automationController *controller.AutomationController) {
+	r.logger.Println("Registering automation routes")
+
+	// List and create rules handler
+	r.mux.Handle("/api/v1/automations", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.Method {
+					case "GET":
+						automationController.GetRules(w, r)
+					case "POST":
+						middleware.BindAndValidate(&dto.AutomationRuleRequest{})(
+							http.HandlerFunc(automationController.CreateRule)).ServeHTTP(w, r)
+					default:
+						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					}
+				})))))
+
+	// Get, replace and delete rule, and execution log handlers
+	r.mux.Handle("/api/v1/automations/", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if strings.HasSuffix(r.URL.Path, "/executions") {
+						middleware.MethodCheck("GET")(
+							http.HandlerFunc(automationController.GetExecutions)).ServeHTTP(w, r)
+						return
 					}
-				default:
-					http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-				}
-			}))))
+
+					switch r.Method {
+					case "GET":
+						automationController.GetRule(w, r)
+					case "PUT":
+						middleware.BindAndValidate(&dto.AutomationRuleRequest{})(
+							http.HandlerFunc(automationController.UpdateRule)).ServeHTTP(w, r)
+					case "DELETE":
+						automationController.DeleteRule(w, r)
+					default:
+						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					}
+				})))))
+}
+
+// RegisterCustomFieldRoutes registers custom field routes
+func (r *Router) RegisterCustomFieldRoutes(customFieldController *controller.CustomFieldController) {
+	r.logger.Println("Registering custom field routes")
+
+	// List and create fields handler
+	r.mux.Handle("/api/v1/custom-fields", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.Method {
+					case "GET":
+						customFieldController.GetFields(w, r)
+					case "POST":
+						middleware.BindAndValidate(&dto.CustomFieldRequest{})(
+							http.HandlerFunc(customFieldController.CreateField)).ServeHTTP(w, r)
+					default:
+						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					}
+				})))))
+
+	// Get, update and delete field handlers
+	r.mux.Handle("/api/v1/custom-fields/{id}", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.Method {
+					case "GET":
+						customFieldController.GetField(w, r)
+					case "PUT":
+						middleware.BindAndValidate(&dto.UpdateCustomFieldRequest{})(
+							http.HandlerFunc(customFieldController.UpdateField)).ServeHTTP(w, r)
+					case "DELETE":
+						customFieldController.DeleteField(w, r)
+					default:
+						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					}
+				})))))
+
+	// Set and clear task field value handlers
+	// Changes honour If-Match against the task version
+	r.mux.Handle("/api/v1/tasks/{id}/fields/{fieldID}", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				middleware.IfMatch(
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						switch r.Method {
+						case "PUT":
+							middleware.BindAndValidate(&dto.SetCustomFieldValueRequest{})(
+								http.HandlerFunc(customFieldController.SetValue)).ServeHTTP(w, r)
+						case "DELETE":
+							customFieldController.ClearValue(w, r)
+						default:
+							http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+						}
+					}))))))
+}
+
+// RegisterSavedViewRoutes registers saved view routes
+func (r *Router) RegisterSavedViewRoutes(savedViewController *controller.SavedViewController) {
+	r.logger.Println("Registering saved view routes")
+
+	// List and create views handler
+	r.mux.Handle("/api/v1/views", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.Method {
+					case "GET":
+						savedViewController.GetViews(w, r)
+					case "POST":
+						middleware.BindAndValidate(&dto.SavedViewRequest{})(
+							http.HandlerFunc(savedViewController.CreateView)).ServeHTTP(w, r)
+					default:
+						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					}
+				})))))
+
+	// Default task list handler
+	r.mux.Handle("/api/v1/views/default/tasks", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				middleware.MethodCheck("GET")(
+					http.HandlerFunc(savedViewController.GetDefaultTasks))))))
+
+	// Get, replace, delete, pin and run view handlers
+	r.mux.Handle("/api/v1/views/", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch {
+					case strings.HasSuffix(r.URL.Path, "/tasks"):
+						middleware.MethodCheck("GET")(
+							http.HandlerFunc(savedViewController.GetViewTasks)).ServeHTTP(w, r)
+					case strings.HasSuffix(r.URL.Path, "/pin") && r.Method == "PUT":
+						savedViewController.PinView(w, r)
+					case strings.HasSuffix(r.URL.Path, "/pin") && r.Method == "DELETE":
+						savedViewController.UnpinView(w, r)
+					case strings.HasSuffix(r.URL.Path, "/pin"):
+						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					case r.Method == "GET":
+						savedViewController.GetView(w, r)
+					case r.Method == "PUT":
+						middleware.BindAndValidate(&dto.SavedViewRequest{})(
+							http.HandlerFunc(savedViewController.UpdateView)).ServeHTTP(w, r)
+					case r.Method == "DELETE":
+						savedViewController.DeleteView(w, r)
+					default:
+						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					}
+				})))))
+}
+
+// RegisterReminderRoutes registers task reminder routes
+func (r *Router) RegisterReminderRoutes(reminderController *controller.ReminderController) {
+	r.logger.Println("Registering reminder routes")
+
+	// List and create reminders handler
+	r.mux.Handle("/api/v1/tasks/{id}/reminders", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.Method {
+					case "GET":
+						reminderController.GetReminders(w, r)
+					case "POST":
+						middleware.BindAndValidate(&dto.ReminderRequest{})(
+							http.HandlerFunc(reminderController.CreateReminder)).ServeHTTP(w, r)
+					default:
+						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					}
+				})))))
+
+	// Delete reminder handler
+	r.mux.Handle("/api/v1/tasks/{id}/reminders/{reminderID}", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				middleware.MethodCheck("DELETE")(
+					http.HandlerFunc(reminderController.DeleteReminder))))))
+}
+
+// RegisterSnoozeRoutes registers task snooze routes
+func (r *Router) RegisterSnoozeRoutes(snoozeController *controller.SnoozeController) {
+	r.logger.Println("Registering snooze routes")
+
+	// Snooze and unsnooze task handler
+	r.mux.Handle("/api/v1/tasks/{id}/snooze", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.Method {
+					case "PUT":
+						middleware.BindAndValidate(&dto.SnoozeRequest{})(
+							http.HandlerFunc(snoozeController.SnoozeTask)).ServeHTTP(w, r)
+					case "DELETE":
+						snoozeController.UnsnoozeTask(w, r)
+					default:
+						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					}
+				})))))
+}
+
+// RegisterTransferRoutes registers task ownership transfer routes
+func (r *Router) RegisterTransferRoutes(transferController *controller.TransferController) {
+	r.logger.Println("Registering transfer routes")
+
+	// Request transfer handler; an admin override honours If-Match against the task version
+	r.mux.Handle("/api/v1/tasks/{id}/transfer", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				r.sensitive(
+					middleware.MethodCheck("POST")(
+						middleware.IfMatch(
+							middleware.BindAndValidate(&dto.TransferRequest{})(
+								http.HandlerFunc(transferController.RequestTransfer)))))))))
+
+	// List pending transfers handler
+	r.mux.Handle("/api/v1/transfers", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				middleware.MethodCheck("GET")(
+					http.HandlerFunc(transferController.GetTransfers))))))
+
+	// Accept transfer handler
+	r.mux.Handle("/api/v1/transfers/{id}/accept", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				r.sensitive(
+					middleware.MethodCheck("PUT")(
+						http.HandlerFunc(transferController.AcceptTransfer)))))))
+
+	// Decline transfer handler
+	r.mux.Handle("/api/v1/transfers/{id}/decline", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				middleware.MethodCheck("PUT")(
+					http.HandlerFunc(transferController.DeclineTransfer))))))
+
+	// Cancel transfer handler
+	r.mux.Handle("/api/v1/transfers/{id}", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.taskAccess(
+				middleware.MethodCheck("DELETE")(
+					http.HandlerFunc(transferController.CancelTransfer))))))
+
+	// Offboarding handler: transfer all of a user's tasks (admins only)
+	r.mux.Handle("/api/v1/admin/users/{id}/transfer-tasks", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.authorize(entity.PermissionUsersManage,
+				r.sensitive(
+					middleware.MethodCheck("POST")(
+						middleware.BindAndValidate(&dto.BulkTransferRequest{})(
+							http.HandlerFunc(transferController.TransferAllTasks))))))))
+}
+
+// authorize restricts an authenticated handler to users whose role grants the permission
+func (r *Router) authorize(permission entity.Permission, handler http.Handler) http.Handler {
+	return r.permissionMiddleware.Require(permission)(handler)
+}
+
+// taskAccess restricts an authenticated task handler to users whose role may read tasks,
+// or change them for requests other than GET
+func (r *Router) taskAccess(handler http.Handler) http.Handler {
+	return r.permissionMiddleware.RequireByMethod(entity.PermissionTasksRead, entity.PermissionTasksWrite)(handler)
+}
+
+// RegisterPersonalAccessTokenRoutes registers personal access token routes
+func (r *Router) RegisterPersonalAccessTokenRoutes(tokenController *controller.PersonalAccessTokenController) {
+	r.logger.Println("Registering personal access token routes")
+
+	// List and create tokens handler
+	r.mux.Handle("/api/v1/access-tokens", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.sessionOnly(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.Method {
+					case "GET":
+						tokenController.GetTokens(w, r)
+					case "POST":
+						middleware.BindAndValidate(&dto.CreatePersonalAccessTokenRequest{})(
+							http.HandlerFunc(tokenController.CreateToken)).ServeHTTP(w, r)
+					default:
+						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					}
+				})))))
+
+	// Revoke token handler
+	r.mux.Handle("/api/v1/access-tokens/{id}", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.sessionOnly(
+				middleware.MethodCheck("DELETE")(
+					http.HandlerFunc(tokenController.RevokeToken))))))
+
+	// Rotate token handler
+	r.mux.Handle("/api/v1/access-tokens/{id}/rotate", r.wrapHandler(
+		r.authMiddleware.Middleware(
+			r.sessionOnly(
+				r.sensitive(
+					middleware.MethodCheck("POST")(
+						http.HandlerFunc(tokenController.RotateToken)))))))
+}
+
+// sessionOnly restricts an authenticated handler to login sessions, turning away personal access tokens
+func (r *Router) sessionOnly(handler http.Handler) http.Handler {
+	return r.authMiddleware.SessionOnly(handler)
+}
+
+// sensitive restricts an authenticated handler to users with a verified email, if that is required
+func (r *Router) sensitive(handler http.Handler) http.Handler {
+	if r.verifiedEmailMiddleware != nil {
+		return r.verifiedEmailMiddleware.Middleware(handler)
+	}
+
+	return handler
 }
 
 // wrapHandler wraps a handler with the logging middleware if available
@@ -191,5 +772,10 @@ func (r *Router) wrapHandler(handler http.Handler) http.Handler {
 
 // GetHandler returns the HTTP handler
 func (r *Router) GetHandler() http.Handler {
+	// Resolve task keys before routing, so every task route accepts them
+	if r.taskKeyMiddleware != nil {
+		return r.taskKeyMiddleware.Middleware(r.mux)
+	}
+
 	return r.mux
-}
\ No newline at end of file
+}
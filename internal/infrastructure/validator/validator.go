@@ -1,7 +1,9 @@
 package validator
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
@@ -11,6 +13,15 @@ var validate *validator.Validate
 
 func init() {
 	validate = validator.New()
+
+	// Report fields by their JSON names so messages match the request body
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+		if name == "" || name == "-" {
+			return fld.Name
+		}
+		return name
+	})
 }
 
 // Validate validates a struct
@@ -30,11 +41,25 @@ func Validate(v interface{}) error {
 		case "email":
 			errMessages = append(errMessages, fmt.Sprintf("%s must be a valid email", field))
 		case "min":
-			errMessages = append(errMessages, fmt.Sprintf("%s must be at least %s characters", field, err.Param()))
+			if err.Kind() == reflect.Slice {
+				errMessages = append(errMessages, fmt.Sprintf("%s must contain at least %s items", field, err.Param()))
+			} else {
+				errMessages = append(errMessages, fmt.Sprintf("%s must be at least %s characters", field, err.Param()))
+			}
+		case "max":
+			if err.Kind() == reflect.Slice {
+				errMessages = append(errMessages, fmt.Sprintf("%s must contain at most %s items", field, err.Param()))
+			} else {
+				errMessages = append(errMessages, fmt.Sprintf("%s must be at most %s characters", field, err.Param()))
+			}
+		case "oneof":
+			errMessages = append(errMessages, fmt.Sprintf("%s must be one of: %s", field, err.Param()))
+		case "required_if":
+			errMessages = append(errMessages, fmt.Sprintf("%s is required for this request", field))
 		default:
 			errMessages = append(errMessages, fmt.Sprintf("%s is invalid", field))
 		}
 	}
 
-	return fmt.Errorf(strings.Join(errMessages, ", "))
+	return errors.New(strings.Join(errMessages, ", "))
 }
@@ -0,0 +1,5 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS external_id TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_created_by_id_external_id
+    ON tasks (created_by_id, external_id)
+    WHERE external_id IS NOT NULL;
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS automation_rules (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID NOT NULL DEFAULT uuid_generate_v4() UNIQUE,
+    owner_id UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    trigger VARCHAR(50) NOT NULL,
+    overdue_days INTEGER NOT NULL DEFAULT 0,
+    conditions JSONB NOT NULL DEFAULT '[]',
+    actions JSONB NOT NULL DEFAULT '[]',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_automation_rules_owner_id_trigger ON automation_rules (owner_id, trigger);
+
+CREATE TABLE IF NOT EXISTS automation_executions (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID NOT NULL DEFAULT uuid_generate_v4() UNIQUE,
+    rule_id BIGINT NOT NULL REFERENCES automation_rules(id) ON DELETE CASCADE,
+    task_id UUID NOT NULL,
+    trigger VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    error TEXT,
+    dedupe_key TEXT UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_automation_executions_rule_id ON automation_executions (rule_id, created_at);
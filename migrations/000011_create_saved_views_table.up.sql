@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS saved_views (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID NOT NULL DEFAULT uuid_generate_v4() UNIQUE,
+    owner_id UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    filter JSONB NOT NULL DEFAULT '{}',
+    sort_by VARCHAR(20) NOT NULL DEFAULT 'created_at',
+    sort_desc BOOLEAN NOT NULL DEFAULT TRUE,
+    columns JSONB NOT NULL DEFAULT '[]',
+    pinned BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_views_owner_id ON saved_views (owner_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_saved_views_owner_id_pinned ON saved_views (owner_id) WHERE pinned;
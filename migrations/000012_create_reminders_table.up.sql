@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS reminders (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID NOT NULL DEFAULT uuid_generate_v4() UNIQUE,
+    task_id UUID NOT NULL REFERENCES tasks(uuid) ON DELETE CASCADE,
+    created_by_id UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+    remind_at TIMESTAMP,
+    minutes_before_due INTEGER,
+    recipients JSONB NOT NULL DEFAULT '[]',
+    sent_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((remind_at IS NULL) <> (minutes_before_due IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_reminders_task_id ON reminders (task_id);
+CREATE INDEX IF NOT EXISTS idx_reminders_pending ON reminders (remind_at) WHERE sent_at IS NULL;
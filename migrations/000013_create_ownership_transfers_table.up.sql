@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS ownership_transfers (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID NOT NULL DEFAULT uuid_generate_v4() UNIQUE,
+    task_id UUID NOT NULL REFERENCES tasks(uuid) ON DELETE CASCADE,
+    from_user_id UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+    to_user_id UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+    requested_by_id UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    responded_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_ownership_transfers_to_user_id ON ownership_transfers (to_user_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_ownership_transfers_requested_by_id ON ownership_transfers (requested_by_id) WHERE status = 'pending';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ownership_transfers_task_id_pending ON ownership_transfers (task_id) WHERE status = 'pending';
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS task_approvals;
+DROP TABLE IF EXISTS task_reviewers;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS approval_round;
+ALTER TABLE tasks DROP COLUMN IF EXISTS awaiting_approval;
+ALTER TABLE tasks DROP COLUMN IF EXISTS required_approvals;
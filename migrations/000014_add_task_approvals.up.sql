@@ -0,0 +1,23 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS required_approvals INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS awaiting_approval BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS approval_round INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS task_reviewers (
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS task_approvals (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID NOT NULL DEFAULT uuid_generate_v4() UNIQUE,
+    task_id UUID NOT NULL REFERENCES tasks(uuid) ON DELETE CASCADE,
+    reviewer_id UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+    round INTEGER NOT NULL,
+    decision VARCHAR(20) NOT NULL,
+    comment TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_reviewers_user_id ON task_reviewers (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_approvals_task_id_reviewer_id_round ON task_approvals (task_id, reviewer_id, round);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS task_links (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID NOT NULL DEFAULT uuid_generate_v4() UNIQUE,
+    source_task_id UUID NOT NULL REFERENCES tasks(uuid) ON DELETE CASCADE,
+    target_task_id UUID NOT NULL REFERENCES tasks(uuid) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    created_by_id UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_links_source_task_id_target_task_id_type ON task_links (source_task_id, target_task_id, type);
+CREATE INDEX IF NOT EXISTS idx_task_links_target_task_id ON task_links (target_task_id);
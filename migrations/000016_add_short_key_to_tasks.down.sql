@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_tasks_short_key;
+
+DROP TABLE IF EXISTS task_key_sequences;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS short_key;
@@ -0,0 +1,18 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS short_key TEXT;
+
+CREATE TABLE IF NOT EXISTS task_key_sequences (
+    prefix TEXT PRIMARY KEY,
+    last_number BIGINT NOT NULL
+);
+
+-- Give existing tasks a TASK key, in creation order
+UPDATE tasks SET short_key = 'TASK-' || numbered.number
+FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY id) AS number FROM tasks WHERE short_key IS NULL) AS numbered
+WHERE tasks.id = numbered.id
+    AND NOT EXISTS (SELECT 1 FROM task_key_sequences WHERE prefix = 'TASK');
+
+INSERT INTO task_key_sequences (prefix, last_number)
+SELECT 'TASK', COUNT(*) FROM tasks WHERE short_key LIKE 'TASK-%'
+ON CONFLICT (prefix) DO NOTHING;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_short_key ON tasks (short_key);
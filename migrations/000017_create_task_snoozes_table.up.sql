@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS task_snoozes (
+    id BIGSERIAL PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks(uuid) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+    snoozed_until TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_snoozes_task_id_user_id ON task_snoozes (task_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_task_snoozes_user_id ON task_snoozes (user_id, snoozed_until);
+CREATE INDEX IF NOT EXISTS idx_task_snoozes_snoozed_until ON task_snoozes (snoozed_until);
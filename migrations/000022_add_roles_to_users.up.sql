@@ -0,0 +1,7 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'member';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_at TIMESTAMP;
+
+-- The earliest registered user becomes the admin of a deployment that has none
+UPDATE users SET role = 'admin'
+WHERE id = (SELECT id FROM users WHERE deleted_at IS NULL ORDER BY id LIMIT 1)
+  AND NOT EXISTS (SELECT 1 FROM users WHERE role = 'admin');
@@ -0,0 +1,3 @@
+ALTER TABLE saved_views DROP COLUMN IF EXISTS sort_field_id;
+DROP TABLE IF EXISTS task_field_values;
+DROP TABLE IF EXISTS custom_fields;
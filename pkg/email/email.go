@@ -71,4 +71,28 @@ func (s *EmailService) SendRegistrationEmail(to, name string) error {
 	return s.SendTemplateEmail(to, "Welcome to Task App", templatePath, map[string]string{
 		"Name": name,
 	})
-}
\ No newline at end of file
+}
+
+// SendVerificationEmail sends an email with a link that verifies the address
+func (s *EmailService) SendVerificationEmail(to, name, link string) error {
+	// Get template path
+	templatePath := filepath.Join("templates", "verification_email_template.html")
+	
+	// Send email
+	return s.SendTemplateEmail(to, "Verify your email address", templatePath, map[string]string{
+		"Name": name,
+		"Link": link,
+	})
+}
+
+// SendPasswordResetEmail sends an email with a single-use link to set a new password
+func (s *EmailService) SendPasswordResetEmail(to, name, link string) error {
+	// Get template path
+	templatePath := filepath.Join("templates", "password_reset_email_template.html")
+	
+	// Send email
+	return s.SendTemplateEmail(to, "Reset your password", templatePath, map[string]string{
+		"Name": name,
+		"Link": link,
+	})
+}
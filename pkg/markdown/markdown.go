@@ -0,0 +1,83 @@
+package markdown
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// DefaultAllowedTags lists the HTML elements kept in rendered markdown by default
+var DefaultAllowedTags = []string{
+	"p", "br", "hr", "h1", "h2", "h3", "h4", "h5", "h6",
+	"strong", "em", "del", "code", "pre", "blockquote",
+	"ul", "ol", "li", "a", "img", "input",
+	"table", "thead", "tbody", "tr", "th", "td",
+}
+
+// DefaultAllowedAttributes lists the attributes kept in rendered markdown by default.
+// Each entry is either "element:attribute" or a bare attribute allowed on every element.
+var DefaultAllowedAttributes = []string{
+	"a:href", "a:title",
+	"img:src", "img:alt", "img:title",
+	"input:type", "input:checked", "input:disabled",
+	"th:align", "td:align",
+	"ol:start",
+}
+
+// Renderer converts markdown to sanitized HTML
+type Renderer struct {
+	markdown goldmark.Markdown
+	policy   *bluemonday.Policy
+}
+
+// NewRenderer creates a renderer that keeps only the given tags and attributes.
+// Empty lists fall back to DefaultAllowedTags and DefaultAllowedAttributes.
+func NewRenderer(allowedTags, allowedAttributes []string) *Renderer {
+	if len(allowedTags) == 0 {
+		allowedTags = DefaultAllowedTags
+	}
+	if len(allowedAttributes) == 0 {
+		allowedAttributes = DefaultAllowedAttributes
+	}
+	
+	// Links may only use http, https and mailto and get rel="nofollow"
+	policy := bluemonday.NewPolicy()
+	policy.AllowStandardURLs()
+	policy.AllowElements(allowedTags...)
+	for _, attribute := range allowedAttributes {
+		if element, name, ok := strings.Cut(attribute, ":"); ok {
+			policy.AllowAttrs(name).OnElements(element)
+		} else {
+			policy.AllowAttrs(attribute).Globally()
+		}
+	}
+	
+	return &Renderer{
+		markdown: goldmark.New(goldmark.WithExtensions(extension.GFM)),
+		policy:   policy,
+	}
+}
+
+// Render converts markdown to HTML containing only allow-listed tags and attributes.
+// Raw HTML in the source is dropped rather than passed through.
+func (r *Renderer) Render(source string) string {
+	if source == "" {
+		return ""
+	}
+	
+	var buf bytes.Buffer
+	if err := r.markdown.Convert([]byte(source), &buf); err != nil {
+		// Fall back to the escaped source so the description is never lost
+		return r.policy.Sanitize("<p>" + escapeText(source) + "</p>")
+	}
+	
+	return r.policy.Sanitize(buf.String())
+}
+
+// escapeText escapes text for inclusion in HTML
+func escapeText(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(text)
+}
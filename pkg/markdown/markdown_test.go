@@ -0,0 +1,89 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderStripsXSS(t *testing.T) {
+	renderer := NewRenderer(nil, nil)
+
+	tests := []struct {
+		name      string
+		source    string
+		forbidden []string
+	}{
+		{"script tag", "<script>alert(1)</script>", []string{"<script", "alert(1)"}},
+		{"javascript link", "[x](javascript:alert(1))", []string{"href", "javascript:"}},
+		{"mixed case javascript link", "[x](JaVaScRiPt:alert(1))", []string{"href", "javascript:"}},
+		{"entity encoded javascript link", "[x](&#106;avascript:alert(1))", []string{"href", "avascript:"}},
+		{"vbscript link", "[x](vbscript:msgbox(1))", []string{"href", "vbscript:"}},
+		{"data link", "[x](data:text/html;base64,PHNjcmlwdD4=)", []string{"href", "data:"}},
+		{"javascript image", "![x](javascript:alert(1))", []string{"src", "javascript:"}},
+		{"raw img with handler", "<img src=x onerror=alert(1)>", []string{"<img", "onerror"}},
+		{"raw anchor", `<a href="javascript:alert(1)">x</a>`, []string{"href", "javascript:"}},
+		{"iframe", `<iframe src="https://evil.example"></iframe>`, []string{"<iframe", "evil.example"}},
+		{"svg with handler", "<svg onload=alert(1)>", []string{"<svg", "onload"}},
+		{"style attribute", `<div style="background:url(javascript:alert(1))">x</div>`, []string{"style", "javascript:"}},
+		{"attribute breakout", `[x](http://a" onmouseover="alert(1))`, []string{"href", `onmouseover="`}},
+		{"task list item with handler", "- [ ] <img src=x onerror=alert(1)>", []string{"<img", "onerror"}},
+		{"task list item with javascript link", "- [x] [x](javascript:alert(1))", []string{"href", "javascript:"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html := strings.ToLower(renderer.Render(tt.source))
+			for _, forbidden := range tt.forbidden {
+				if strings.Contains(html, strings.ToLower(forbidden)) {
+					t.Errorf("Render(%q) = %q, must not contain %q", tt.source, html, forbidden)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderKeepsSafeMarkdown(t *testing.T) {
+	renderer := NewRenderer(nil, nil)
+
+	tests := []struct {
+		name   string
+		source string
+		want   []string
+	}{
+		{"emphasis", "**bold** and *em*", []string{"<strong>bold</strong>", "<em>em</em>"}},
+		{"https link gets nofollow", "[ok](https://example.com)", []string{`href="https://example.com"`, `rel="nofollow"`}},
+		{"mailto link", "[mail](mailto:a@example.com)", []string{`href="mailto:a@example.com"`}},
+		{"checked task list item", "- [x] done", []string{`<input checked="" disabled="" type="checkbox"`, "done"}},
+		{"unchecked task list item", "- [ ] todo", []string{`<input disabled="" type="checkbox"`, "todo"}},
+		{"html in code is escaped", "`<script>`", []string{"<code>&lt;script&gt;</code>"}},
+		{"html in fenced code is escaped", "```\n<script>alert(1)</script>\n```", []string{"&lt;script&gt;alert(1)&lt;/script&gt;"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html := renderer.Render(tt.source)
+			for _, want := range tt.want {
+				if !strings.Contains(html, want) {
+					t.Errorf("Render(%q) = %q, want it to contain %q", tt.source, html, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderNeverAllowsScriptOrStyle(t *testing.T) {
+	// Even an allow-list that names script and style must not let them through
+	renderer := NewRenderer([]string{"p", "a", "script", "style"}, []string{"a:href"})
+
+	for _, source := range []string{"<script>alert(1)</script>", "<style>body{display:none}</style>"} {
+		if html := renderer.Render(source); strings.Contains(html, "<script") || strings.Contains(html, "<style") {
+			t.Errorf("Render(%q) = %q, want script and style removed", source, html)
+		}
+	}
+}
+
+func TestRenderEmpty(t *testing.T) {
+	if html := NewRenderer(nil, nil).Render(""); html != "" {
+		t.Errorf("Render(\"\") = %q, want empty", html)
+	}
+}
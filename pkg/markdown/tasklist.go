@@ -0,0 +1,90 @@
+package markdown
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ErrTaskListItemNotFound is returned when a task list item index is out of range
+var ErrTaskListItemNotFound = errors.New("task list item not found")
+
+// taskListItemPattern matches GitHub-style task list items such as "- [ ] write docs" or "1. [x] ship"
+var taskListItemPattern = regexp.MustCompile(`^(\s*(?:[-*+]|\d+[.)])\s+\[)([ xX])(\]\s+)(.*)$`)
+
+// fencePattern matches the opening or closing line of a fenced code block
+var fencePattern = regexp.MustCompile("^\\s*(```|~~~)")
+
+// TaskListItem is a checkbox item in a markdown task list
+type TaskListItem struct {
+	Index   int    `json:"index"`
+	Line    int    `json:"line"`
+	Text    string `json:"text"`
+	Checked bool   `json:"checked"`
+}
+
+// ParseTaskList returns the task list items in markdown source, in document order.
+// Items inside fenced code blocks are ignored.
+func ParseTaskList(source string) []TaskListItem {
+	var items []TaskListItem
+	
+	forEachTaskListLine(source, func(line int, match []string) {
+		items = append(items, TaskListItem{
+			Index:   len(items),
+			Line:    line + 1,
+			Text:    strings.TrimSpace(match[4]),
+			Checked: match[2] != " ",
+		})
+	})
+	
+	return items
+}
+
+// SetTaskListItem checks or unchecks the task list item at index and returns the updated source
+func SetTaskListItem(source string, index int, checked bool) (string, error) {
+	lines := strings.Split(source, "\n")
+	found := false
+	
+	count := 0
+	forEachTaskListLine(source, func(line int, match []string) {
+		if count == index {
+			mark := " "
+			if checked {
+				mark = "x"
+			}
+			// Keep the carriage return of CRLF line endings
+			ending := ""
+			if strings.HasSuffix(lines[line], "\r") {
+				ending = "\r"
+			}
+			lines[line] = match[1] + mark + match[3] + match[4] + ending
+			found = true
+		}
+		count++
+	})
+	
+	if !found {
+		return "", ErrTaskListItemNotFound
+	}
+	
+	return strings.Join(lines, "\n"), nil
+}
+
+// forEachTaskListLine calls fn with the zero-based line number and submatches of each task list item outside code blocks
+func forEachTaskListLine(source string, fn func(line int, match []string)) {
+	inFence := false
+	
+	for i, line := range strings.Split(source, "\n") {
+		if fencePattern.MatchString(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		
+		if match := taskListItemPattern.FindStringSubmatch(strings.TrimSuffix(line, "\r")); match != nil {
+			fn(i, match)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package markdown
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTaskList(t *testing.T) {
+	source := "# Plan\n- [ ] write docs\n* [x] ship\n1. [X] tag release\n\n```\n- [ ] not an item\n```\n+ [ ]   trim me  \n- plain item"
+
+	want := []TaskListItem{
+		{Index: 0, Line: 2, Text: "write docs", Checked: false},
+		{Index: 1, Line: 3, Text: "ship", Checked: true},
+		{Index: 2, Line: 4, Text: "tag release", Checked: true},
+		{Index: 3, Line: 9, Text: "trim me", Checked: false},
+	}
+
+	if got := ParseTaskList(source); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseTaskList() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSetTaskListItem(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		index   int
+		checked bool
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "check item",
+			source:  "- [ ] a\n- [ ] b",
+			index:   1,
+			checked: true,
+			want:    "- [ ] a\n- [x] b",
+		},
+		{
+			name:    "uncheck item",
+			source:  "1. [X] a",
+			index:   0,
+			checked: false,
+			want:    "1. [ ] a",
+		},
+		{
+			name:    "keeps CRLF line endings",
+			source:  "- [ ] a\r\n- [ ] b\r\n- [ ] c\r\n",
+			index:   1,
+			checked: true,
+			want:    "- [ ] a\r\n- [x] b\r\n- [ ] c\r\n",
+		},
+		{
+			name:    "skips items in code blocks",
+			source:  "```\n- [ ] code\n```\n- [ ] real",
+			index:   0,
+			checked: true,
+			want:    "```\n- [ ] code\n```\n- [x] real",
+		},
+		{
+			name:    "keeps item text byte for byte",
+			source:  "- [ ] <img src=x onerror=alert(1)>",
+			index:   0,
+			checked: true,
+			want:    "- [x] <img src=x onerror=alert(1)>",
+		},
+		{
+			name:    "index out of range",
+			source:  "- [ ] a",
+			index:   1,
+			checked: true,
+			wantErr: ErrTaskListItemNotFound,
+		},
+		{
+			name:    "negative index",
+			source:  "- [ ] a",
+			index:   -1,
+			checked: true,
+			wantErr: ErrTaskListItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SetTaskListItem(tt.source, tt.index, tt.checked)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetTaskListItem() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SetTaskListItem() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetTaskListItemRendersSafely(t *testing.T) {
+	// Toggling an item must not turn its text into live HTML
+	source, err := SetTaskListItem("- [ ] <img src=x onerror=alert(1)>\n- [ ] [x](javascript:alert(1))", 0, true)
+	if err != nil {
+		t.Fatalf("SetTaskListItem() error = %v", err)
+	}
+
+	html := strings.ToLower(NewRenderer(nil, nil).Render(source))
+	for _, forbidden := range []string{"<img", "onerror", "javascript:"} {
+		if strings.Contains(html, forbidden) {
+			t.Errorf("rendered %q, must not contain %q", html, forbidden)
+		}
+	}
+	if !strings.Contains(html, `<input checked="" disabled="" type="checkbox"`) {
+		t.Errorf("rendered %q, want the first item checked", html)
+	}
+}
@@ -0,0 +1,361 @@
+package quickadd
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Priorities recognised after a "!"
+const (
+	PriorityUrgent = "urgent"
+	PriorityHigh   = "high"
+	PriorityMedium = "medium"
+	PriorityLow    = "low"
+)
+
+// DefaultDueHour is the hour of day, in the user's timezone, of due dates given without a time
+const DefaultDueHour = 17
+
+// tonightHour is the hour of day "tonight" is due at, unless a time is given
+const tonightHour = 20
+
+// Result is the interpretation of a quick-add line
+type Result struct {
+	Title    string
+	DueDate  *time.Time
+	Labels   []string
+	Priority string
+	Mentions []string
+}
+
+// priorities maps the words accepted after "!" to priorities
+var priorities = map[string]string{
+	"urgent": PriorityUrgent,
+	"high":   PriorityHigh,
+	"medium": PriorityMedium,
+	"med":    PriorityMedium,
+	"normal": PriorityMedium,
+	"low":    PriorityLow,
+}
+
+// weekdays maps full and short day names to weekdays; "sun" and "sat" are left out as they are common words
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday,
+}
+
+// months maps full and short month names to months
+var months = map[string]time.Month{
+	"january": time.January, "jan": time.January,
+	"february": time.February, "feb": time.February,
+	"march": time.March, "mar": time.March,
+	"april": time.April, "apr": time.April,
+	"may":  time.May,
+	"june": time.June, "jun": time.June,
+	"july": time.July, "jul": time.July,
+	"august": time.August, "aug": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October,
+	"november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December,
+}
+
+// connectors are words dropped from the title when they introduce a date or time, as in "at 5pm"
+var connectors = map[string]bool{"at": true, "on": true, "by": true, "due": true}
+
+// clockPattern matches times such as 5pm, 5:30pm and 17:00
+var clockPattern = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)?$`)
+
+// dayOfMonthPattern matches days of the month such as 1, 1st and 22nd
+var dayOfMonthPattern = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?$`)
+
+// parser holds the state of parsing one line
+type parser struct {
+	now     time.Time // the current time in the user's timezone
+	date    *time.Time
+	clock   *[2]int
+	exact   *time.Time
+	tonight bool // the date was given as "tonight"
+}
+
+// Parse interprets a quick-add line such as "Send invoice to ACME tomorrow 5pm #finance !high @sara".
+// #words are labels, !urgent, !high, !medium and !low set the priority, and @handles are mentions.
+// The first date and the first time of day set the due date, read relative to now in loc; a date
+// without a time is due at DefaultDueHour and a time without a date is due at its next occurrence.
+// Everything else, in order, is the title.
+func Parse(text string, now time.Time, loc *time.Location) Result {
+	p := &parser{now: now.In(loc)}
+	result := Result{}
+
+	var title []string
+	tokens := strings.Fields(text)
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		word := strings.TrimRight(token, ",.;:")
+
+		switch {
+		case len(word) > 1 && word[0] == '#':
+			result.Labels = appendUnique(result.Labels, word[1:])
+			continue
+		case len(word) > 1 && word[0] == '!' && priorities[strings.ToLower(word[1:])] != "" && result.Priority == "":
+			result.Priority = priorities[strings.ToLower(word[1:])]
+			continue
+		case len(word) > 1 && word[0] == '@':
+			result.Mentions = appendUnique(result.Mentions, word[1:])
+			continue
+		}
+
+		// Dates and times, optionally introduced by a connector such as "at"
+		if n := p.match(tokens[i:]); n > 0 {
+			i += n - 1
+			continue
+		}
+		if connectors[strings.ToLower(word)] {
+			if n := p.match(tokens[i+1:]); n > 0 {
+				i += n
+				continue
+			}
+		}
+
+		title = append(title, token)
+	}
+
+	result.Title = strings.Join(title, " ")
+	result.DueDate = p.dueDate()
+	return result
+}
+
+// match consumes a date or time expression at the start of tokens, returning the number of tokens used
+func (p *parser) match(tokens []string) int {
+	if len(tokens) == 0 {
+		return 0
+	}
+
+	words := make([]string, 0, 3)
+	for _, token := range tokens[:min(len(tokens), 3)] {
+		words = append(words, strings.ToLower(strings.TrimRight(token, ",.;:")))
+	}
+	today := time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+
+	if p.date == nil && p.exact == nil {
+		// Relative expressions
+		if len(words) >= 3 && words[0] == "in" {
+			if n, err := strconv.Atoi(words[1]); err == nil && n > 0 {
+				switch strings.TrimSuffix(words[2], "s") {
+				case "day":
+					p.setDate(today.AddDate(0, 0, n))
+					return 3
+				case "week":
+					p.setDate(today.AddDate(0, 0, 7*n))
+					return 3
+				case "month":
+					p.setDate(today.AddDate(0, n, 0))
+					return 3
+				case "hour", "hr":
+					p.setExact(p.now.Add(time.Duration(n) * time.Hour))
+					return 3
+				case "minute", "min":
+					p.setExact(p.now.Add(time.Duration(n) * time.Minute))
+					return 3
+				}
+			}
+		}
+
+		if len(words) >= 2 && words[0] == "next" {
+			switch words[1] {
+			case "week":
+				p.setDate(today.AddDate(0, 0, daysUntil(today.Weekday(), time.Monday, true)))
+				return 2
+			case "month":
+				p.setDate(time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location()))
+				return 2
+			}
+			if weekday, ok := weekdays[words[1]]; ok {
+				p.setDate(today.AddDate(0, 0, daysUntil(today.Weekday(), weekday, true)))
+				return 2
+			}
+		}
+
+		// Month and day, in either order
+		if len(words) >= 2 {
+			if date, ok := monthDay(words[0], words[1], today); ok {
+				p.setDate(date)
+				return 2
+			}
+			if date, ok := monthDay(words[1], words[0], today); ok {
+				p.setDate(date)
+				return 2
+			}
+		}
+
+		switch words[0] {
+		case "today":
+			p.setDate(today)
+			return 1
+		case "tonight":
+			p.setDate(today)
+			p.tonight = true
+			return 1
+		case "tomorrow", "tmrw":
+			p.setDate(today.AddDate(0, 0, 1))
+			return 1
+		}
+		if weekday, ok := weekdays[words[0]]; ok {
+			p.setDate(today.AddDate(0, 0, daysUntil(today.Weekday(), weekday, false)))
+			return 1
+		}
+		if date, err := time.ParseInLocation("2006-01-02", words[0], today.Location()); err == nil {
+			p.setDate(date)
+			return 1
+		}
+	}
+
+	if p.clock == nil && p.exact == nil {
+		switch words[0] {
+		case "noon":
+			p.clock = &[2]int{12, 0}
+			return 1
+		case "midnight":
+			p.clock = &[2]int{0, 0}
+			return 1
+		}
+
+		// A time such as 5pm or 17:00, or a number followed by am or pm
+		if len(words) >= 2 && (words[1] == "am" || words[1] == "pm") {
+			if clock, ok := parseClock(words[0] + words[1]); ok {
+				p.clock = &clock
+				return 2
+			}
+		}
+		if clock, ok := parseClock(words[0]); ok {
+			p.clock = &clock
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// setDate sets the due date, keeping any time of day already found
+func (p *parser) setDate(date time.Time) {
+	p.date = &date
+}
+
+// setExact sets an exact due time, as in "in 2 hours"
+func (p *parser) setExact(due time.Time) {
+	p.exact = &due
+}
+
+// dueDate combines the date and time of day found into a due date
+func (p *parser) dueDate() *time.Time {
+	if p.exact != nil {
+		return p.exact
+	}
+	if p.date == nil && p.clock == nil {
+		return nil
+	}
+
+	clock := [2]int{DefaultDueHour, 0}
+	if p.tonight {
+		clock = [2]int{tonightHour, 0}
+	}
+	if p.clock != nil {
+		clock = *p.clock
+	}
+
+	date := time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+	if p.date != nil {
+		date = *p.date
+	}
+
+	due := time.Date(date.Year(), date.Month(), date.Day(), clock[0], clock[1], 0, 0, date.Location())
+
+	// A time on its own means its next occurrence
+	if p.date == nil && !due.After(p.now) {
+		due = due.AddDate(0, 0, 1)
+	}
+
+	return &due
+}
+
+// parseClock parses a time of day such as 5pm, 5:30pm or 17:00
+func parseClock(word string) ([2]int, bool) {
+	match := clockPattern.FindStringSubmatch(word)
+	if match == nil || (match[2] == "" && match[3] == "") {
+		return [2]int{}, false
+	}
+
+	hour, _ := strconv.Atoi(match[1])
+	minute := 0
+	if match[2] != "" {
+		minute, _ = strconv.Atoi(match[2])
+	}
+
+	switch match[3] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return [2]int{}, false
+		}
+		hour %= 12
+		if match[3] == "pm" {
+			hour += 12
+		}
+	}
+
+	if hour > 23 || minute > 59 {
+		return [2]int{}, false
+	}
+
+	return [2]int{hour, minute}, true
+}
+
+// monthDay parses a month name and day of the month as their next occurrence on or after today
+func monthDay(monthWord string, dayWord string, today time.Time) (time.Time, bool) {
+	month, ok := months[monthWord]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	match := dayOfMonthPattern.FindStringSubmatch(dayWord)
+	if match == nil {
+		return time.Time{}, false
+	}
+	day, _ := strconv.Atoi(match[1])
+
+	date := time.Date(today.Year(), month, day, 0, 0, 0, 0, today.Location())
+	if date.Month() != month || day == 0 {
+		return time.Time{}, false
+	}
+	if date.Before(today) {
+		date = date.AddDate(1, 0, 0)
+	}
+
+	return date, true
+}
+
+// daysUntil counts the days from one weekday to the next occurrence of another.
+// The same weekday counts as today, unless strict is set, in which case it is a week away.
+func daysUntil(from time.Weekday, to time.Weekday, strict bool) int {
+	days := (int(to) - int(from) + 7) % 7
+	if days == 0 && strict {
+		days = 7
+	}
+	return days
+}
+
+// appendUnique appends value to values unless it is already there, ignoring case
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if strings.EqualFold(existing, value) {
+			return values
+		}
+	}
+	return append(values, value)
+}
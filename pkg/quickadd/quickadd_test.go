@@ -0,0 +1,238 @@
+package quickadd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// wednesday is the time the tests parse relative to: Wednesday 11 June 2025, 10:00
+var wednesday = time.Date(2025, time.June, 11, 10, 0, 0, 0, time.UTC)
+
+func TestParseDueDate(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		wantTitle string
+		wantDue   string // in UTC, "" for no due date
+	}{
+		// Relative dates; a date without a time is due at 17:00
+		{"today", "call mom today", "call mom", "2025-06-11 17:00"},
+		{"tonight", "movie tonight", "movie", "2025-06-11 20:00"},
+		{"tonight keeps a time", "movie tonight 9pm", "movie", "2025-06-11 21:00"},
+		{"tomorrow", "send invoice tomorrow", "send invoice", "2025-06-12 17:00"},
+		{"tmrw", "send invoice tmrw", "send invoice", "2025-06-12 17:00"},
+		{"in days", "report in 3 days", "report", "2025-06-14 17:00"},
+		{"in one day", "report in 1 day", "report", "2025-06-12 17:00"},
+		{"in weeks", "review in 2 weeks", "review", "2025-06-25 17:00"},
+		{"in months", "renew in 1 month", "renew", "2025-07-11 17:00"},
+		{"in hours", "ping in 2 hours", "ping", "2025-06-11 12:00"},
+		{"in minutes", "ping in 30 minutes", "ping", "2025-06-11 10:30"},
+		{"in zero days is not a date", "wait in 0 days", "wait in 0 days", ""},
+		{"next week is next monday", "plan next week", "plan", "2025-06-16 17:00"},
+		{"next month is its first day", "pay rent next month", "pay rent", "2025-07-01 17:00"},
+		{"weekday later this week", "gym friday", "gym", "2025-06-13 17:00"},
+		{"weekday today", "gym wednesday", "gym", "2025-06-11 17:00"},
+		{"short weekday", "gym mon", "gym", "2025-06-16 17:00"},
+		{"next weekday skips today", "gym next wednesday", "gym", "2025-06-18 17:00"},
+		{"iso date", "taxes 2025-07-31", "taxes", "2025-07-31 17:00"},
+		{"only the first date counts", "x tomorrow friday", "x friday", "2025-06-12 17:00"},
+		{"no date", "just a title", "just a title", ""},
+
+		// Month and day, in either order; past dates roll over to next year
+		{"month then day", "party june 20", "party", "2025-06-20 17:00"},
+		{"day then month", "party 20 june", "party", "2025-06-20 17:00"},
+		{"ordinal day", "party june 20th", "party", "2025-06-20 17:00"},
+		{"ordinal day first", "trip 1st jul", "trip", "2025-07-01 17:00"},
+		{"past date is next year", "party jan 5", "party", "2026-01-05 17:00"},
+		{"past date day first", "party 5 jan", "party", "2026-01-05 17:00"},
+		{"today's date is today", "party jun 11", "party", "2025-06-11 17:00"},
+		{"invalid day of month", "party feb 30", "party feb 30", ""},
+		{"day zero", "party jan 0", "party jan 0", ""},
+
+		// Times of day; a time without a date is due at its next occurrence
+		{"time later today", "call 5pm", "call", "2025-06-11 17:00"},
+		{"time already passed", "standup 9am", "standup", "2025-06-12 09:00"},
+		{"time with minutes", "call 5:30pm", "call", "2025-06-11 17:30"},
+		{"24 hour time", "call 17:00", "call", "2025-06-11 17:00"},
+		{"am and pm as a separate word", "call tomorrow 5 pm", "call", "2025-06-12 17:00"},
+		{"noon", "lunch noon", "lunch", "2025-06-11 12:00"},
+		{"midnight", "deploy midnight", "deploy", "2025-06-12 00:00"},
+		{"date after time", "call 9am tomorrow", "call", "2025-06-12 09:00"},
+		{"12am is midnight", "x tomorrow 12am", "x", "2025-06-12 00:00"},
+		{"12pm is noon", "x tomorrow 12pm", "x", "2025-06-12 12:00"},
+		{"12:30am", "x tomorrow 12:30am", "x", "2025-06-12 00:30"},
+		{"1am", "x tomorrow 1am", "x", "2025-06-12 01:00"},
+		{"11:59pm", "x tomorrow 11:59pm", "x", "2025-06-12 23:59"},
+		{"13pm is not a time", "x tomorrow 13pm", "x 13pm", "2025-06-12 17:00"},
+		{"0am is not a time", "x tomorrow 0am", "x 0am", "2025-06-12 17:00"},
+		{"24:00 is not a time", "x tomorrow 24:00", "x 24:00", "2025-06-12 17:00"},
+		{"bad minutes", "x tomorrow 5:60pm", "x 5:60pm", "2025-06-12 17:00"},
+		{"bare number is not a time", "buy 5 apples", "buy 5 apples", ""},
+
+		// Connectors are dropped only when they introduce a date or time
+		{"at time", "meet Bob at 3pm", "meet Bob", "2025-06-11 15:00"},
+		{"due date", "pay bills due friday", "pay bills", "2025-06-13 17:00"},
+		{"by date", "finish report by tomorrow", "finish report", "2025-06-12 17:00"},
+		{"on month day", "dinner on jun 20", "dinner", "2025-06-20 17:00"},
+		{"connector in any case", "dinner On friday", "dinner", "2025-06-13 17:00"},
+		{"connector without a date", "work at home", "work at home", ""},
+		{"connector at the end", "look it up at", "look it up at", ""},
+
+		// Punctuation after dates is ignored
+		{"trailing comma", "tomorrow, call Sam.", "call Sam.", "2025-06-12 17:00"},
+		{"upper case", "Call TOMORROW 5PM", "Call", "2025-06-12 17:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Parse(tt.text, wednesday, time.UTC)
+
+			if result.Title != tt.wantTitle {
+				t.Errorf("Parse(%q).Title = %q, want %q", tt.text, result.Title, tt.wantTitle)
+			}
+			assertDue(t, tt.text, result.DueDate, tt.wantDue, time.UTC)
+		})
+	}
+}
+
+func TestParseDueDateAcrossDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone data not available: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		text    string
+		wantDue string // in Europe/Berlin
+	}{
+		// Clocks go forward at 02:00 on 30 March 2025 and back at 03:00 on 26 October 2025
+		{"tomorrow after spring forward", time.Date(2025, time.March, 29, 12, 0, 0, 0, berlin), "x tomorrow", "2025-03-30 17:00"},
+		{"in days after spring forward", time.Date(2025, time.March, 29, 12, 0, 0, 0, berlin), "x in 1 day 9am", "2025-03-30 09:00"},
+		{"next week over spring forward", time.Date(2025, time.March, 26, 12, 0, 0, 0, berlin), "x next week", "2025-03-31 17:00"},
+		{"hours are elapsed time over spring forward", time.Date(2025, time.March, 30, 1, 30, 0, 0, berlin), "x in 1 hour", "2025-03-30 03:30"},
+		{"tomorrow after fall back", time.Date(2025, time.October, 25, 12, 0, 0, 0, berlin), "x tomorrow 9am", "2025-10-26 09:00"},
+		{"hours are elapsed time over fall back", time.Date(2025, time.October, 26, 1, 30, 0, 0, berlin), "x in 2 hours", "2025-10-26 02:30"},
+		{"time passed today across fall back", time.Date(2025, time.October, 25, 20, 0, 0, 0, berlin), "x 8am", "2025-10-26 08:00"},
+
+		// Days are the user's days, not UTC days
+		{"today is the local day", time.Date(2025, time.June, 11, 23, 30, 0, 0, time.UTC), "x today", "2025-06-12 17:00"},
+		{"time later in the local day", time.Date(2025, time.June, 11, 23, 30, 0, 0, time.UTC), "x 9am", "2025-06-12 09:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Parse(tt.text, tt.now, berlin)
+			assertDue(t, tt.text, result.DueDate, tt.wantDue, berlin)
+		})
+	}
+
+	// Elapsed hours are exact even when the wall clock jumps
+	now := time.Date(2025, time.October, 26, 1, 30, 0, 0, berlin)
+	if due := Parse("x in 2 hours", now, berlin).DueDate; due == nil || due.Sub(now) != 2*time.Hour {
+		t.Errorf("Parse(\"x in 2 hours\") = %v, want 2 hours after %v", due, now)
+	}
+}
+
+func TestParseTokens(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		wantTitle    string
+		wantLabels   []string
+		wantPriority string
+		wantMentions []string
+	}{
+		{
+			name:         "everything",
+			text:         "Send invoice to ACME tomorrow 5pm #finance !high @sara",
+			wantTitle:    "Send invoice to ACME",
+			wantLabels:   []string{"finance"},
+			wantPriority: PriorityHigh,
+			wantMentions: []string{"sara"},
+		},
+		{
+			name:         "first priority wins and duplicates are dropped ignoring case",
+			text:         "x !HIGH !low #a #A @bob @Bob",
+			wantTitle:    "x !low",
+			wantLabels:   []string{"a"},
+			wantPriority: PriorityHigh,
+			wantMentions: []string{"bob"},
+		},
+		{
+			name:         "priority aliases",
+			text:         "x !med",
+			wantTitle:    "x",
+			wantPriority: PriorityMedium,
+		},
+		{
+			name:      "unknown priority stays in the title",
+			text:      "wow !nope",
+			wantTitle: "wow !nope",
+		},
+		{
+			name:      "lone markers stay in the title",
+			text:      "a # b ! c @",
+			wantTitle: "a # b ! c @",
+		},
+		{
+			name:         "email mention",
+			text:         "x @sara@example.com",
+			wantTitle:    "x",
+			wantMentions: []string{"sara@example.com"},
+		},
+		{
+			name:         "trailing punctuation is not part of a tag",
+			text:         "x #ops, @sam.",
+			wantTitle:    "x",
+			wantLabels:   []string{"ops"},
+			wantMentions: []string{"sam"},
+		},
+		{
+			name:      "extra spaces are collapsed",
+			text:      "  spaced   out  ",
+			wantTitle: "spaced out",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Parse(tt.text, wednesday, time.UTC)
+
+			if result.Title != tt.wantTitle {
+				t.Errorf("Title = %q, want %q", result.Title, tt.wantTitle)
+			}
+			if !reflect.DeepEqual(result.Labels, tt.wantLabels) {
+				t.Errorf("Labels = %q, want %q", result.Labels, tt.wantLabels)
+			}
+			if result.Priority != tt.wantPriority {
+				t.Errorf("Priority = %q, want %q", result.Priority, tt.wantPriority)
+			}
+			if !reflect.DeepEqual(result.Mentions, tt.wantMentions) {
+				t.Errorf("Mentions = %q, want %q", result.Mentions, tt.wantMentions)
+			}
+		})
+	}
+}
+
+// assertDue checks a parsed due date against a "2006-01-02 15:04" time in loc, or "" for none
+func assertDue(t *testing.T, text string, due *time.Time, want string, loc *time.Location) {
+	t.Helper()
+
+	if want == "" {
+		if due != nil {
+			t.Errorf("Parse(%q).DueDate = %v, want none", text, due)
+		}
+		return
+	}
+
+	wantDue, err := time.ParseInLocation("2006-01-02 15:04", want, loc)
+	if err != nil {
+		t.Fatalf("bad want %q: %v", want, err)
+	}
+	if due == nil || !due.Equal(wantDue) {
+		t.Errorf("Parse(%q).DueDate = %v, want %v", text, due, wantDue)
+	}
+}
@@ -3,14 +3,29 @@ package utils
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 // UserUUIDKey is the context key for the user UUID
 type contextKey string
+
 const UserUUIDKey contextKey = "userUUID"
 
+// AccessTokenKey is the context key for the access token that authenticated the request
+const AccessTokenKey contextKey = "accessToken"
+
+// AccessToken identifies the access token that authenticated a request
+type AccessToken struct {
+	ID        uuid.UUID
+	SessionID uuid.UUID // the refresh token family of the session, or uuid.Nil if unknown
+	ExpiresAt time.Time
+}
+
+// TokenScopesKey is the context key for the scopes of the personal access token that authenticated the request
+const TokenScopesKey contextKey = "tokenScopes"
+
 // GetUserUUIDFromRequest gets the user UUID from the request context
 func GetUserUUIDFromRequest(r *http.Request) uuid.UUID {
 	// Get user UUID from context
@@ -31,4 +46,21 @@ func GetUserUUIDFromContext(ctx context.Context) uuid.UUID {
 	}
 	
 	return userUUID
-}
\ No newline at end of file
+}
+
+// GetAccessTokenFromRequest gets the access token that authenticated the request, or nil if there is none
+func GetAccessTokenFromRequest(r *http.Request) *AccessToken {
+	accessToken, ok := r.Context().Value(AccessTokenKey).(*AccessToken)
+	if !ok {
+		return nil
+	}
+	
+	return accessToken
+}
+
+// GetTokenScopesFromContext gets the scopes of the personal access token that authenticated the request.
+// It reports false for requests authenticated by a login session, which are not limited by scopes.
+func GetTokenScopesFromContext(ctx context.Context) ([]string, bool) {
+	scopes, ok := ctx.Value(TokenScopesKey).([]string)
+	return scopes, ok
+}
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"context"
+	"strconv"
+)
+
+// ExpectedVersionsKey is the context key for the versions accepted by an If-Match precondition
+const ExpectedVersionsKey contextKey = "expectedVersions"
+
+// WithExpectedVersions returns a context carrying the versions accepted by an If-Match precondition
+func WithExpectedVersions(ctx context.Context, versions []int64) context.Context {
+	return context.WithValue(ctx, ExpectedVersionsKey, versions)
+}
+
+// GetExpectedVersionsFromContext gets the versions accepted by an If-Match precondition.
+// The second result is false if the request has no precondition, including when it was cleared with nil versions.
+func GetExpectedVersionsFromContext(ctx context.Context) ([]int64, bool) {
+	versions, ok := ctx.Value(ExpectedVersionsKey).([]int64)
+	return versions, ok && versions != nil
+}
+
+// ETag formats a version as a strong entity tag
+func ETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ParseETag parses a strong entity tag produced by ETag
+func ParseETag(tag string) (int64, bool) {
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false
+	}
+	
+	version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	
+	return version, true
+}